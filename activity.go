@@ -0,0 +1,226 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	maxActividadesPorTienda = 50
+	muestrasDuracion        = 5
+)
+
+type Actividad struct {
+	Accion     string    `json:"accion"`
+	Inicio     time.Time `json:"inicio"`
+	DuracionMs int64     `json:"duracion_ms"`
+	Codigo     int       `json:"codigo"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func (a Actividad) Duracion() time.Duration {
+	return time.Duration(a.DuracionMs) * time.Millisecond
+}
+
+func (a Actividad) Exitosa() bool {
+	return a.Codigo == 0
+}
+
+type Historial struct {
+	Tiendas map[string][]Actividad `json:"tiendas"`
+	mutex   sync.RWMutex
+	carga   sync.Once
+}
+
+var historialGlobal = &Historial{
+	Tiendas: make(map[string][]Actividad),
+}
+
+func ObtenerHistorial() *Historial {
+	historialGlobal.carga.Do(func() {
+		historialGlobal.cargar()
+	})
+	return historialGlobal
+}
+
+func obtenerRutaHistorial() (string, error) {
+	dirBase, err := obtenerDirectorioBase()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dirBase, "activity.json"), nil
+}
+
+func (h *Historial) cargar() {
+	rutaArchivo, err := obtenerRutaHistorial()
+	if err != nil {
+		return
+	}
+
+	datos, err := os.ReadFile(rutaArchivo)
+	if err != nil {
+		return
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err := json.Unmarshal(datos, h); err != nil || h.Tiendas == nil {
+		h.Tiendas = make(map[string][]Actividad)
+	}
+}
+
+func (h *Historial) guardar() error {
+	if err := crearDirectorioBase(); err != nil {
+		return err
+	}
+
+	rutaArchivo, err := obtenerRutaHistorial()
+	if err != nil {
+		return err
+	}
+
+	datos, err := json.MarshalIndent(h, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(rutaArchivo, datos, 0644)
+}
+
+func codigoSalida(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var errSalida *exec.ExitError
+	if errors.As(err, &errSalida) && errSalida.ExitCode() >= 0 {
+		return errSalida.ExitCode()
+	}
+	return -1
+}
+
+func (h *Historial) Registrar(nombreTienda, accion string, inicio time.Time, err error) error {
+	actividad := Actividad{
+		Accion:     accion,
+		Inicio:     inicio,
+		DuracionMs: time.Since(inicio).Milliseconds(),
+		Codigo:     codigoSalida(err),
+	}
+	if err != nil {
+		actividad.Error = err.Error()
+	}
+
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	actividades := append(h.Tiendas[nombreTienda], actividad)
+	if len(actividades) > maxActividadesPorTienda {
+		actividades = actividades[len(actividades)-maxActividadesPorTienda:]
+	}
+	h.Tiendas[nombreTienda] = actividades
+
+	return h.guardar()
+}
+
+func (h *Historial) Actividades(nombreTienda string) []Actividad {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	copia := make([]Actividad, len(h.Tiendas[nombreTienda]))
+	copy(copia, h.Tiendas[nombreTienda])
+	return copia
+}
+
+func (h *Historial) Ultima(nombreTienda string) (Actividad, bool) {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	actividades := h.Tiendas[nombreTienda]
+	if len(actividades) == 0 {
+		return Actividad{}, false
+	}
+	return actividades[len(actividades)-1], true
+}
+
+func (h *Historial) DuracionHabitual(nombreTienda, accion string) time.Duration {
+	h.mutex.RLock()
+	defer h.mutex.RUnlock()
+
+	if duracion := duracionMedia(h.Tiendas[nombreTienda], accion); duracion > 0 {
+		return duracion
+	}
+
+	var todas []Actividad
+	for _, actividades := range h.Tiendas {
+		todas = append(todas, actividades...)
+	}
+	sort.Slice(todas, func(i, j int) bool { return todas[i].Inicio.Before(todas[j].Inicio) })
+	return duracionMedia(todas, accion)
+}
+
+func duracionMedia(actividades []Actividad, accion string) time.Duration {
+	var total time.Duration
+	muestras := 0
+	for i := len(actividades) - 1; i >= 0 && muestras < muestrasDuracion; i-- {
+		if actividad := actividades[i]; actividad.Accion == accion && actividad.Exitosa() {
+			total += actividad.Duracion()
+			muestras++
+		}
+	}
+	if muestras == 0 {
+		return 0
+	}
+	return total / time.Duration(muestras)
+}
+
+func (h *Historial) UltimoUso(nombreTienda string) time.Time {
+	if actividad, ok := h.Ultima(nombreTienda); ok {
+		return actividad.Inicio
+	}
+	return time.Time{}
+}
+
+func (h *Historial) EliminarTienda(nombreTienda string) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if _, existe := h.Tiendas[nombreTienda]; !existe {
+		return nil
+	}
+	delete(h.Tiendas, nombreTienda)
+	return h.guardar()
+}
+
+func formatearHace(momento time.Time) string {
+	transcurrido := time.Since(momento)
+
+	switch {
+	case transcurrido < time.Minute:
+		return T("tiempo.ahora")
+	case transcurrido < time.Hour:
+		return T("tiempo.minutos", int(transcurrido.Minutes()))
+	case transcurrido < 24*time.Hour:
+		return T("tiempo.horas", int(transcurrido.Hours()))
+	}
+	return T("tiempo.dias", int(transcurrido.Hours()/24))
+}
+
+func resumenActividad(nombreTienda string) string {
+	actividad, ok := ObtenerHistorial().Ultima(nombreTienda)
+	if !ok {
+		return ""
+	}
+
+	resumen := T("actividad.ultima", T("actividad."+actividad.Accion), formatearHace(actividad.Inicio))
+	if !actividad.Exitosa() {
+		resumen += " " + Icons.Error
+	}
+	return resumen
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRegistrarActividad(t *testing.T) {
+	casos := []struct {
+		nombre     string
+		registros  int
+		guardadas  int
+		primeraSeg int
+	}{
+		{"bajo el límite", 3, 3, 0},
+		{"justo en el límite", maxActividadesPorTienda, maxActividadesPorTienda, 0},
+		{"sobre el límite", maxActividadesPorTienda + 7, maxActividadesPorTienda, 7},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("USERPROFILE", home)
+
+			base := time.Now().Add(-time.Hour)
+			historial := &Historial{Tiendas: make(map[string][]Actividad)}
+			for i := 0; i < caso.registros; i++ {
+				if err := historial.Registrar("alpha", accionPull, base.Add(time.Duration(i)*time.Second), nil); err != nil {
+					t.Fatalf("Registrar: %v", err)
+				}
+			}
+			historial.Registrar("beta", accionPush, base, nil)
+
+			actividades := historial.Actividades("alpha")
+			if len(actividades) != caso.guardadas {
+				t.Fatalf("se guardaron %d actividades, se esperaban %d", len(actividades), caso.guardadas)
+			}
+			if primera := actividades[0].Inicio; !primera.Equal(base.Add(time.Duration(caso.primeraSeg) * time.Second)) {
+				t.Fatalf("la actividad más antigua empieza en %s", primera.Sub(base))
+			}
+			if len(historial.Actividades("beta")) != 1 {
+				t.Fatal("el límite es por tienda")
+			}
+
+			cargado := &Historial{}
+			cargado.cargar()
+			if len(cargado.Actividades("alpha")) != caso.guardadas {
+				t.Fatalf("tras recargar hay %d actividades, se esperaban %d", len(cargado.Actividades("alpha")), caso.guardadas)
+			}
+		})
+	}
+}
+
+func TestUltimaActividad(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	historial := &Historial{Tiendas: make(map[string][]Actividad)}
+	if !historial.UltimoUso("alpha").IsZero() {
+		t.Fatal("una tienda sin actividad no tiene último uso")
+	}
+
+	inicio := time.Now().Add(-time.Minute)
+	historial.Registrar("alpha", accionPull, inicio.Add(-time.Hour), nil)
+	historial.Registrar("alpha", accionPush, inicio, errors.New("exit status 1"))
+
+	ultima, ok := historial.Ultima("alpha")
+	if !ok || ultima.Accion != accionPush || ultima.Exitosa() || ultima.Error != "exit status 1" {
+		t.Fatalf("Ultima() = %+v, %v", ultima, ok)
+	}
+	if !historial.UltimoUso("alpha").Equal(inicio) {
+		t.Fatalf("UltimoUso() = %s", historial.UltimoUso("alpha"))
+	}
+
+	if err := historial.EliminarTienda("alpha"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := historial.Ultima("alpha"); ok {
+		t.Fatal("la actividad de una tienda eliminada debe borrarse")
+	}
+}
@@ -5,7 +5,7 @@ set -e
 
 VERSION="1.0.0"
 OUTPUT_DIR="./releases"
-BINARY_NAME="shopify-cli"
+BINARY_NAME="shopify-tui"
 
 echo "🔨 Compilando shopify-cli v${VERSION} para todas las plataformas..."
 
@@ -23,7 +23,17 @@ for PLATFORM in "${PLATFORMS[@]}"; do
   OS="${PLATFORM%/*}"
   ARCH="${PLATFORM#*/}"
   
-  OUTPUT_NAME="${BINARY_NAME}-${OS}-${ARCH}"
+  RELEASE_OS="$OS"
+  if [ "$OS" = "windows" ]; then
+    RELEASE_OS="win32"
+  fi
+
+  RELEASE_ARCH="$ARCH"
+  if [ "$ARCH" = "amd64" ]; then
+    RELEASE_ARCH="x64"
+  fi
+
+  OUTPUT_NAME="${BINARY_NAME}-${RELEASE_OS}-${RELEASE_ARCH}"
   
   if [ "$OS" = "windows" ]; then
     OUTPUT_NAME="${OUTPUT_NAME}.exe"
@@ -36,6 +46,9 @@ for PLATFORM in "${PLATFORMS[@]}"; do
   echo "   ✅ ${OUTPUT_NAME}"
 done
 
+echo "🔐 Generando checksums.txt..."
+(cd "$OUTPUT_DIR" && sha256sum ${BINARY_NAME}-* > checksums.txt)
+
 echo ""
 echo "🎉 Compilación completada!"
 echo "   Binarios en: ${OUTPUT_DIR}/"
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+var urlNotasVersion = "https://api.github.com/repos/JacuXx/shopify-tui/releases?per_page=100"
+
+const maxPaginasNotas = 10
+
+var marcasRuptura = []string{"breaking", "incompatible", "⚠"}
+
+type NotaVersion struct {
+	Version string
+	Fecha   time.Time
+	Cuerpo  string
+	Mayor   bool
+}
+
+type releaseGitHub struct {
+	Etiqueta   string    `json:"tag_name"`
+	Cuerpo     string    `json:"body"`
+	Borrador   bool      `json:"draft"`
+	Preliminar bool      `json:"prerelease"`
+	Publicada  time.Time `json:"published_at"`
+}
+
+type notasVersionMsg struct {
+	version     string
+	notas       []NotaVersion
+	incompletas bool
+	err         error
+}
+
+func consultarReleases(client *http.Client, url string) ([]releaseGitHub, string, error) {
+	peticion, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	peticion.Header.Set("Accept", "application/vnd.github+json")
+
+	resp, err := client.Do(peticion)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, "", errors.New(resp.Status)
+	}
+
+	var releases []releaseGitHub
+	if err := json.NewDecoder(resp.Body).Decode(&releases); err != nil {
+		return nil, "", err
+	}
+	return releases, paginaSiguiente(resp.Header.Get("Link")), nil
+}
+
+func paginaSiguiente(enlaces string) string {
+	for _, enlace := range strings.Split(enlaces, ",") {
+		partes := strings.Split(enlace, ";")
+		destino := strings.TrimSpace(partes[0])
+		if !strings.HasPrefix(destino, "<") || !strings.HasSuffix(destino, ">") {
+			continue
+		}
+		for _, parametro := range partes[1:] {
+			if strings.TrimSpace(parametro) == `rel="next"` {
+				return strings.Trim(destino, "<>")
+			}
+		}
+	}
+	return ""
+}
+
+func consultarNotasVersion(desde, hasta string) ([]NotaVersion, bool, error) {
+	client := &http.Client{
+		Timeout: 10 * time.Second,
+	}
+
+	var releases []releaseGitHub
+	siguiente := urlNotasVersion
+	for pagina := 0; siguiente != "" && pagina < maxPaginasNotas; pagina++ {
+		lote, enlace, err := consultarReleases(client, siguiente)
+		if err != nil {
+			return nil, false, err
+		}
+		releases = append(releases, lote...)
+		siguiente = enlace
+	}
+
+	vDesde, okDesde := parsearVersion(desde)
+	notas := []NotaVersion{}
+	for _, release := range releases {
+		if release.Borrador || release.Preliminar {
+			continue
+		}
+		version, ok := parsearVersion(release.Etiqueta)
+		if !ok {
+			continue
+		}
+		if !compararVersiones(desde, release.Etiqueta) || compararVersiones(hasta, release.Etiqueta) {
+			continue
+		}
+
+		notas = append(notas, NotaVersion{
+			Version: strings.TrimPrefix(release.Etiqueta, "v"),
+			Fecha:   release.Publicada,
+			Cuerpo:  strings.ReplaceAll(release.Cuerpo, "\r\n", "\n"),
+			Mayor:   okDesde && version.numeros[0] > vDesde.numeros[0],
+		})
+	}
+
+	sort.Slice(notas, func(i, j int) bool {
+		return compararVersiones(notas[j].Version, notas[i].Version)
+	})
+	return notas, siguiente != "", nil
+}
+
+func cargarNotasVersion(desde, hasta string) tea.Cmd {
+	return func() tea.Msg {
+		notas, incompletas, err := consultarNotasVersion(desde, hasta)
+		return notasVersionMsg{version: hasta, notas: notas, incompletas: incompletas, err: err}
+	}
+}
+
+func esRuptura(linea string) bool {
+	minusculas := strings.ToLower(linea)
+	for _, marca := range marcasRuptura {
+		if strings.Contains(minusculas, marca) {
+			return true
+		}
+	}
+	return false
+}
+
+func (n NotaVersion) TieneRupturas() bool {
+	return n.Mayor || esRuptura(n.Cuerpo)
+}
+
+func (m Model) lineasNovedades(ancho int) []string {
+	var lineas []string
+	for i, nota := range m.notasVersion {
+		if i > 0 {
+			lineas = append(lineas, "")
+		}
+
+		cabecera := estiloLabel.Render("v" + nota.Version)
+		if !nota.Fecha.IsZero() {
+			cabecera += estiloDesc.Render(" · " + nota.Fecha.Format("2006-01-02"))
+		}
+		if nota.Mayor {
+			cabecera += " " + estiloError.Render("⚠ "+T("novedades.version_mayor"))
+		}
+		lineas = append(lineas, cabecera)
+
+		enRuptura := false
+		for _, linea := range strings.Split(strings.TrimSpace(nota.Cuerpo), "\n") {
+			linea = strings.TrimRight(linea, " \t")
+			titulo := strings.HasPrefix(linea, "#")
+			if titulo {
+				enRuptura = esRuptura(linea)
+				linea = strings.TrimSpace(strings.TrimLeft(linea, "#"))
+			}
+
+			estilo := estiloDesc
+			switch {
+			case enRuptura || esRuptura(linea):
+				estilo = estiloError
+			case titulo:
+				estilo = estiloAviso
+			}
+
+			for _, parte := range strings.Split(ansi.Wordwrap("  "+linea, ancho, ""), "\n") {
+				lineas = append(lineas, estilo.Render(parte))
+			}
+		}
+	}
+	return lineas
+}
+
+func (m Model) maxScrollNovedades() int {
+	return max(len(m.lineasNovedades(m.anchoNovedades()))-m.lineasPorPagina(), 0)
+}
+
+func (m Model) anchoNovedades() int {
+	if m.ancho == 0 {
+		return 80
+	}
+	return max(m.ancho-6, 20)
+}
+
+func (m Model) abrirNovedades() (tea.Model, tea.Cmd) {
+	m.vista = VistaNovedades
+	m.novedadesScroll = 0
+	m.mensaje = ""
+	if m.notasPara == m.versionNueva || m.cargandoNotas {
+		return m, nil
+	}
+
+	m.cargandoNotas = true
+	m.errorNotas = ""
+	return m, cargarNotasVersion(Version, m.versionNueva)
+}
+
+func (m Model) updateNovedades(msg tea.Msg) (tea.Model, tea.Cmd) {
+	teclaMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(teclaMsg, Teclas.Actualizar):
+		return m.accionActualizar()
+
+	case key.Matches(teclaMsg, Teclas.Arriba):
+		m.novedadesScroll = max(m.novedadesScroll-1, 0)
+
+	case key.Matches(teclaMsg, Teclas.Abajo):
+		m.novedadesScroll = min(m.novedadesScroll+1, m.maxScrollNovedades())
+
+	case key.Matches(teclaMsg, Teclas.PaginaArriba):
+		m.novedadesScroll = max(m.novedadesScroll-m.lineasPorPagina(), 0)
+
+	case key.Matches(teclaMsg, Teclas.PaginaAbajo):
+		m.novedadesScroll = min(m.novedadesScroll+m.lineasPorPagina(), m.maxScrollNovedades())
+
+	case key.Matches(teclaMsg, Teclas.Inicio):
+		m.novedadesScroll = 0
+
+	case key.Matches(teclaMsg, Teclas.Final):
+		m.novedadesScroll = m.maxScrollNovedades()
+	}
+	return m, nil
+}
+
+func (m Model) vistaNovedades() string {
+	var b strings.Builder
+
+	b.WriteString(estiloTitulo.Render("📝 " + T("novedades.titulo", Version, m.versionNueva)))
+	b.WriteString("\n\n")
+
+	switch {
+	case m.cargandoNotas:
+		b.WriteString(estiloDesc.Render(T("novedades.cargando")))
+		b.WriteString("\n")
+
+	case m.errorNotas != "":
+		b.WriteString(estiloError.Render(IconError(T("novedades.error", m.errorNotas))))
+		b.WriteString("\n")
+
+	case len(m.notasVersion) == 0:
+		b.WriteString(estiloDesc.Render(T("novedades.vacio")))
+		b.WriteString("\n")
+
+	default:
+		rupturas := 0
+		for _, nota := range m.notasVersion {
+			if nota.TieneRupturas() {
+				rupturas++
+			}
+		}
+		resumen := estiloDesc.Render(T("novedades.resumen", len(m.notasVersion)))
+		if rupturas > 0 {
+			resumen += estiloError.Render(" · ⚠ " + T("novedades.rupturas", rupturas))
+		}
+		if m.notasIncompletas {
+			resumen += estiloAviso.Render(" · " + T("novedades.incompletas"))
+		}
+		b.WriteString(resumen)
+		b.WriteString("\n\n")
+
+		lineas := m.lineasNovedades(m.anchoNovedades())
+		inicio := min(m.novedadesScroll, len(lineas))
+		fin := min(inicio+m.lineasPorPagina(), len(lineas))
+		for _, linea := range lineas[inicio:fin] {
+			b.WriteString(linea)
+			b.WriteString("\n")
+		}
+		if len(lineas) > m.lineasPorPagina() {
+			b.WriteString(estiloDesc.Render(T("novedades.posicion", inicio+1, fin, len(lineas))))
+			b.WriteString("\n")
+		}
+	}
+
+	if m.mensaje != "" {
+		b.WriteString("\n")
+		if strings.HasPrefix(m.mensaje, Icons.Success) {
+			b.WriteString(estiloExito.Render(m.mensaje))
+		} else {
+			b.WriteString(estiloError.Render(m.mensaje))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(estiloAyuda.Render(T("novedades.ayuda",
+		ayudaTecla(Teclas.Arriba), ayudaTecla(Teclas.Abajo), ayudaTecla(Teclas.PaginaAbajo),
+		ayudaTecla(Teclas.Actualizar), ayudaTecla(Teclas.Volver))))
+	return estiloContenedor.Render(b.String())
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestPaginaSiguiente(t *testing.T) {
+	casos := []struct {
+		nombre   string
+		enlaces  string
+		esperada string
+	}{
+		{"sin cabecera", "", ""},
+		{"con siguiente", `<https://api.github.com/releases?page=2>; rel="next", <https://api.github.com/releases?page=5>; rel="last"`, "https://api.github.com/releases?page=2"},
+		{"siguiente al final", `<https://api.github.com/releases?page=1>; rel="prev", <https://api.github.com/releases?page=3>; rel="next"`, "https://api.github.com/releases?page=3"},
+		{"última página", `<https://api.github.com/releases?page=4>; rel="prev", <https://api.github.com/releases?page=1>; rel="first"`, ""},
+		{"espacios", `  <https://x/?page=2> ;  rel="next"  `, "https://x/?page=2"},
+		{"sin corchetes", `https://x/?page=2; rel="next"`, ""},
+		{"sin rel", `<https://x/?page=2>`, ""},
+		{"rel parecido", `<https://x/?page=2>; rel="nextpage"`, ""},
+	}
+
+	for _, caso := range casos {
+		if got := paginaSiguiente(caso.enlaces); got != caso.esperada {
+			t.Errorf("%s: paginaSiguiente = %q, se esperaba %q", caso.nombre, got, caso.esperada)
+		}
+	}
+}
+
+func usarReleasesFalsas(t *testing.T, paginas [][]releaseGitHub, sinFin bool) *atomic.Int32 {
+	t.Helper()
+
+	var pedidos atomic.Int32
+	var servidor *httptest.Server
+	servidor = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pedidos.Add(1)
+		pagina, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if sinFin || pagina+1 < len(paginas) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/releases?page=%d>; rel="next"`, servidor.URL, pagina+1))
+		}
+		var lote []releaseGitHub
+		if pagina < len(paginas) {
+			lote = paginas[pagina]
+		}
+		json.NewEncoder(w).Encode(lote)
+	}))
+
+	anterior := urlNotasVersion
+	urlNotasVersion = servidor.URL + "/releases?page=0"
+	t.Cleanup(func() {
+		servidor.Close()
+		urlNotasVersion = anterior
+	})
+	return &pedidos
+}
+
+func TestConsultarNotasVersion(t *testing.T) {
+	paginas := [][]releaseGitHub{
+		{
+			{Etiqueta: "v2.1.0"},
+			{Etiqueta: "v2.0.0", Cuerpo: "Nueva\r\nversión"},
+			{Etiqueta: "v1.9.0-beta.1", Preliminar: true},
+			{Etiqueta: "v1.9.0", Borrador: true},
+		},
+		{
+			{Etiqueta: "v1.8.2"},
+			{Etiqueta: "nightly"},
+			{Etiqueta: "v1.8.0"},
+			{Etiqueta: "v1.7.0"},
+		},
+	}
+
+	casos := []struct {
+		nombre      string
+		desde       string
+		hasta       string
+		versiones   []string
+		mayores     []bool
+		incompletas bool
+	}{
+		{"rango completo", "1.8.0", "2.1.0", []string{"2.1.0", "2.0.0", "1.8.2"}, []bool{true, true, false}, false},
+		{"excluye desde e incluye hasta", "1.8.2", "2.0.0", []string{"2.0.0"}, []bool{true}, false},
+		{"misma versión", "2.1.0", "2.1.0", []string{}, []bool{}, false},
+		{"sin cambio mayor", "1.7.0", "1.8.2", []string{"1.8.2", "1.8.0"}, []bool{false, false}, false},
+		{"desde inválida", "dev", "1.8.0", []string{}, []bool{}, false},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			pedidos := usarReleasesFalsas(t, paginas, false)
+
+			notas, incompletas, err := consultarNotasVersion(caso.desde, caso.hasta)
+			if err != nil {
+				t.Fatalf("consultarNotasVersion: %v", err)
+			}
+			if pedidos.Load() != 2 {
+				t.Fatalf("%d páginas pedidas, se esperaban 2", pedidos.Load())
+			}
+			versiones, mayores := []string{}, []bool{}
+			for _, nota := range notas {
+				versiones = append(versiones, nota.Version)
+				mayores = append(mayores, nota.Mayor)
+			}
+			if !reflect.DeepEqual(versiones, caso.versiones) || !reflect.DeepEqual(mayores, caso.mayores) || incompletas != caso.incompletas {
+				t.Fatalf("notas = %v %v (incompletas=%v), se esperaba %v %v (incompletas=%v)", versiones, mayores, incompletas, caso.versiones, caso.mayores, caso.incompletas)
+			}
+			for _, nota := range notas {
+				if nota.Version == "2.0.0" && nota.Cuerpo != "Nueva\nversión" {
+					t.Fatalf("cuerpo = %q, se esperaban saltos de línea normalizados", nota.Cuerpo)
+				}
+			}
+		})
+	}
+}
+
+func TestConsultarNotasVersionLimitePaginas(t *testing.T) {
+	pedidos := usarReleasesFalsas(t, [][]releaseGitHub{{{Etiqueta: "v2.0.0"}}}, true)
+
+	notas, incompletas, err := consultarNotasVersion("1.0.0", "2.0.0")
+	if err != nil {
+		t.Fatalf("consultarNotasVersion: %v", err)
+	}
+	if pedidos.Load() != maxPaginasNotas {
+		t.Fatalf("%d páginas pedidas, se esperaba el límite de %d", pedidos.Load(), maxPaginasNotas)
+	}
+	if !incompletas || len(notas) != 1 {
+		t.Fatalf("notas = %d (incompletas=%v), se esperaba 1 nota marcada como incompleta", len(notas), incompletas)
+	}
+}
+
+func TestConsultarNotasVersionError(t *testing.T) {
+	servidor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "rate limit", http.StatusForbidden)
+	}))
+	defer servidor.Close()
+
+	anterior := urlNotasVersion
+	urlNotasVersion = servidor.URL
+	defer func() { urlNotasVersion = anterior }()
+
+	if _, _, err := consultarNotasVersion("1.0.0", "2.0.0"); err == nil {
+		t.Fatal("se esperaba el error de la API")
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+type listaEtiquetas []string
+
+func (l *listaEtiquetas) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listaEtiquetas) Set(valor string) error {
+	*l = append(*l, normalizarEtiquetas(valor)...)
+	return nil
+}
+
+type comandoCLI struct {
+	nombre   string
+	ejecutar func(args []string) int
+}
+
+func comandosCLI() []comandoCLI {
+	return []comandoCLI{
+		{nombre: "list", ejecutar: cliListar},
+		{nombre: "pull", ejecutar: func(args []string) int { return cliTema("pull", args) }},
+		{nombre: "push", ejecutar: func(args []string) int { return cliTema("push", args) }},
+		{nombre: "self-update", ejecutar: cliAutoActualizar},
+	}
+}
+
+func ejecutarCLI(args []string) (bool, int) {
+	if len(args) == 0 || args[0] == "." {
+		return false, 0
+	}
+
+	for _, comando := range comandosCLI() {
+		if comando.nombre == args[0] {
+			return true, comando.ejecutar(args[1:])
+		}
+	}
+
+	if args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		fmt.Println(T("cli.uso"))
+		return true, 0
+	}
+
+	fmt.Fprintln(os.Stderr, T("cli.comando_desconocido", args[0]))
+	fmt.Fprintln(os.Stderr, T("cli.uso"))
+	return true, 2
+}
+
+func banderasFiltro(nombre string, filtro *FiltroTiendas) *flag.FlagSet {
+	banderas := flag.NewFlagSet(nombre, flag.ContinueOnError)
+	banderas.Var((*listaEtiquetas)(&filtro.Etiquetas), "tag", T("cli.flag.tag"))
+	banderas.StringVar(&filtro.Grupo, "group", "", T("cli.flag.grupo"))
+	banderas.BoolVar(&filtro.Favoritas, "favorites", false, T("cli.flag.favoritas"))
+	return banderas
+}
+
+func cliListar(args []string) int {
+	var filtro FiltroTiendas
+	banderas := banderasFiltro("list", &filtro)
+	if err := banderas.Parse(args); err != nil {
+		return 2
+	}
+
+	tiendas, err := cargarTiendas()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, IconError(T("error.generico", err.Error())))
+		return 1
+	}
+
+	for _, t := range filtrarTiendasPor(tiendas, filtro) {
+		linea := t.Nombre + "\t" + t.URL
+		if t.Grupo != "" {
+			linea += "\t[" + t.Grupo + "]"
+		}
+		if len(t.Etiquetas) > 0 {
+			linea += "\t" + textoEtiquetas(t.Etiquetas)
+		}
+		if t.Favorita {
+			linea = "★ " + linea
+		}
+		fmt.Println(linea)
+	}
+	return 0
+}
+
+func cliTema(accion string, args []string) int {
+	var filtro FiltroTiendas
+	var todas bool
+	banderas := banderasFiltro(accion, &filtro)
+	banderas.BoolVar(&todas, "all", false, T("cli.flag.todas"))
+	if err := banderas.Parse(args); err != nil {
+		return 2
+	}
+
+	if filtro.vacio() && !todas {
+		fmt.Fprintln(os.Stderr, IconWarning(T("cli.sin_filtro", accion)))
+		return 2
+	}
+
+	tiendas, err := cargarTiendas()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, IconError(T("error.generico", err.Error())))
+		return 1
+	}
+
+	seleccion := filtrarTiendasPor(tiendas, filtro)
+	if len(seleccion) == 0 {
+		fmt.Fprintln(os.Stderr, IconWarning(T("cli.sin_tiendas")))
+		return 1
+	}
+
+	var fallidas []string
+	for _, t := range seleccion {
+		fmt.Println()
+		fmt.Println(Icons.Store + " " + T("cli.ejecutando", accion, t.Nombre, t.URL))
+
+		cmd := exec.Command("shopify", "theme", accion, "--store", t.URL)
+		cmd.Dir = t.Ruta
+
+		inicio := time.Now()
+		err := conGanchos(cmd, t, accion).Run()
+		ObtenerHistorial().Registrar(t.Nombre, accion, inicio, err)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, IconError(T("error.generico", err.Error())))
+			fallidas = append(fallidas, t.Nombre)
+		}
+	}
+
+	fmt.Println()
+	if len(fallidas) > 0 {
+		fmt.Println(IconError(T("cli.resumen_fallos", len(seleccion)-len(fallidas), len(seleccion), strings.Join(fallidas, ", "))))
+		return 1
+	}
+	fmt.Println(IconSuccess(T("cli.resumen_ok", len(seleccion))))
+	return 0
+}
@@ -18,13 +18,25 @@ type errorMsg struct {
 	err error
 }
 
+type servidorReiniciadoMsg struct {
+	nombre string
+	err    error
+}
+
+func reiniciarServidor(tienda Tienda) tea.Cmd {
+	return func() tea.Msg {
+		_, err := ObtenerGestor().ReiniciarServidor(tienda)
+		return servidorReiniciadoMsg{nombre: tienda.Nombre, err: err}
+	}
+}
+
 func ejecutarShopifyLogin() tea.Cmd {
 	cmd := exec.Command("shopify", "auth", "login")
 	return tea.ExecProcess(cmd, func(err error) tea.Msg {
 		if err != nil {
 			return errorMsg{err: err}
 		}
-		return comandoTerminadoMsg{resultado: IconSuccess("Sesión iniciada correctamente")}
+		return comandoTerminadoMsg{resultado: IconSuccess(T("cmd.login_ok"))}
 	})
 }
 
@@ -37,7 +49,7 @@ func ejecutarShopifyPull(storeURL string, directorio string) tea.Cmd {
 		if err != nil {
 			return errorMsg{err: err}
 		}
-		return comandoTerminadoMsg{resultado: IconSuccess("Tema descargado correctamente")}
+		return comandoTerminadoMsg{resultado: IconSuccess(T("cmd.tema_descargado"))}
 	})
 }
 
@@ -49,7 +61,7 @@ func ejecutarThemeDev(storeURL string, directorio string) tea.Cmd {
 		if err != nil {
 			return errorMsg{err: err}
 		}
-		return comandoTerminadoMsg{resultado: IconSuccess("Servidor de desarrollo cerrado")}
+		return comandoTerminadoMsg{resultado: IconSuccess(T("cmd.dev_cerrado"))}
 	})
 }
 
@@ -62,7 +74,7 @@ func ejecutarGitClone(gitURL string, directorio string) tea.Cmd {
 		if err != nil {
 			return errorMsg{err: err}
 		}
-		return comandoTerminadoMsg{resultado: IconSuccess("Repositorio clonado correctamente")}
+		return comandoTerminadoMsg{resultado: IconSuccess(T("cmd.repo_clonado"))}
 	})
 }
 
@@ -89,34 +101,33 @@ func ejecutarDescargaTema(tienda Tienda) tea.Cmd {
 				return errorMsg{err: err}
 			}
 			return comandoTerminadoMsg{
-				resultado: IconSuccess("Tienda configurada correctamente"),
+				resultado: IconSuccess(T("cmd.tienda_configurada")),
 				tienda:    &tienda,
 			}
 		})()
 	}
 }
 
-func ejecutarDescargaConExec(tienda Tienda, directorio string) tea.Cmd {
-	var cmd *exec.Cmd
+func ejecutarDescargaEnPanel(tienda Tienda, directorio string) tea.Cmd {
+	accion := accionShopifyPull
 	if tienda.Metodo == MetodoGitClone {
-		cmd = exec.Command("git", "clone", tienda.GitURL, ".")
-	} else {
-		cmd = exec.Command("shopify", "theme", "pull", "--store", tienda.URL, "--path", ".")
+		accion = accionGitClone
 	}
-	cmd.Dir = directorio
 
 	t := tienda
 	t.Ruta = directorio
 
-	return tea.ExecProcess(cmd, func(err error) tea.Msg {
-		if err != nil {
-			return errorMsg{err: err}
-		}
-		return comandoTerminadoMsg{
-			resultado: IconSuccess("Tienda configurada correctamente"),
-			tienda:    &t,
-		}
-	})
+	return func() tea.Msg {
+		return ejecutarTrabajoEnPanel(ObtenerTrabajos().Nuevo(accion, t), func(err error) tea.Msg {
+			if err != nil {
+				return errorMsg{err: err}
+			}
+			return comandoTerminadoMsg{
+				resultado: IconSuccess(T("cmd.tienda_configurada")),
+				tienda:    &t,
+			}
+		})()
+	}
 }
 
 func ejecutarThemeDevInteractivo(tienda Tienda) tea.Cmd {
@@ -127,37 +138,7 @@ func ejecutarThemeDevInteractivo(tienda Tienda) tea.Cmd {
 		if err != nil {
 			return errorMsg{err: err}
 		}
-		return comandoTerminadoMsg{resultado: IconSuccess("Servidor de desarrollo cerrado")}
-	})
-}
-
-func ejecutarThemePull(tienda Tienda) tea.Cmd {
-	cmd := exec.Command("shopify", "theme", "pull", "--store", tienda.URL)
-	cmd.Dir = tienda.Ruta
-
-	return tea.ExecProcess(cmd, func(err error) tea.Msg {
-		if err != nil {
-			return errorMsg{err: err}
-		}
-		return comandoTerminadoMsg{
-			resultado:       IconSuccess("Cambios descargados correctamente"),
-			volverAOpciones: true,
-		}
-	})
-}
-
-func ejecutarThemePush(tienda Tienda) tea.Cmd {
-	cmd := exec.Command("shopify", "theme", "push", "--store", tienda.URL)
-	cmd.Dir = tienda.Ruta
-
-	return tea.ExecProcess(cmd, func(err error) tea.Msg {
-		if err != nil {
-			return errorMsg{err: err}
-		}
-		return comandoTerminadoMsg{
-			resultado:       IconSuccess("Cambios subidos correctamente"),
-			volverAOpciones: true,
-		}
+		return comandoTerminadoMsg{resultado: IconSuccess(T("cmd.dev_cerrado"))}
 	})
 }
 
@@ -171,7 +152,7 @@ func ejecutarAbrirEditor(tienda Tienda) tea.Cmd {
 			return errorMsg{err: err}
 		}
 		return comandoTerminadoMsg{
-			resultado:       IconSuccess("Editor abierto"),
+			resultado:       IconSuccess(T("cmd.editor_abierto")),
 			volverAOpciones: true,
 		}
 	})
@@ -184,20 +165,24 @@ func ejecutarAbrirTerminal(tienda Tienda) tea.Cmd {
 		shell = "zsh"
 	}
 
-	fmt.Println("\n╭─────────────────────────────────────────────────╮")
-	fmt.Println("│  " + Icons.Folder + " Terminal abierta en: " + tienda.Nombre)
-	fmt.Println("│  " + Icons.Info + " Escribe 'exit' o presiona Ctrl+D para volver")
-	fmt.Println("╰─────────────────────────────────────────────────╯\n")
+	if !terminalDisponible {
+		fmt.Println("\n╭─────────────────────────────────────────────────╮")
+		fmt.Println("│  " + Icons.Folder + " " + T("cmd.terminal_abierta", tienda.Nombre))
+		fmt.Println("│  " + Icons.Info + " " + T("cmd.terminal_salir"))
+		fmt.Println("╰─────────────────────────────────────────────────╯")
+		fmt.Println()
+	}
 
 	cmd := exec.Command(shell)
 	cmd.Dir = tienda.Ruta
+	configurarTerminalControl(cmd)
 
-	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+	return ejecutarEnPanel(T("cmd.terminal_abierta", tienda.Ruta), tienda, &comandoSimple{Cmd: cmd}, nil, func(err error) tea.Msg {
 		if err != nil {
 			return errorMsg{err: err}
 		}
 		return comandoTerminadoMsg{
-			resultado:       IconSuccess("Terminal cerrada"),
+			resultado:       IconSuccess(T("cmd.terminal_cerrada")),
 			volverAOpciones: true,
 		}
 	})
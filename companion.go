@@ -0,0 +1,167 @@
+package main
+
+import (
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+type Acompanante struct {
+	Nombre  string `json:"nombre"`
+	Comando string `json:"comando"`
+}
+
+type ProcesoAcompanante struct {
+	Acompanante
+	Proceso  *exec.Cmd
+	Iniciado time.Time
+	Activo   bool
+	Codigo   int
+	Error    string
+	detenido bool
+}
+
+type EstadoAcompanante struct {
+	Nombre string
+	Activo bool
+	Codigo int
+	Error  string
+}
+
+func prefijoLog(nombre string) string {
+	return "[" + nombre + "] "
+}
+
+func separarFuenteLog(linea string, tienda Tienda) (string, string) {
+	fuentes := []string{T("ganchos.prefijo")}
+	for _, acompanante := range tienda.Acompanantes {
+		fuentes = append(fuentes, acompanante.Nombre)
+	}
+
+	for _, fuente := range fuentes {
+		if resto, ok := strings.CutPrefix(linea, prefijoLog(fuente)); ok {
+			return fuente, resto
+		}
+	}
+	return "", linea
+}
+
+func (g *GestorServidores) iniciarAcompanantes(servidor *ServidorActivo) {
+	for _, acompanante := range servidor.Tienda.Acompanantes {
+		proceso := &ProcesoAcompanante{Acompanante: acompanante}
+
+		g.mutex.Lock()
+		if !servidor.Activo() {
+			g.mutex.Unlock()
+			return
+		}
+		servidor.Acompanantes = append(servidor.Acompanantes, proceso)
+		g.mutex.Unlock()
+
+		g.iniciarAcompanante(servidor, proceso)
+	}
+}
+
+func (g *GestorServidores) iniciarAcompanante(servidor *ServidorActivo, proceso *ProcesoAcompanante) {
+	prefijo := prefijoLog(proceso.Nombre)
+
+	cmd := comandoShell(proceso.Comando)
+	cmd.Dir = servidor.Tienda.Ruta
+	cmd.Env = entornoTienda(servidor.Tienda, servidor.Puerto)
+	configurarGrupoProcesos(cmd)
+
+	g.mutex.Lock()
+	if !servidor.Activo() {
+		g.mutex.Unlock()
+		return
+	}
+
+	stdout, errOut := cmd.StdoutPipe()
+	stderr, errErr := cmd.StderrPipe()
+	err := errOut
+	if err == nil {
+		err = errErr
+	}
+	if err == nil {
+		err = cmd.Start()
+	}
+	proceso.Proceso = cmd
+	proceso.Iniciado = time.Now()
+	if err != nil {
+		proceso.Codigo = -1
+		proceso.Error = err.Error()
+		g.mutex.Unlock()
+		servidor.AgregarLog(prefijo + IconError(T("acompanante.error_iniciar", err)))
+		return
+	}
+	proceso.Activo = true
+	g.mutex.Unlock()
+
+	servidor.AgregarLog(prefijo + T("acompanante.iniciado", proceso.Comando))
+
+	go func() {
+		var lectores sync.WaitGroup
+		lectores.Add(2)
+		go func() {
+			defer lectores.Done()
+			leerLogs(stdout, servidor, prefijo)
+		}()
+		go func() {
+			defer lectores.Done()
+			leerLogs(stderr, servidor, prefijo)
+		}()
+		lectores.Wait()
+		err := cmd.Wait()
+
+		g.mutex.Lock()
+		proceso.Activo = false
+		if proceso.detenido {
+			err = nil
+		}
+		proceso.Codigo = codigoSalida(err)
+		if err != nil {
+			proceso.Error = err.Error()
+		}
+		g.mutex.Unlock()
+
+		if err != nil {
+			servidor.AgregarLog(prefijo + IconWarning(T("acompanante.fallo", proceso.Codigo)))
+			return
+		}
+		servidor.AgregarLog(prefijo + T("acompanante.detenido"))
+	}()
+}
+
+func (g *GestorServidores) detenerAcompanantes(servidor *ServidorActivo) {
+	for _, proceso := range servidor.Acompanantes {
+		if !proceso.Activo || proceso.Proceso == nil {
+			continue
+		}
+		proceso.detenido = true
+		terminarGrupo(proceso.Proceso)
+	}
+}
+
+func estadosAcompanantes(servidor *ServidorActivo) []EstadoAcompanante {
+	estados := make([]EstadoAcompanante, 0, len(servidor.Acompanantes))
+	for _, proceso := range servidor.Acompanantes {
+		estados = append(estados, EstadoAcompanante{
+			Nombre: proceso.Nombre,
+			Activo: proceso.Activo,
+			Codigo: proceso.Codigo,
+			Error:  proceso.Error,
+		})
+	}
+	return estados
+}
+
+func (e EstadoAcompanante) Texto() string {
+	if e.Activo {
+		return T("acompanante.estado_activo")
+	}
+	if e.Codigo != 0 {
+		return T("acompanante.estado_fallo", e.Codigo)
+	}
+	return T("acompanante.estado_detenido")
+}
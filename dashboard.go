@@ -0,0 +1,69 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type lineaCombinada struct {
+	LineaLog
+	servidor *InstantaneaServidor
+	fuente   int
+}
+
+func distribucionPanel(cantidad, ancho int) (int, int) {
+	columnas := 1
+	switch {
+	case cantidad > 4 && ancho >= 180:
+		columnas = 3
+	case cantidad > 1 && ancho >= 110:
+		columnas = 2
+	}
+	if columnas > cantidad {
+		columnas = cantidad
+	}
+	filas := (cantidad + columnas - 1) / columnas
+	return columnas, filas
+}
+
+func colorFuente(indice int) lipgloss.TerminalColor {
+	colores := []string{Tema.Primario, Tema.Exito, Tema.Aviso, Tema.Enlace, Tema.Comando, Tema.Atajo}
+	return colorTema(colores[indice%len(colores)])
+}
+
+func combinarLogs(servidores []*InstantaneaServidor) []lineaCombinada {
+	var lineas []lineaCombinada
+	for i, servidor := range servidores {
+		for _, linea := range servidor.Lineas {
+			lineas = append(lineas, lineaCombinada{LineaLog: linea, servidor: servidor, fuente: i})
+		}
+	}
+	sort.SliceStable(lineas, func(i, j int) bool {
+		return lineas[i].Momento.Before(lineas[j].Momento)
+	})
+	return lineas
+}
+
+func (m Model) erroresSinLeer(servidor *InstantaneaServidor) int {
+	sinLeer := servidor.Errores - m.erroresVistos[servidor.Tienda.Nombre]
+	if sinLeer < 0 {
+		return 0
+	}
+	return sinLeer
+}
+
+func (m Model) marcarErroresVistos() {
+	switch m.vista {
+	case VistaLogs:
+		if servidor := m.instantanea(m.tiendaParaDev.Nombre); servidor != nil {
+			m.erroresVistos[servidor.Tienda.Nombre] = servidor.Errores
+		}
+	case VistaPanel:
+		for i, servidor := range m.servidoresActivos() {
+			if m.panelCombinado || i == m.panelFoco {
+				m.erroresVistos[servidor.Tienda.Nombre] = servidor.Errores
+			}
+		}
+	}
+}
@@ -0,0 +1,682 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	contextoDiff     = 3
+	maxEdicionesDiff = 1000
+	maxBytesBinario  = 8000
+)
+
+const (
+	diffModificado = "modificado"
+	diffSoloLocal  = "solo_local"
+	diffSoloRemoto = "solo_remoto"
+)
+
+var carpetasTema = []string{"assets", "blocks", "config", "layout", "locales", "sections", "snippets", "templates"}
+
+type LineaDiff struct {
+	Tipo   byte
+	Texto  string
+	Remoto int
+	Local  int
+}
+
+type ArchivoDiff struct {
+	Ruta       string
+	Estado     string
+	Binario    bool
+	Agregadas  int
+	Eliminadas int
+	Lineas     []LineaDiff
+}
+
+type diffLanzadoMsg struct {
+	trabajo *Trabajo
+}
+
+func archivosTema(raiz string) (map[string]bool, error) {
+	archivos := make(map[string]bool)
+	for _, carpeta := range carpetasTema {
+		err := filepath.WalkDir(filepath.Join(raiz, carpeta), func(ruta string, entrada fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if !entrada.Type().IsRegular() {
+				return nil
+			}
+			relativa, err := filepath.Rel(raiz, ruta)
+			if err != nil {
+				return err
+			}
+			archivos[filepath.ToSlash(relativa)] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return archivos, nil
+}
+
+func compararTemas(local, remoto string) ([]ArchivoDiff, error) {
+	locales, err := archivosTema(local)
+	if err != nil {
+		return nil, err
+	}
+	remotos, err := archivosTema(remoto)
+	if err != nil {
+		return nil, err
+	}
+
+	rutas := make([]string, 0, len(locales)+len(remotos))
+	for ruta := range locales {
+		rutas = append(rutas, ruta)
+	}
+	for ruta := range remotos {
+		if !locales[ruta] {
+			rutas = append(rutas, ruta)
+		}
+	}
+	sort.Strings(rutas)
+
+	var archivos []ArchivoDiff
+	for _, ruta := range rutas {
+		var datosLocal, datosRemoto []byte
+		if locales[ruta] {
+			if datosLocal, err = os.ReadFile(filepath.Join(local, filepath.FromSlash(ruta))); err != nil {
+				return nil, err
+			}
+		}
+		if remotos[ruta] {
+			if datosRemoto, err = os.ReadFile(filepath.Join(remoto, filepath.FromSlash(ruta))); err != nil {
+				return nil, err
+			}
+		}
+		if archivo, distinto := compararArchivo(ruta, datosRemoto, datosLocal, remotos[ruta], locales[ruta]); distinto {
+			archivos = append(archivos, archivo)
+		}
+	}
+	return archivos, nil
+}
+
+func compararArchivo(ruta string, remoto, local []byte, enRemoto, enLocal bool) (ArchivoDiff, bool) {
+	archivo := ArchivoDiff{Ruta: ruta, Estado: diffModificado}
+	switch {
+	case !enRemoto:
+		archivo.Estado = diffSoloLocal
+	case !enLocal:
+		archivo.Estado = diffSoloRemoto
+	}
+
+	remoto = bytes.ReplaceAll(remoto, []byte("\r\n"), []byte("\n"))
+	local = bytes.ReplaceAll(local, []byte("\r\n"), []byte("\n"))
+	if archivo.Estado == diffModificado && bytes.Equal(remoto, local) {
+		return archivo, false
+	}
+
+	if esBinario(remoto) || esBinario(local) {
+		archivo.Binario = true
+		return archivo, true
+	}
+
+	lineas := diferenciarLineas(lineasArchivo(remoto), lineasArchivo(local))
+	for _, linea := range lineas {
+		switch linea.Tipo {
+		case '+':
+			archivo.Agregadas++
+		case '-':
+			archivo.Eliminadas++
+		}
+	}
+	archivo.Lineas = bloquesDiff(lineas, contextoDiff)
+	return archivo, true
+}
+
+func esBinario(datos []byte) bool {
+	return bytes.IndexByte(datos[:min(len(datos), maxBytesBinario)], 0) >= 0
+}
+
+func lineasArchivo(datos []byte) []string {
+	if len(datos) == 0 {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(string(datos), "\n"), "\n")
+}
+
+func diferenciarLineas(remoto, local []string) []LineaDiff {
+	prefijo := 0
+	for prefijo < len(remoto) && prefijo < len(local) && remoto[prefijo] == local[prefijo] {
+		prefijo++
+	}
+	sufijo := 0
+	for sufijo < len(remoto)-prefijo && sufijo < len(local)-prefijo &&
+		remoto[len(remoto)-1-sufijo] == local[len(local)-1-sufijo] {
+		sufijo++
+	}
+
+	var lineas []LineaDiff
+	for i := 0; i < prefijo; i++ {
+		lineas = append(lineas, LineaDiff{Tipo: ' ', Texto: remoto[i], Remoto: i + 1, Local: i + 1})
+	}
+
+	a, b := remoto[prefijo:len(remoto)-sufijo], local[prefijo:len(local)-sufijo]
+	cambios, ok := myers(a, b)
+	if !ok {
+		cambios = nil
+		for _, texto := range a {
+			cambios = append(cambios, LineaDiff{Tipo: '-', Texto: texto})
+		}
+		for _, texto := range b {
+			cambios = append(cambios, LineaDiff{Tipo: '+', Texto: texto})
+		}
+	}
+
+	numRemoto, numLocal := prefijo, prefijo
+	for _, linea := range cambios {
+		if linea.Tipo != '+' {
+			numRemoto++
+			linea.Remoto = numRemoto
+		}
+		if linea.Tipo != '-' {
+			numLocal++
+			linea.Local = numLocal
+		}
+		lineas = append(lineas, linea)
+	}
+
+	for i := 0; i < sufijo; i++ {
+		numRemoto++
+		numLocal++
+		lineas = append(lineas, LineaDiff{Tipo: ' ', Texto: local[numLocal-1], Remoto: numRemoto, Local: numLocal})
+	}
+	return lineas
+}
+
+func myers(a, b []string) ([]LineaDiff, bool) {
+	n, m := len(a), len(b)
+	desplazamiento := n + m + 1
+	v := make([]int, 2*desplazamiento+1)
+	var traza [][]int
+
+	fin := -1
+	for d := 0; d <= n+m && fin < 0; d++ {
+		if d > maxEdicionesDiff {
+			return nil, false
+		}
+		if d > 0 {
+			traza = append(traza, append([]int(nil), v[desplazamiento-d+1:desplazamiento+d]...))
+		}
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[desplazamiento+k-1] < v[desplazamiento+k+1]) {
+				x = v[desplazamiento+k+1]
+			} else {
+				x = v[desplazamiento+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[desplazamiento+k] = x
+			if x >= n && y >= m {
+				fin = d
+				break
+			}
+		}
+	}
+
+	var inverso []LineaDiff
+	x, y := n, m
+	for d := fin; d > 0; d-- {
+		anterior := traza[d-1]
+		valor := func(k int) int { return anterior[k+d-1] }
+
+		k := x - y
+		kAnterior := k - 1
+		if k == -d || (k != d && valor(k-1) < valor(k+1)) {
+			kAnterior = k + 1
+		}
+		xAnterior := valor(kAnterior)
+		yAnterior := xAnterior - kAnterior
+
+		for x > xAnterior && y > yAnterior {
+			x--
+			y--
+			inverso = append(inverso, LineaDiff{Tipo: ' ', Texto: b[y]})
+		}
+		if x == xAnterior {
+			y--
+			inverso = append(inverso, LineaDiff{Tipo: '+', Texto: b[y]})
+		} else {
+			x--
+			inverso = append(inverso, LineaDiff{Tipo: '-', Texto: a[x]})
+		}
+	}
+	for x > 0 && y > 0 {
+		x--
+		y--
+		inverso = append(inverso, LineaDiff{Tipo: ' ', Texto: b[y]})
+	}
+
+	lineas := make([]LineaDiff, len(inverso))
+	for i, linea := range inverso {
+		lineas[len(inverso)-1-i] = linea
+	}
+	return lineas, true
+}
+
+func bloquesDiff(lineas []LineaDiff, contexto int) []LineaDiff {
+	var filas []LineaDiff
+	for i := 0; i < len(lineas); {
+		if lineas[i].Tipo == ' ' {
+			i++
+			continue
+		}
+
+		inicio := max(i-contexto, 0)
+		fin := i + 1
+		for j := i + 1; j < len(lineas) && j-fin <= 2*contexto; j++ {
+			if lineas[j].Tipo != ' ' {
+				fin = j + 1
+			}
+		}
+		fin = min(fin+contexto, len(lineas))
+
+		antesRemoto, antesLocal := contarLineas(lineas[:inicio])
+		filas = append(filas, LineaDiff{Tipo: '@', Texto: encabezadoBloque(lineas[inicio:fin], antesRemoto, antesLocal)})
+		filas = append(filas, lineas[inicio:fin]...)
+		i = fin
+	}
+	return filas
+}
+
+func contarLineas(lineas []LineaDiff) (int, int) {
+	remoto, local := 0, 0
+	for _, linea := range lineas {
+		if linea.Tipo != '+' {
+			remoto++
+		}
+		if linea.Tipo != '-' {
+			local++
+		}
+	}
+	return remoto, local
+}
+
+func encabezadoBloque(lineas []LineaDiff, antesRemoto, antesLocal int) string {
+	cuentaRemoto, cuentaLocal := contarLineas(lineas)
+	return "@@ -" + rangoBloque(antesRemoto, cuentaRemoto) + " +" + rangoBloque(antesLocal, cuentaLocal) + " @@"
+}
+
+func rangoBloque(antes, cuenta int) string {
+	switch cuenta {
+	case 0:
+		return strconv.Itoa(antes) + ",0"
+	case 1:
+		return strconv.Itoa(antes + 1)
+	}
+	return strconv.Itoa(antes+1) + "," + strconv.Itoa(cuenta)
+}
+
+func temaComparado(tienda Tienda) string {
+	switch tienda.TemaRemoto {
+	case "", temaPublicado:
+		return T("diff.tema_publicado")
+	case temaDesarrollo:
+		return T("diff.tema_desarrollo")
+	}
+	return T("diff.tema", tienda.TemaRemoto)
+}
+
+func lanzarDiff(tienda Tienda) tea.Cmd {
+	return func() tea.Msg {
+		trabajo, _ := ObtenerTrabajos().Lanzar(accionDiff, tienda)
+		return diffLanzadoMsg{trabajo: trabajo}
+	}
+}
+
+func (m Model) abrirDiff() (tea.Model, tea.Cmd) {
+	m.vista = VistaDiff
+	m.diffTrabajo = nil
+	m.diffCursor = 0
+	m.diffScroll = 0
+	m.mensaje = ""
+	return m, lanzarDiff(m.tiendaParaDev)
+}
+
+func (m Model) diffLanzado(msg diffLanzadoMsg) (tea.Model, tea.Cmd) {
+	if m.vista != VistaDiff || m.diffTrabajo != nil {
+		msg.trabajo.Cancelar()
+		return m, nil
+	}
+	m.diffTrabajo = msg.trabajo
+	return m, nil
+}
+
+func (m Model) cerrarDiff() Model {
+	if m.diffTrabajo != nil {
+		m.diffTrabajo.Cancelar()
+	}
+	m.diffTrabajo = nil
+	m.vista = VistaSeleccionarModo
+	m.lista.SetItems(crearListaModos(m.tiendaParaDev, ObtenerGestor().TieneServidorActivo(m.tiendaParaDev.Nombre)))
+	m.mensaje = ""
+	return m
+}
+
+func (m Model) diferencias() []ArchivoDiff {
+	if m.diffTrabajo == nil {
+		return nil
+	}
+	return m.diffTrabajo.Diferencias()
+}
+
+func (m Model) filasDiff() int {
+	return max(m.lineasPorPagina()-3, 3)
+}
+
+func (m Model) updateDiff(msg tea.Msg) (tea.Model, tea.Cmd) {
+	teclaMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	if key.Matches(teclaMsg, Teclas.Repetir) && m.diffTrabajo != nil && !m.diffTrabajo.Activo() {
+		return m.abrirDiff()
+	}
+
+	archivos := m.diferencias()
+	if len(archivos) == 0 {
+		return m, nil
+	}
+	m.diffCursor = min(m.diffCursor, len(archivos)-1)
+
+	switch {
+	case key.Matches(teclaMsg, Teclas.Arriba):
+		m.diffCursor = max(m.diffCursor-1, 0)
+
+	case key.Matches(teclaMsg, Teclas.Abajo):
+		m.diffCursor = min(m.diffCursor+1, len(archivos)-1)
+
+	case key.Matches(teclaMsg, Teclas.Inicio):
+		m.diffCursor = 0
+
+	case key.Matches(teclaMsg, Teclas.Final):
+		m.diffCursor = len(archivos) - 1
+
+	case key.Matches(teclaMsg, Teclas.Seleccionar):
+		if archivos[m.diffCursor].Binario {
+			m.mensaje = IconInfo(T("diff.binario_sin_detalle"))
+			return m, nil
+		}
+		m.vista = VistaDiffArchivo
+		m.diffLineaScroll = 0
+		m.mensaje = ""
+	}
+
+	m.diffScroll = min(m.diffScroll, m.diffCursor)
+	m.diffScroll = max(m.diffScroll, m.diffCursor-m.filasDiff()+1)
+	return m, nil
+}
+
+func (m Model) maxScrollDiff() int {
+	archivos := m.diferencias()
+	if m.diffCursor >= len(archivos) {
+		return 0
+	}
+	return max(len(archivos[m.diffCursor].Lineas)-m.lineasPorPagina(), 0)
+}
+
+func (m Model) saltarArchivoDiff(paso int) Model {
+	archivos := m.diferencias()
+	for i := m.diffCursor + paso; i >= 0 && i < len(archivos); i += paso {
+		if !archivos[i].Binario {
+			m.diffCursor = i
+			m.diffLineaScroll = 0
+			break
+		}
+	}
+	m.diffScroll = min(m.diffScroll, m.diffCursor)
+	m.diffScroll = max(m.diffScroll, m.diffCursor-m.filasDiff()+1)
+	return m
+}
+
+func (m Model) updateDiffArchivo(msg tea.Msg) (tea.Model, tea.Cmd) {
+	teclaMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	switch {
+	case key.Matches(teclaMsg, Teclas.Arriba):
+		m.diffLineaScroll = max(m.diffLineaScroll-1, 0)
+
+	case key.Matches(teclaMsg, Teclas.Abajo):
+		m.diffLineaScroll = min(m.diffLineaScroll+1, m.maxScrollDiff())
+
+	case key.Matches(teclaMsg, Teclas.PaginaArriba):
+		m.diffLineaScroll = max(m.diffLineaScroll-m.lineasPorPagina(), 0)
+
+	case key.Matches(teclaMsg, Teclas.PaginaAbajo):
+		m.diffLineaScroll = min(m.diffLineaScroll+m.lineasPorPagina(), m.maxScrollDiff())
+
+	case key.Matches(teclaMsg, Teclas.Inicio):
+		m.diffLineaScroll = 0
+
+	case key.Matches(teclaMsg, Teclas.Final):
+		m.diffLineaScroll = m.maxScrollDiff()
+
+	case key.Matches(teclaMsg, Teclas.FocoSiguiente):
+		m = m.saltarArchivoDiff(1)
+
+	case key.Matches(teclaMsg, Teclas.FocoAnterior):
+		m = m.saltarArchivoDiff(-1)
+	}
+	return m, nil
+}
+
+func estiloEstadoDiff(estado string) (string, lipgloss.Style) {
+	switch estado {
+	case diffSoloLocal:
+		return "+", estiloExito
+	case diffSoloRemoto:
+		return "−", estiloError
+	}
+	return "~", estiloAviso
+}
+
+func resumenArchivoDiff(archivo ArchivoDiff) string {
+	if archivo.Binario {
+		return estiloDesc.Render(T("diff.binario"))
+	}
+	return estiloExito.Render("+"+strconv.Itoa(archivo.Agregadas)) + " " + estiloError.Render("−"+strconv.Itoa(archivo.Eliminadas))
+}
+
+func (m Model) vistaDiff() string {
+	var b strings.Builder
+
+	b.WriteString(estiloTitulo.Render("± " + T("diff.titulo", m.tiendaParaDev.Nombre)))
+	b.WriteString("\n")
+	b.WriteString(estiloDesc.Render(T("diff.leyenda", temaComparado(m.tiendaParaDev), m.tiendaParaDev.Ruta)))
+	b.WriteString("\n\n")
+
+	var info InfoTrabajo
+	if m.diffTrabajo != nil {
+		info = m.diffTrabajo.Info()
+	} else {
+		info.Estado = trabajoEjecutando
+	}
+
+	switch info.Estado {
+	case trabajoPendiente, trabajoEjecutando:
+		texto := T("diff.descargando")
+		if info.Estado == trabajoPendiente {
+			texto = T("diff.en_cola")
+		}
+		b.WriteString(m.spinner.View() + " " + estiloInfo.Render(texto))
+		b.WriteString("\n")
+		if info.Salida != "" {
+			b.WriteString(estiloDesc.Render(ansi.Truncate(info.Salida, max(m.anchoNovedades(), 20), "…")))
+			b.WriteString("\n")
+		}
+
+	case trabajoFallido:
+		b.WriteString(estiloError.Render(IconError(T("diff.error", info.Error))))
+		b.WriteString("\n")
+
+	case trabajoCancelado:
+		b.WriteString(estiloAviso.Render(IconWarning(T("diff.cancelado"))))
+		b.WriteString("\n")
+
+	default:
+		archivos := m.diferencias()
+		if len(archivos) == 0 {
+			b.WriteString(estiloExito.Render(IconSuccess(T("diff.sin_cambios"))))
+			b.WriteString("\n")
+			break
+		}
+
+		agregadas, eliminadas := 0, 0
+		anchoRuta := 0
+		for _, archivo := range archivos {
+			agregadas += archivo.Agregadas
+			eliminadas += archivo.Eliminadas
+			anchoRuta = max(anchoRuta, len([]rune(archivo.Ruta)))
+		}
+		anchoRuta = min(anchoRuta, max(m.anchoNovedades()-30, 20))
+
+		inicio := min(m.diffScroll, len(archivos))
+		fin := min(inicio+m.filasDiff(), len(archivos))
+		for i, archivo := range archivos[inicio:fin] {
+			cursor := "  "
+			if inicio+i == m.diffCursor {
+				cursor = "> "
+			}
+			marca, estilo := estiloEstadoDiff(archivo.Estado)
+			ruta := fmt.Sprintf("%-*s", anchoRuta, ansi.Truncate(archivo.Ruta, anchoRuta, "…"))
+			if inicio+i == m.diffCursor {
+				ruta = estiloItemSeleccionado.Render(ruta)
+			}
+			linea := cursor + estilo.Render(marca) + " " + ruta + "  " + resumenArchivoDiff(archivo)
+			if archivo.Estado != diffModificado {
+				linea += "  " + estiloDesc.Render(T("diff.estado."+archivo.Estado))
+			}
+			b.WriteString(linea)
+			b.WriteString("\n")
+		}
+		if len(archivos) > m.filasDiff() {
+			b.WriteString(estiloDesc.Render(T("diff.lista.posicion", inicio+1, fin, len(archivos))))
+			b.WriteString("\n")
+		}
+		b.WriteString("\n")
+		b.WriteString(estiloLabel.Render(T("diff.resumen", len(archivos), agregadas, eliminadas)))
+		b.WriteString("\n")
+	}
+
+	if m.mensaje != "" {
+		b.WriteString("\n")
+		b.WriteString(estiloAviso.Render(m.mensaje))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(estiloAyuda.Render(T("diff.ayuda", ayudaTecla(Teclas.Arriba), ayudaTecla(Teclas.Abajo),
+		ayudaTecla(Teclas.Seleccionar), ayudaTecla(Teclas.Repetir), ayudaTecla(Teclas.Volver))))
+	return estiloContenedor.Render(b.String())
+}
+
+func renderizarLineaDiff(ruta string, linea LineaDiff, anchoNumero, ancho int) string {
+	if linea.Tipo == '@' {
+		return estiloInfo.Render(linea.Texto)
+	}
+
+	numero := func(n int) string {
+		if n == 0 {
+			return strings.Repeat(" ", anchoNumero)
+		}
+		return fmt.Sprintf("%*d", anchoNumero, n)
+	}
+	margen := estiloDesc.Render(numero(linea.Remoto) + " " + numero(linea.Local) + " │")
+
+	base := lipgloss.NewStyle()
+	marca := base.Render(" ")
+	switch linea.Tipo {
+	case '+':
+		base = estiloDiffAgregado
+		marca = pintar(estiloExito, base, "+")
+	case '-':
+		base = estiloDiffEliminado
+		marca = pintar(estiloError, base, "-")
+	}
+
+	anchoCodigo := max(ancho-lipgloss.Width(margen)-1, 10)
+	texto := ansi.Truncate(strings.ReplaceAll(linea.Texto, "\t", "    "), anchoCodigo, "…")
+	codigo := resaltarCodigo(ruta, texto, base)
+	if linea.Tipo != ' ' {
+		codigo += base.Render(strings.Repeat(" ", max(anchoCodigo-lipgloss.Width(texto), 0)))
+	}
+	return margen + marca + codigo
+}
+
+func (m Model) vistaDiffArchivo() string {
+	var b strings.Builder
+
+	archivos := m.diferencias()
+	if m.diffCursor >= len(archivos) {
+		return m.vistaDiff()
+	}
+	archivo := archivos[m.diffCursor]
+
+	marca, estilo := estiloEstadoDiff(archivo.Estado)
+	b.WriteString(estiloTitulo.Render(estilo.Render(marca) + " " + archivo.Ruta))
+	b.WriteString("\n")
+	b.WriteString(resumenArchivoDiff(archivo) + estiloDesc.Render(" · "+T("diff.estado."+archivo.Estado)+" · "+
+		T("diff.archivo.posicion", m.diffCursor+1, len(archivos))))
+	b.WriteString("\n\n")
+
+	anchoNumero := 1
+	for _, linea := range archivo.Lineas {
+		anchoNumero = max(anchoNumero, len(strconv.Itoa(max(linea.Remoto, linea.Local))))
+	}
+
+	scroll := min(m.diffLineaScroll, m.maxScrollDiff())
+	inicio := min(scroll, len(archivo.Lineas))
+	fin := min(inicio+m.lineasPorPagina(), len(archivo.Lineas))
+	for _, linea := range archivo.Lineas[inicio:fin] {
+		b.WriteString(renderizarLineaDiff(archivo.Ruta, linea, anchoNumero, max(m.anchoNovedades(), 20)))
+		b.WriteString("\n")
+	}
+	if len(archivo.Lineas) > m.lineasPorPagina() {
+		b.WriteString(estiloDesc.Render(T("diff.posicion", inicio+1, fin, len(archivo.Lineas))))
+		b.WriteString("\n")
+	}
+
+	if m.mensaje != "" {
+		b.WriteString("\n")
+		b.WriteString(estiloAviso.Render(m.mensaje))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(estiloAyuda.Render(T("diff.archivo.ayuda", ayudaTecla(Teclas.Arriba), ayudaTecla(Teclas.Abajo),
+		ayudaTecla(Teclas.FocoSiguiente), ayudaTecla(Teclas.FocoAnterior), ayudaTecla(Teclas.Volver))))
+	return estiloContenedor.Render(b.String())
+}
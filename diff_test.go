@@ -0,0 +1,235 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func formatoLineas(lineas []LineaDiff) []string {
+	filas := []string{}
+	for _, linea := range lineas {
+		filas = append(filas, fmt.Sprintf("%c%d,%d %s", linea.Tipo, linea.Remoto, linea.Local, linea.Texto))
+	}
+	return filas
+}
+
+func TestDiferenciarLineas(t *testing.T) {
+	casos := []struct {
+		nombre   string
+		remoto   []string
+		local    []string
+		esperado []string
+	}{
+		{"solo inserción", []string{"a", "c"}, []string{"a", "b", "c"}, []string{" 1,1 a", "+0,2 b", " 2,3 c"}},
+		{"solo borrado", []string{"a", "b", "c"}, []string{"a", "c"}, []string{" 1,1 a", "-2,0 b", " 3,2 c"}},
+		{"reemplazo", []string{"a", "b", "c"}, []string{"a", "x", "c"}, []string{" 1,1 a", "-2,0 b", "+0,2 x", " 3,3 c"}},
+		{"todo igual", []string{"a", "b"}, []string{"a", "b"}, []string{" 1,1 a", " 2,2 b"}},
+		{"remoto vacío", nil, []string{"a", "b"}, []string{"+0,1 a", "+0,2 b"}},
+		{"local vacío", []string{"a", "b"}, nil, []string{"-1,0 a", "-2,0 b"}},
+		{"ambos vacíos", nil, nil, []string{}},
+		{"cambio al inicio", []string{"x", "b", "c"}, []string{"y", "b", "c"}, []string{"-1,0 x", "+0,1 y", " 2,2 b", " 3,3 c"}},
+		{"cambio al final", []string{"a", "b", "x"}, []string{"a", "b", "y"}, []string{" 1,1 a", " 2,2 b", "-3,0 x", "+0,3 y"}},
+		{"líneas repetidas", []string{"a", "b", "a", "b"}, []string{"b", "a", "b", "a"}, []string{"-1,0 a", " 2,1 b", " 3,2 a", " 4,3 b", "+0,4 a"}},
+	}
+
+	for _, caso := range casos {
+		if got := formatoLineas(diferenciarLineas(caso.remoto, caso.local)); !reflect.DeepEqual(got, caso.esperado) {
+			t.Errorf("%s: diferenciarLineas = %q, se esperaba %q", caso.nombre, got, caso.esperado)
+		}
+	}
+}
+
+func TestDiferenciarLineasSinLimite(t *testing.T) {
+	remoto, local := []string{"inicio"}, []string{"inicio"}
+	for i := range maxEdicionesDiff {
+		remoto = append(remoto, fmt.Sprintf("remoto %d", i))
+		local = append(local, fmt.Sprintf("local %d", i))
+	}
+	remoto, local = append(remoto, "fin"), append(local, "fin")
+
+	if _, ok := myers(remoto[1:len(remoto)-1], local[1:len(local)-1]); ok {
+		t.Fatalf("myers con %d ediciones debería rendirse", 2*maxEdicionesDiff)
+	}
+
+	lineas := diferenciarLineas(remoto, local)
+	if len(lineas) != 2*maxEdicionesDiff+2 {
+		t.Fatalf("%d líneas, se esperaban %d", len(lineas), 2*maxEdicionesDiff+2)
+	}
+	if got := formatoLineas(lineas[:1]); got[0] != " 1,1 inicio" {
+		t.Fatalf("primera línea = %q", got[0])
+	}
+	for i := range maxEdicionesDiff {
+		borrada, agregada := lineas[1+i], lineas[1+maxEdicionesDiff+i]
+		if borrada.Tipo != '-' || borrada.Texto != remoto[1+i] || borrada.Remoto != i+2 || borrada.Local != 0 {
+			t.Fatalf("línea %d = %+v, se esperaba el borrado de %q", 1+i, borrada, remoto[1+i])
+		}
+		if agregada.Tipo != '+' || agregada.Texto != local[1+i] || agregada.Local != i+2 || agregada.Remoto != 0 {
+			t.Fatalf("línea %d = %+v, se esperaba la inserción de %q", 1+maxEdicionesDiff+i, agregada, local[1+i])
+		}
+	}
+	if ultima := lineas[len(lineas)-1]; ultima.Tipo != ' ' || ultima.Remoto != maxEdicionesDiff+2 || ultima.Local != maxEdicionesDiff+2 {
+		t.Fatalf("última línea = %+v", ultima)
+	}
+}
+
+func TestEncabezadoBloque(t *testing.T) {
+	casos := []struct {
+		lineas      []LineaDiff
+		antesRemoto int
+		antesLocal  int
+		esperado    string
+	}{
+		{[]LineaDiff{{Tipo: ' '}, {Tipo: '-'}, {Tipo: '+'}, {Tipo: ' '}}, 4, 6, "@@ -5,3 +7,3 @@"},
+		{[]LineaDiff{{Tipo: '-'}, {Tipo: '+'}}, 0, 0, "@@ -1 +1 @@"},
+		{[]LineaDiff{{Tipo: '+'}, {Tipo: '+'}}, 0, 0, "@@ -0,0 +1,2 @@"},
+		{[]LineaDiff{{Tipo: '-'}}, 0, 0, "@@ -1 +0,0 @@"},
+		{[]LineaDiff{{Tipo: ' '}, {Tipo: '+'}}, 9, 9, "@@ -10 +10,2 @@"},
+		{[]LineaDiff{{Tipo: ' '}, {Tipo: '-'}}, 9, 9, "@@ -10,2 +10 @@"},
+	}
+
+	for _, caso := range casos {
+		if got := encabezadoBloque(caso.lineas, caso.antesRemoto, caso.antesLocal); got != caso.esperado {
+			t.Errorf("encabezadoBloque(%q, %d, %d) = %q, se esperaba %q", formatoLineas(caso.lineas), caso.antesRemoto, caso.antesLocal, got, caso.esperado)
+		}
+	}
+}
+
+func lineasNumeradas(desde, hasta int, cambios map[int]string) []string {
+	var lineas []string
+	for i := desde; i <= hasta; i++ {
+		if texto, existe := cambios[i]; existe {
+			if texto != "" {
+				lineas = append(lineas, texto)
+			}
+			continue
+		}
+		lineas = append(lineas, fmt.Sprintf("línea %d", i))
+	}
+	return lineas
+}
+
+func casosBloques() []struct {
+	nombre string
+	remoto []string
+	local  []string
+} {
+	base := lineasNumeradas(1, 30, nil)
+	return []struct {
+		nombre string
+		remoto []string
+		local  []string
+	}{
+		{"un cambio", base, lineasNumeradas(1, 30, map[int]string{15: "cambio"})},
+		{"cambio en la primera línea", base, lineasNumeradas(1, 30, map[int]string{1: "cambio"})},
+		{"cambio en la última línea", base, lineasNumeradas(1, 30, map[int]string{30: "cambio"})},
+		{"inserción al inicio", base, append([]string{"nueva"}, base...)},
+		{"borrado al final", base, base[:29]},
+		{"separados por el doble del contexto", base, lineasNumeradas(1, 30, map[int]string{10: "uno", 17: "dos"})},
+		{"separados por una línea más", base, lineasNumeradas(1, 30, map[int]string{10: "uno", 18: "dos"})},
+		{"borrados e inserciones", base, lineasNumeradas(1, 30, map[int]string{3: "", 4: "", 12: "x\ny", 25: ""})},
+		{"remoto vacío", nil, lineasNumeradas(1, 4, nil)},
+		{"local vacío", lineasNumeradas(1, 4, nil), nil},
+		{"una línea", []string{"a"}, []string{"b"}},
+	}
+}
+
+func contenidoArchivo(lineas []string) []byte {
+	if len(lineas) == 0 {
+		return nil
+	}
+	return []byte(strings.Join(lineas, "\n") + "\n")
+}
+
+func TestBloquesDiff(t *testing.T) {
+	casos := map[string]int{
+		"un cambio":                           1,
+		"separados por el doble del contexto": 1,
+		"separados por una línea más":         2,
+		"borrados e inserciones":              3,
+	}
+
+	for _, caso := range casosBloques() {
+		esperados, existe := casos[caso.nombre]
+		if !existe {
+			continue
+		}
+		archivo, _ := compararArchivo("a.liquid", contenidoArchivo(caso.remoto), contenidoArchivo(caso.local), true, true)
+		bloques := 0
+		for _, linea := range archivo.Lineas {
+			if linea.Tipo == '@' {
+				bloques++
+			}
+		}
+		if bloques != esperados {
+			t.Errorf("%s: %d bloques, se esperaban %d", caso.nombre, bloques, esperados)
+		}
+	}
+}
+
+func TestBloquesDiffComoDiffUnificado(t *testing.T) {
+	if _, err := exec.LookPath("diff"); err != nil {
+		t.Skip("diff no está instalado")
+	}
+	carpeta := t.TempDir()
+
+	for _, caso := range casosBloques() {
+		remoto, local := contenidoArchivo(caso.remoto), contenidoArchivo(caso.local)
+		rutaRemoto, rutaLocal := filepath.Join(carpeta, "remoto"), filepath.Join(carpeta, "local")
+		if err := os.WriteFile(rutaRemoto, remoto, 0644); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(rutaLocal, local, 0644); err != nil {
+			t.Fatal(err)
+		}
+
+		salida, err := exec.Command("diff", fmt.Sprintf("-U%d", contextoDiff), rutaRemoto, rutaLocal).Output()
+		var errorSalida *exec.ExitError
+		if err != nil && (!errors.As(err, &errorSalida) || errorSalida.ExitCode() != 1) {
+			t.Fatalf("%s: diff: %v", caso.nombre, err)
+		}
+		esperados := []string{}
+		for _, linea := range strings.Split(string(salida), "\n") {
+			if strings.HasPrefix(linea, "@@") {
+				esperados = append(esperados, linea)
+			}
+		}
+
+		archivo, distinto := compararArchivo("a.liquid", remoto, local, true, true)
+		if !distinto {
+			t.Fatalf("%s: compararArchivo no encontró diferencias", caso.nombre)
+		}
+		encabezados := []string{}
+		for _, linea := range archivo.Lineas {
+			if linea.Tipo == '@' {
+				encabezados = append(encabezados, linea.Texto)
+			}
+		}
+		if !reflect.DeepEqual(encabezados, esperados) {
+			t.Errorf("%s: encabezados = %q, diff -u muestra %q", caso.nombre, encabezados, esperados)
+		}
+	}
+}
+
+func TestTemaComparado(t *testing.T) {
+	casos := []struct {
+		tema     string
+		esperado string
+	}{
+		{"", T("diff.tema_publicado")},
+		{temaPublicado, T("diff.tema_publicado")},
+		{temaDesarrollo, T("diff.tema_desarrollo")},
+		{"123456789", T("diff.tema", "123456789")},
+	}
+
+	for _, caso := range casos {
+		if got := temaComparado(Tienda{TemaRemoto: caso.tema}); got != caso.esperado {
+			t.Errorf("temaComparado(%q) = %q, se esperaba %q", caso.tema, got, caso.esperado)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const capacidadEventos = 256
+
+type TipoEvento int
+
+const (
+	EventoIniciado TipoEvento = iota
+	EventoListo
+	EventoDeteniendo
+	EventoDetenido
+	EventoCaido
+	EventoLog
+	EventoURL
+	EventoSalud
+	EventoRecursos
+)
+
+type EventoServidor struct {
+	Tipo   TipoEvento
+	Tienda string
+	Texto  string
+}
+
+type eventosServidorMsg []EventoServidor
+
+type InstantaneaServidor struct {
+	Tienda       Tienda
+	Puerto       int
+	Iniciado     time.Time
+	URL          string
+	URLRed       string
+	URLCompartir string
+	Ciclo        CicloVida
+	Salud        Salud
+	Recursos     Recursos
+	Lineas       []LineaLog
+	Errores      int
+	Acompanantes []EstadoAcompanante
+}
+
+type suscripcion struct {
+	eventos    chan EventoServidor
+	mutex      sync.Mutex
+	pendientes []EventoServidor
+	aviso      chan struct{}
+	fin        chan struct{}
+}
+
+func (t TipoEvento) descartable() bool {
+	return t == EventoLog || t == EventoRecursos
+}
+
+var patronURLCompartir = regexp.MustCompile(`https://[^\s]+[?&]preview_theme_id=\d+`)
+
+func (g *GestorServidores) Suscribir() <-chan EventoServidor {
+	g.eventosMutex.Lock()
+	defer g.eventosMutex.Unlock()
+
+	nueva := &suscripcion{
+		eventos: make(chan EventoServidor, capacidadEventos),
+		aviso:   make(chan struct{}, 1),
+		fin:     make(chan struct{}),
+	}
+	g.suscriptores = append(g.suscriptores, nueva)
+	go nueva.repartir()
+	return nueva.eventos
+}
+
+func (g *GestorServidores) CancelarSuscripcion(eventos <-chan EventoServidor) {
+	g.eventosMutex.Lock()
+	defer g.eventosMutex.Unlock()
+
+	for i, suscriptor := range g.suscriptores {
+		if suscriptor.eventos == eventos {
+			g.suscriptores = append(g.suscriptores[:i], g.suscriptores[i+1:]...)
+			close(suscriptor.fin)
+			return
+		}
+	}
+}
+
+func (g *GestorServidores) publicar(tipo TipoEvento, tienda, texto string) {
+	g.eventosMutex.Lock()
+	defer g.eventosMutex.Unlock()
+
+	evento := EventoServidor{Tipo: tipo, Tienda: tienda, Texto: texto}
+	for _, suscriptor := range g.suscriptores {
+		suscriptor.encolar(evento)
+	}
+}
+
+func (s *suscripcion) encolar(evento EventoServidor) {
+	s.mutex.Lock()
+	if evento.Tipo.descartable() && len(s.pendientes) >= capacidadEventos {
+		s.mutex.Unlock()
+		return
+	}
+	s.pendientes = append(s.pendientes, evento)
+	s.mutex.Unlock()
+
+	select {
+	case s.aviso <- struct{}{}:
+	default:
+	}
+}
+
+func (s *suscripcion) repartir() {
+	defer close(s.eventos)
+
+	for {
+		select {
+		case <-s.aviso:
+		case <-s.fin:
+			return
+		}
+
+		s.mutex.Lock()
+		pendientes := s.pendientes
+		s.pendientes = nil
+		s.mutex.Unlock()
+
+		for _, evento := range pendientes {
+			select {
+			case s.eventos <- evento:
+			case <-s.fin:
+				return
+			}
+		}
+	}
+}
+
+func (s *ServidorActivo) detectarURL(linea string) {
+	url := patronURLCompartir.FindString(ansi.Strip(linea))
+	if url == "" {
+		return
+	}
+
+	s.LogsMutex.Lock()
+	nueva := s.urlCompartir != url
+	s.urlCompartir = url
+	s.LogsMutex.Unlock()
+
+	if nueva {
+		ObtenerGestor().publicar(EventoURL, s.Tienda.Nombre, url)
+	}
+}
+
+func (s *ServidorActivo) instantanea() InstantaneaServidor {
+	recursos := s.Recursos()
+
+	s.LogsMutex.RLock()
+	defer s.LogsMutex.RUnlock()
+
+	return InstantaneaServidor{
+		Tienda:       s.Tienda,
+		Puerto:       s.Puerto,
+		Iniciado:     s.Iniciado,
+		URL:          s.URL,
+		URLRed:       s.URLRed,
+		URLCompartir: s.urlCompartir,
+		Ciclo:        s.ciclo,
+		Salud:        s.salud,
+		Recursos:     recursos,
+		Lineas:       append([]LineaLog(nil), s.Logs...),
+		Errores:      s.errores,
+	}
+}
+
+func (g *GestorServidores) Instantaneas() map[string]InstantaneaServidor {
+	g.mutex.RLock()
+	defer g.mutex.RUnlock()
+
+	instantaneas := make(map[string]InstantaneaServidor, len(g.servidores))
+	for nombre, servidor := range g.servidores {
+		instantanea := servidor.instantanea()
+		instantanea.Acompanantes = estadosAcompanantes(servidor)
+		instantaneas[nombre] = instantanea
+	}
+	return instantaneas
+}
+
+func (i *InstantaneaServidor) Activo() bool {
+	return i.Ciclo.Estado.Activo()
+}
+
+func (i *InstantaneaServidor) Logs() []string {
+	logs := make([]string, len(i.Lineas))
+	for j, linea := range i.Lineas {
+		logs[j] = linea.Texto
+	}
+	return logs
+}
+
+func (m Model) instantanea(nombreTienda string) *InstantaneaServidor {
+	instantanea, existe := m.servidores[nombreTienda]
+	if !existe {
+		return nil
+	}
+	return &instantanea
+}
+
+func (m Model) servidoresActivos() []*InstantaneaServidor {
+	var activos []*InstantaneaServidor
+	for nombre := range m.servidores {
+		if instantanea := m.instantanea(nombre); instantanea.Activo() {
+			activos = append(activos, instantanea)
+		}
+	}
+	sort.Slice(activos, func(i, j int) bool {
+		return activos[i].Tienda.Nombre < activos[j].Tienda.Nombre
+	})
+	return activos
+}
+
+func (m Model) tieneServidorActivo(nombreTienda string) bool {
+	instantanea := m.instantanea(nombreTienda)
+	return instantanea != nil && instantanea.Activo()
+}
+
+func esperarEventos(eventos <-chan EventoServidor) tea.Cmd {
+	if eventos == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		evento, ok := <-eventos
+		if !ok {
+			return nil
+		}
+
+		lote := eventosServidorMsg{evento}
+		for len(lote) < capacidadEventos {
+			select {
+			case evento, ok := <-eventos:
+				if !ok {
+					return lote
+				}
+				lote = append(lote, evento)
+			default:
+				return lote
+			}
+		}
+		return lote
+	}
+}
+
+func (m Model) aplicarEventos(eventos eventosServidorMsg) (tea.Model, tea.Cmd) {
+	cambioEstado := false
+	for _, evento := range eventos {
+		switch evento.Tipo {
+		case EventoIniciado, EventoListo, EventoDeteniendo, EventoDetenido, EventoSalud:
+			cambioEstado = true
+		case EventoCaido:
+			cambioEstado = true
+			m.mensaje = IconError(T("servidores.caido", evento.Tienda))
+		}
+	}
+
+	m.servidores = ObtenerGestor().Instantaneas()
+	if !cambioEstado {
+		return m, esperarEventos(m.eventos)
+	}
+	return m, tea.Batch(m.refrescarListas(), esperarEventos(m.eventos))
+}
+
+func (m *Model) refrescarListas() tea.Cmd {
+	indice := m.lista.Index()
+	var cmd tea.Cmd
+	switch m.vista {
+	case VistaSeleccionarModo:
+		cmd = m.lista.SetItems(crearListaModos(m.tiendaParaDev, m.tieneServidorActivo(m.tiendaParaDev.Nombre)))
+	case VistaSeleccionarTienda:
+		items := m.lista.Items()
+		for i, item := range items {
+			if tienda, ok := item.(itemTienda); ok {
+				tienda.servidor = m.instantanea(tienda.tienda.Nombre)
+				items[i] = tienda
+			}
+		}
+		cmd = m.lista.SetItems(items)
+	default:
+		return nil
+	}
+	m.lista.Select(indice)
+	return cmd
+}
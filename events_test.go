@@ -0,0 +1,106 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventosCicloVidaSinPerdidas(t *testing.T) {
+	gestor := &GestorServidores{servidores: make(map[string]*ServidorActivo)}
+	eventos := gestor.Suscribir()
+	defer gestor.CancelarSuscripcion(eventos)
+
+	ciclo := []TipoEvento{EventoIniciado, EventoListo, EventoDeteniendo, EventoDetenido, EventoCaido}
+	for _, tipo := range ciclo {
+		for range 3 * capacidadEventos {
+			gestor.publicar(EventoLog, "saturada", "línea")
+			gestor.publicar(EventoRecursos, "saturada", "")
+		}
+		gestor.publicar(tipo, "saturada", "")
+	}
+
+	var recibidos []TipoEvento
+	logs := 0
+	limite := time.After(10 * time.Second)
+	for len(recibidos) < len(ciclo) {
+		select {
+		case evento := <-eventos:
+			if evento.Tipo.descartable() {
+				logs++
+				continue
+			}
+			recibidos = append(recibidos, evento.Tipo)
+		case <-limite:
+			t.Fatalf("eventos de ciclo de vida recibidos = %v, se esperaban %v", recibidos, ciclo)
+		}
+	}
+
+	for i, tipo := range ciclo {
+		if recibidos[i] != tipo {
+			t.Fatalf("eventos de ciclo de vida = %v, se esperaban %v en orden", recibidos, ciclo)
+		}
+	}
+	if publicados := 2 * 3 * capacidadEventos * len(ciclo); logs >= publicados {
+		t.Fatalf("se recibieron los %d eventos descartables; la cola no aplicó contrapresión", logs)
+	}
+}
+
+func TestAplicarEventosRefrescaTiendas(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("USERPROFILE", t.TempDir())
+
+	gestor := ObtenerGestor()
+	servidor := &ServidorActivo{
+		Tienda: Tienda{Nombre: "instantanea"},
+		URL:    "http://127.0.0.1:9555",
+		ciclo:  CicloVida{Estado: EstadoListo},
+		Logs:   []LineaLog{{Texto: "Error: al compilar"}},
+	}
+	gestor.mutex.Lock()
+	gestor.servidores["instantanea"] = servidor
+	gestor.mutex.Unlock()
+	t.Cleanup(func() {
+		gestor.mutex.Lock()
+		delete(gestor.servidores, "instantanea")
+		gestor.mutex.Unlock()
+	})
+
+	m := Model{vista: VistaSeleccionarTienda, tiendas: []Tienda{{Nombre: "otra"}, {Nombre: "instantanea"}}, ancho: 100, alto: 30}
+	m.recrearListaTiendas()
+	for _, item := range m.lista.Items() {
+		if item.(itemTienda).activo() {
+			t.Fatal("la lista se construyó antes de la instantánea y no debería mostrar servidores")
+		}
+	}
+
+	nuevo, _ := m.aplicarEventos(eventosServidorMsg{{Tipo: EventoListo, Tienda: "instantanea"}})
+	m = nuevo.(Model)
+	item := m.lista.Items()[1].(itemTienda)
+	if !strings.HasPrefix(item.Title(), Icons.ServerOn) || !strings.Contains(item.Description(), servidor.URL) {
+		t.Fatalf("item = %q / %q, se esperaba el servidor activo", item.Title(), item.Description())
+	}
+	if activos := m.servidoresActivos(); len(activos) != 1 || activos[0].Errores != 0 || len(activos[0].Lineas) != 1 {
+		t.Fatalf("servidoresActivos = %+v", activos)
+	}
+
+	servidor.AgregarLog("nueva línea")
+	if lineas := m.instantanea("instantanea").Lineas; len(lineas) != 1 {
+		t.Fatalf("la instantánea cambió sin pasar por aplicarEventos: %d líneas", len(lineas))
+	}
+
+	servidor.transicionar(EstadoDeteniendo)
+	servidor.registrarSalida(nil)
+	nuevo, _ = m.aplicarEventos(eventosServidorMsg{{Tipo: EventoLog, Tienda: "instantanea"}, {Tipo: EventoDetenido, Tienda: "instantanea"}})
+	m = nuevo.(Model)
+	item = m.lista.Items()[1].(itemTienda)
+	if item.activo() || strings.HasPrefix(item.Title(), Icons.ServerOn) {
+		t.Fatalf("item = %q, el servidor detenido no debe aparecer activo", item.Title())
+	}
+	if lineas := m.instantanea("instantanea").Lineas; len(lineas) != 2 {
+		t.Fatalf("instantánea con %d líneas, se esperaban 2 tras el evento", len(lineas))
+	}
+	if len(m.servidoresActivos()) != 0 {
+		t.Fatal("no deberían quedar servidores activos")
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/sahilm/fuzzy"
+)
+
+const separadorFiltro = "\n"
+
+const (
+	campoNombre = iota
+	campoURL
+	campoGit
+	campoEtiquetas
+)
+
+func camposFiltro(t Tienda) []string {
+	return []string{t.Nombre, t.URL, t.GitURL, textoEtiquetas(t.Etiquetas)}
+}
+
+func textoEtiquetas(etiquetas []string) string {
+	if len(etiquetas) == 0 {
+		return ""
+	}
+	return "#" + strings.Join(etiquetas, " #")
+}
+
+func filtrarTiendas(termino string, objetivos []string) []list.Rank {
+	if strings.HasPrefix(termino, "#") {
+		return filtrarPorEtiquetas(normalizarEtiquetas(termino), objetivos)
+	}
+
+	type resultado struct {
+		rank   list.Rank
+		puntos int
+	}
+
+	var resultados []resultado
+	for i, objetivo := range objetivos {
+		if objetivo == "" {
+			continue
+		}
+
+		encontrado := false
+		mejor := resultado{rank: list.Rank{Index: i}}
+
+		inicio := 0
+		for _, campo := range strings.Split(objetivo, separadorFiltro) {
+			coincidencias := fuzzy.Find(termino, []string{campo})
+			if len(coincidencias) > 0 && (!encontrado || coincidencias[0].Score > mejor.puntos) {
+				encontrado = true
+				mejor.puntos = coincidencias[0].Score
+
+				indices := make([]int, len(coincidencias[0].MatchedIndexes))
+				for j, indice := range coincidencias[0].MatchedIndexes {
+					indices[j] = inicio + indice
+				}
+				mejor.rank.MatchedIndexes = indices
+			}
+			inicio += len(campo) + len(separadorFiltro)
+		}
+
+		if encontrado {
+			resultados = append(resultados, mejor)
+		}
+	}
+
+	sort.SliceStable(resultados, func(a, b int) bool {
+		return resultados[a].puntos > resultados[b].puntos
+	})
+
+	ranks := make([]list.Rank, len(resultados))
+	for i, r := range resultados {
+		ranks[i] = r.rank
+	}
+	return ranks
+}
+
+func filtrarPorEtiquetas(etiquetas []string, objetivos []string) []list.Rank {
+	var ranks []list.Rank
+	for i, objetivo := range objetivos {
+		campos := strings.Split(objetivo, separadorFiltro)
+		if len(campos) <= campoEtiquetas || len(etiquetas) == 0 {
+			continue
+		}
+
+		inicio := len(objetivo) - len(campos[campoEtiquetas])
+		presentes := strings.Fields(campos[campoEtiquetas])
+
+		var indices []int
+		for _, buscada := range etiquetas {
+			desde := inicio
+			encontrada := false
+			for _, presente := range presentes {
+				if strings.EqualFold(strings.TrimPrefix(presente, "#"), buscada) {
+					for j := range presente {
+						indices = append(indices, desde+j)
+					}
+					encontrada = true
+					break
+				}
+				desde += len(presente) + 1
+			}
+			if !encontrada {
+				indices = nil
+				break
+			}
+		}
+
+		if indices != nil {
+			ranks = append(ranks, list.Rank{Index: i, MatchedIndexes: indices})
+		}
+	}
+	return ranks
+}
+
+func resaltarCoincidencias(texto string, desde int, indices []int, estilo lipgloss.Style) string {
+	if len(indices) == 0 {
+		return estilo.Render(texto)
+	}
+
+	marcados := make(map[int]bool, len(indices))
+	for _, indice := range indices {
+		marcados[indice-desde] = true
+	}
+
+	var b strings.Builder
+	var tramo strings.Builder
+	resaltando := false
+
+	volcar := func() {
+		if tramo.Len() == 0 {
+			return
+		}
+		if resaltando {
+			b.WriteString(estiloCoincidencia.Inherit(estilo).Render(tramo.String()))
+		} else {
+			b.WriteString(estilo.Render(tramo.String()))
+		}
+		tramo.Reset()
+	}
+
+	for i := 0; i < len(texto); {
+		r, ancho := utf8.DecodeRuneInString(texto[i:])
+		if marcados[i] != resaltando {
+			volcar()
+			resaltando = marcados[i]
+		}
+		tramo.WriteRune(r)
+		i += ancho
+	}
+	volcar()
+
+	return b.String()
+}
+
+func rangoCampo(t Tienda, campo int) int {
+	inicio := 0
+	for i, valor := range camposFiltro(t) {
+		if i == campo {
+			return inicio
+		}
+		inicio += len(valor) + len(separadorFiltro)
+	}
+	return inicio
+}
+
+func coincidenEnCampo(t Tienda, campo int, indices []int) bool {
+	inicio := rangoCampo(t, campo)
+	fin := inicio + len(camposFiltro(t)[campo])
+	for _, indice := range indices {
+		if indice >= inicio && indice < fin {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFiltrarTiendas(t *testing.T) {
+	tiendas := []Tienda{
+		{Nombre: "Moda Norte", URL: "moda-norte.myshopify.com", Etiquetas: []string{"cliente", "moda"}},
+		{Nombre: "Zapatos Sur", URL: "zapatos-sur.myshopify.com", GitURL: "git@github.com:acme/calzado.git", Etiquetas: []string{"cliente"}},
+		{Nombre: "Pruebas", URL: "acme-staging.myshopify.com", Etiquetas: []string{"interna"}},
+		{Nombre: "Modelos", URL: "modelos.myshopify.com"},
+	}
+	var objetivos []string
+	for _, tienda := range tiendas {
+		objetivos = append(objetivos, itemTienda{tienda: tienda}.FilterValue())
+	}
+
+	casos := []struct {
+		nombre   string
+		termino  string
+		esperado []string
+		campo    int
+	}{
+		{"nombre más ajustado primero", "mod", []string{"Modelos", "Moda Norte"}, campoNombre},
+		{"difuso entre palabras", "zsur", []string{"Zapatos Sur"}, campoNombre},
+		{"por dominio", "staging", []string{"Pruebas"}, campoURL},
+		{"por repositorio", "calzado", []string{"Zapatos Sur"}, campoGit},
+		{"sin coincidencias", "xyz", nil, campoNombre},
+		{"una etiqueta", "#cliente", []string{"Moda Norte", "Zapatos Sur"}, campoEtiquetas},
+		{"varias etiquetas", "#cliente #moda", []string{"Moda Norte"}, campoEtiquetas},
+		{"etiqueta sin distinguir mayúsculas", "#INTERNA", []string{"Pruebas"}, campoEtiquetas},
+		{"etiqueta inexistente", "#mayorista", nil, campoEtiquetas},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			ranks := filtrarTiendas(caso.termino, objetivos)
+
+			var nombres []string
+			for _, rank := range ranks {
+				nombres = append(nombres, tiendas[rank.Index].Nombre)
+				if !coincidenEnCampo(tiendas[rank.Index], caso.campo, rank.MatchedIndexes) {
+					t.Errorf("%s: las coincidencias %v no caen en el campo %d", tiendas[rank.Index].Nombre, rank.MatchedIndexes, caso.campo)
+				}
+			}
+			if !reflect.DeepEqual(nombres, caso.esperado) {
+				t.Fatalf("filtrarTiendas(%q) = %v, se esperaba %v", caso.termino, nombres, caso.esperado)
+			}
+		})
+	}
+}
+
+func TestFiltrarTiendasVacias(t *testing.T) {
+	if ranks := filtrarTiendas("a", []string{"", ""}); len(ranks) != 0 {
+		t.Fatalf("los objetivos vacíos no deberían coincidir: %v", ranks)
+	}
+	if ranks := filtrarTiendas("#", []string{itemTienda{tienda: Tienda{Nombre: "a", Etiquetas: []string{"x"}}}.FilterValue()}); ranks != nil {
+		t.Fatalf("un filtro de etiquetas vacío no debería coincidir: %v", ranks)
+	}
+}
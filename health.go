@@ -0,0 +1,215 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	saludIniciando = "iniciando"
+	saludSana      = "sana"
+	saludDegradada = "degradada"
+	saludCaida     = "caida"
+)
+
+type ConfigSalud struct {
+	Desactivada bool `json:"desactivada,omitempty"`
+	Intervalo   int  `json:"intervalo,omitempty"`
+	Timeout     int  `json:"timeout,omitempty"`
+	Lenta       int  `json:"lenta_ms,omitempty"`
+	Fallos      int  `json:"fallos,omitempty"`
+	Arranque    int  `json:"arranque,omitempty"`
+	Reiniciar   bool `json:"reiniciar,omitempty"`
+}
+
+var SaludGlobal = configSaludPorDefecto()
+
+func configSaludPorDefecto() ConfigSalud {
+	return ConfigSalud{
+		Intervalo: 10,
+		Timeout:   3,
+		Lenta:     2000,
+		Fallos:    3,
+		Arranque:  120,
+	}
+}
+
+func InitSalud(config ConfigSalud) error {
+	for _, campo := range []struct {
+		nombre string
+		valor  int
+	}{
+		{"intervalo", config.Intervalo},
+		{"timeout", config.Timeout},
+		{"lenta_ms", config.Lenta},
+		{"fallos", config.Fallos},
+		{"arranque", config.Arranque},
+	} {
+		if campo.valor < 0 {
+			return errors.New(T("salud.valor_invalido", campo.nombre, campo.valor))
+		}
+	}
+
+	predeterminada := configSaludPorDefecto()
+	if config.Intervalo == 0 {
+		config.Intervalo = predeterminada.Intervalo
+	}
+	if config.Timeout == 0 {
+		config.Timeout = predeterminada.Timeout
+	}
+	if config.Lenta == 0 {
+		config.Lenta = predeterminada.Lenta
+	}
+	if config.Fallos == 0 {
+		config.Fallos = predeterminada.Fallos
+	}
+	if config.Arranque == 0 {
+		config.Arranque = predeterminada.Arranque
+	}
+
+	SaludGlobal = config
+	return nil
+}
+
+type Salud struct {
+	Estado        string
+	Latencia      time.Duration
+	UltimoExito   time.Time
+	UltimoChequeo time.Time
+	Fallos        int
+	Error         string
+}
+
+func (s Salud) Texto() string {
+	switch s.Estado {
+	case saludSana:
+		return T("salud.sana", s.Latencia.Round(time.Millisecond))
+	case saludDegradada:
+		if s.Fallos > 0 {
+			return T("salud.sin_respuesta", s.Fallos, s.Error)
+		}
+		return T("salud.lenta", s.Latencia.Round(time.Millisecond))
+	case saludCaida:
+		return T("salud.caida", s.Fallos, s.Error)
+	}
+	return T("salud.iniciando")
+}
+
+func (s *ServidorActivo) Salud() Salud {
+	s.LogsMutex.RLock()
+	defer s.LogsMutex.RUnlock()
+	return s.salud
+}
+
+func (s *ServidorActivo) registrarChequeo(latencia time.Duration, err error) Salud {
+	s.LogsMutex.Lock()
+	defer s.LogsMutex.Unlock()
+
+	ahora := time.Now()
+	s.salud.UltimoChequeo = ahora
+
+	if err == nil {
+		s.salud.Latencia = latencia
+		s.salud.UltimoExito = ahora
+		s.salud.Fallos = 0
+		s.salud.Error = ""
+		s.salud.Estado = saludSana
+		if latencia > time.Duration(s.configSalud.Lenta)*time.Millisecond {
+			s.salud.Estado = saludDegradada
+		}
+		return s.salud
+	}
+
+	s.salud.Fallos++
+	s.salud.Error = resumirError(err)
+	switch {
+	case s.salud.UltimoExito.IsZero() && ahora.Sub(s.Iniciado) < time.Duration(s.configSalud.Arranque)*time.Second:
+		s.salud.Estado = saludIniciando
+	case s.salud.Fallos >= s.configSalud.Fallos:
+		s.salud.Estado = saludCaida
+	default:
+		s.salud.Estado = saludDegradada
+	}
+	return s.salud
+}
+
+func resumirError(err error) string {
+	var errRed net.Error
+	if errors.As(err, &errRed) && errRed.Timeout() {
+		return "timeout"
+	}
+	texto := err.Error()
+	if i := strings.LastIndex(texto, ": "); i >= 0 {
+		return texto[i+2:]
+	}
+	return texto
+}
+
+func sondearServidor(cliente *http.Client, url string) (time.Duration, error) {
+	inicio := time.Now()
+	respuesta, err := cliente.Get(url)
+	if err != nil {
+		return 0, err
+	}
+	respuesta.Body.Close()
+
+	if respuesta.StatusCode >= http.StatusInternalServerError {
+		return 0, fmt.Errorf("HTTP %d", respuesta.StatusCode)
+	}
+	return time.Since(inicio), nil
+}
+
+func (g *GestorServidores) vigilarSalud(servidor *ServidorActivo) {
+	config := servidor.configSalud
+	if config.Desactivada {
+		return
+	}
+
+	cliente := &http.Client{
+		Timeout: time.Duration(config.Timeout) * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+
+	intervalo := time.NewTicker(time.Duration(config.Intervalo) * time.Second)
+	defer intervalo.Stop()
+
+	for {
+		select {
+		case <-servidor.terminado:
+			return
+		case <-intervalo.C:
+		}
+
+		anterior := servidor.Salud().Estado
+		salud := servidor.registrarChequeo(sondearServidor(cliente, servidor.URL))
+		if salud.Estado == saludSana {
+			g.cambiarEstado(servidor, EstadoListo)
+		}
+		if salud.Estado == anterior {
+			continue
+		}
+		g.publicar(EventoSalud, servidor.Tienda.Nombre, salud.Texto())
+
+		switch salud.Estado {
+		case saludSana:
+			if anterior != saludIniciando {
+				servidor.AgregarLog(IconSuccess(T("salud.recuperada")))
+			}
+		case saludDegradada:
+			servidor.AgregarLog(IconWarning(salud.Texto()))
+		case saludCaida:
+			servidor.AgregarLog(IconError(salud.Texto()))
+			if config.Reiniciar {
+				servidor.AgregarLog(IconWarning(T("salud.reiniciando")))
+				go g.ReiniciarServidor(servidor.Tienda)
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRegistrarChequeo(t *testing.T) {
+	config := ConfigSalud{Lenta: 500, Fallos: 3, Arranque: 60}
+	fallo := errors.New("dial tcp 127.0.0.1:9292: connect: connection refused")
+
+	type chequeo struct {
+		latencia time.Duration
+		err      error
+	}
+	casos := []struct {
+		nombre     string
+		iniciado   time.Duration
+		chequeos   []chequeo
+		estado     string
+		fallos     int
+		errorCorto string
+	}{
+		{"responde rápido", time.Second, []chequeo{{100 * time.Millisecond, nil}}, saludSana, 0, ""},
+		{"responde lento", time.Second, []chequeo{{time.Second, nil}}, saludDegradada, 0, ""},
+		{"fallos durante el arranque", time.Second, []chequeo{{0, fallo}, {0, fallo}, {0, fallo}, {0, fallo}}, saludIniciando, 4, "connection refused"},
+		{"arranque agotado", 2 * time.Minute, []chequeo{{0, fallo}}, saludDegradada, 1, "connection refused"},
+		{"fallos tras responder", time.Second, []chequeo{{10 * time.Millisecond, nil}, {0, fallo}, {0, fallo}}, saludDegradada, 2, "connection refused"},
+		{"caída tras el límite", time.Second, []chequeo{{10 * time.Millisecond, nil}, {0, fallo}, {0, fallo}, {0, fallo}}, saludCaida, 3, "connection refused"},
+		{"se recupera", time.Second, []chequeo{{10 * time.Millisecond, nil}, {0, fallo}, {0, fallo}, {0, fallo}, {20 * time.Millisecond, nil}}, saludSana, 0, ""},
+	}
+
+	for _, caso := range casos {
+		servidor := &ServidorActivo{Iniciado: time.Now().Add(-caso.iniciado), configSalud: config}
+		var salud Salud
+		for _, c := range caso.chequeos {
+			salud = servidor.registrarChequeo(c.latencia, c.err)
+		}
+		if salud.Estado != caso.estado || salud.Fallos != caso.fallos || salud.Error != caso.errorCorto {
+			t.Errorf("%s: salud = %s/%d/%q, se esperaba %s/%d/%q",
+				caso.nombre, salud.Estado, salud.Fallos, salud.Error, caso.estado, caso.fallos, caso.errorCorto)
+		}
+	}
+}
+
+func TestSondearServidor(t *testing.T) {
+	casos := []struct {
+		codigo int
+		falla  bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusFound, false},
+		{http.StatusNotFound, false},
+		{http.StatusInternalServerError, true},
+		{http.StatusBadGateway, true},
+	}
+
+	cliente := &http.Client{
+		Timeout: time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	for _, caso := range casos {
+		servidor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if caso.codigo == http.StatusFound {
+				w.Header().Set("Location", "/otra")
+			}
+			w.WriteHeader(caso.codigo)
+		}))
+		_, err := sondearServidor(cliente, servidor.URL)
+		servidor.Close()
+		if (err != nil) != caso.falla {
+			t.Errorf("HTTP %d: err = %v, se esperaba fallo %v", caso.codigo, err, caso.falla)
+		}
+	}
+
+	if _, err := sondearServidor(cliente, "http://127.0.0.1:1"); err == nil {
+		t.Error("un puerto cerrado debería fallar")
+	}
+}
+
+func TestInitSalud(t *testing.T) {
+	anterior := SaludGlobal
+	t.Cleanup(func() { SaludGlobal = anterior })
+
+	if err := InitSalud(ConfigSalud{Fallos: -1}); err == nil {
+		t.Fatal("un valor negativo debería ser inválido")
+	}
+	if err := InitSalud(ConfigSalud{Intervalo: 5}); err != nil {
+		t.Fatal(err)
+	}
+	esperada := configSaludPorDefecto()
+	esperada.Intervalo = 5
+	if SaludGlobal != esperada {
+		t.Fatalf("SaludGlobal = %+v, se esperaba %+v", SaludGlobal, esperada)
+	}
+}
@@ -0,0 +1,294 @@
+package main
+
+import (
+	"path"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+type resaltador struct {
+	comentarioLinea  string
+	comentarioBloque [2]string
+	palabras         map[string]bool
+	plantillas       bool
+	guiones          bool
+	claves           bool
+	filtros          bool
+}
+
+func conjuntoPalabras(palabras string) map[string]bool {
+	conjunto := make(map[string]bool)
+	for _, palabra := range strings.Fields(palabras) {
+		conjunto[palabra] = true
+	}
+	return conjunto
+}
+
+var resaltadorJS = resaltador{
+	comentarioLinea:  "//",
+	comentarioBloque: [2]string{"/*", "*/"},
+	palabras: conjuntoPalabras("async await break case catch class const continue default delete do else export extends " +
+		"false finally for from function if import in instanceof let new null of return static super switch this throw " +
+		"true try typeof undefined var void while yield"),
+	plantillas: true,
+	claves:     true,
+}
+
+var resaltadorCSS = resaltador{
+	comentarioBloque: [2]string{"/*", "*/"},
+	palabras:         conjuntoPalabras("@media @import @font-face @keyframes @supports @layer !important"),
+	guiones:          true,
+	claves:           true,
+}
+
+var resaltadorJSON = resaltador{
+	palabras: conjuntoPalabras("true false null"),
+	claves:   true,
+}
+
+var resaltadorLiquid = resaltador{
+	palabras: conjuntoPalabras("if elsif else endif unless endunless case when endcase for endfor in break continue " +
+		"cycle tablerow endtablerow assign capture endcapture increment decrement render include section sections " +
+		"schema endschema style endstyle javascript endjavascript stylesheet endstylesheet form endform paginate " +
+		"endpaginate comment endcomment raw endraw liquid echo layout with as and or contains true false nil empty blank"),
+	filtros: true,
+}
+
+func resaltarCodigo(ruta, texto string, base lipgloss.Style) string {
+	switch path.Ext(ruta) {
+	case ".liquid":
+		return resaltarLiquid(texto, base)
+	case ".json":
+		return resaltadorJSON.resaltar(texto, base)
+	case ".css", ".scss":
+		return resaltadorCSS.resaltar(texto, base)
+	case ".js", ".mjs":
+		return resaltadorJS.resaltar(texto, base)
+	}
+	return base.Render(texto)
+}
+
+func pintar(estilo, base lipgloss.Style, texto string) string {
+	return estilo.Inherit(base).Render(texto)
+}
+
+func esInicioPalabra(c byte) bool {
+	return c == '_' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func esDigito(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func longitudPalabra(texto, extra string) int {
+	n := 0
+	for n < len(texto) && (esInicioPalabra(texto[n]) || esDigito(texto[n]) || strings.IndexByte(extra, texto[n]) >= 0) {
+		n++
+	}
+	return n
+}
+
+func finCadena(texto string) int {
+	for i := 1; i < len(texto); i++ {
+		switch texto[i] {
+		case '\\':
+			i++
+		case texto[0]:
+			return i + 1
+		}
+	}
+	return len(texto)
+}
+
+func finDelimitado(texto, apertura, cierre string) int {
+	fin := strings.Index(texto[len(apertura):], cierre)
+	if fin < 0 {
+		return len(texto)
+	}
+	return len(apertura) + fin + len(cierre)
+}
+
+func siguienteSignificativo(texto string) byte {
+	texto = strings.TrimLeft(texto, " \t")
+	if texto == "" {
+		return 0
+	}
+	return texto[0]
+}
+
+func (r resaltador) resaltar(texto string, base lipgloss.Style) string {
+	var b, plano strings.Builder
+	escribir := func(estilo lipgloss.Style, token string) {
+		if plano.Len() > 0 {
+			b.WriteString(base.Render(plano.String()))
+			plano.Reset()
+		}
+		b.WriteString(pintar(estilo, base, token))
+	}
+
+	extraPalabra := "$"
+	if r.guiones {
+		extraPalabra += "-"
+	}
+	declaracion := !r.guiones || !strings.Contains(texto, "{")
+	filtro := false
+
+	for i := 0; i < len(texto); {
+		resto := texto[i:]
+		c := resto[0]
+		switch {
+		case r.comentarioLinea != "" && strings.HasPrefix(resto, r.comentarioLinea):
+			escribir(estiloSintaxisComentario, resto)
+			i = len(texto)
+
+		case r.comentarioBloque[0] != "" && strings.HasPrefix(resto, r.comentarioBloque[0]):
+			n := finDelimitado(resto, r.comentarioBloque[0], r.comentarioBloque[1])
+			escribir(estiloSintaxisComentario, resto[:n])
+			i += n
+
+		case c == '"' || c == '\'' || (c == '`' && r.plantillas):
+			n := finCadena(resto)
+			estilo := estiloSintaxisCadena
+			if r.claves && siguienteSignificativo(resto[n:]) == ':' {
+				estilo = estiloSintaxisClave
+			}
+			escribir(estilo, resto[:n])
+			i += n
+
+		case esDigito(c):
+			n := longitudPalabra(resto, ".%")
+			escribir(estiloSintaxisNumero, resto[:n])
+			i += n
+
+		case esInicioPalabra(c) || ((c == '@' || c == '!' || c == '-') && len(resto) > 1 && esInicioPalabra(resto[1]) && r.guiones):
+			n := 1 + longitudPalabra(resto[1:], extraPalabra)
+			palabra := resto[:n]
+			switch {
+			case r.palabras[palabra]:
+				escribir(estiloSintaxisPalabra, palabra)
+			case filtro:
+				escribir(estiloSintaxisFuncion, palabra)
+			case r.claves && declaracion && siguienteSignificativo(resto[n:]) == ':':
+				escribir(estiloSintaxisClave, palabra)
+			default:
+				plano.WriteString(palabra)
+			}
+			filtro = false
+			i += n
+
+		default:
+			if r.filtros && c == '|' {
+				filtro = true
+			}
+			plano.WriteByte(c)
+			i++
+		}
+	}
+
+	if plano.Len() > 0 {
+		b.WriteString(base.Render(plano.String()))
+	}
+	return b.String()
+}
+
+func resaltarHTML(texto string, base lipgloss.Style) string {
+	var b, plano strings.Builder
+	escribir := func(estilo lipgloss.Style, token string) {
+		if plano.Len() > 0 {
+			b.WriteString(base.Render(plano.String()))
+			plano.Reset()
+		}
+		b.WriteString(pintar(estilo, base, token))
+	}
+
+	etiqueta := false
+	for i := 0; i < len(texto); {
+		resto := texto[i:]
+		c := resto[0]
+		switch {
+		case strings.HasPrefix(resto, "<!--"):
+			n := finDelimitado(resto, "<!--", "-->")
+			escribir(estiloSintaxisComentario, resto[:n])
+			i += n
+
+		case c == '<' && len(resto) > 1 && (esInicioPalabra(resto[1]) || resto[1] == '/' || resto[1] == '!'):
+			n := 2 + longitudPalabra(resto[2:], "-")
+			escribir(estiloSintaxisEtiqueta, resto[:n])
+			etiqueta = true
+			i += n
+
+		case etiqueta && (c == '>' || strings.HasPrefix(resto, "/>")):
+			n := 1
+			if c == '/' {
+				n = 2
+			}
+			escribir(estiloSintaxisEtiqueta, resto[:n])
+			etiqueta = false
+			i += n
+
+		case etiqueta && (c == '"' || c == '\''):
+			n := finCadena(resto)
+			escribir(estiloSintaxisCadena, resto[:n])
+			i += n
+
+		case etiqueta && esInicioPalabra(c):
+			n := longitudPalabra(resto, "-:")
+			escribir(estiloSintaxisClave, resto[:n])
+			i += n
+
+		default:
+			plano.WriteByte(c)
+			i++
+		}
+	}
+
+	if plano.Len() > 0 {
+		b.WriteString(base.Render(plano.String()))
+	}
+	return b.String()
+}
+
+func resaltarLiquid(texto string, base lipgloss.Style) string {
+	var b strings.Builder
+	for texto != "" {
+		inicio := strings.Index(texto, "{{")
+		if etiqueta := strings.Index(texto, "{%"); etiqueta >= 0 && (inicio < 0 || etiqueta < inicio) {
+			inicio = etiqueta
+		}
+		if inicio < 0 {
+			b.WriteString(resaltarHTML(texto, base))
+			break
+		}
+		b.WriteString(resaltarHTML(texto[:inicio], base))
+		texto = texto[inicio:]
+
+		cierre := "}}"
+		if strings.HasPrefix(texto, "{%") {
+			cierre = "%}"
+		}
+		apertura := texto[:2]
+		if strings.HasPrefix(texto[2:], "-") {
+			apertura = texto[:3]
+		}
+
+		fin := strings.Index(texto[len(apertura):], cierre)
+		if fin < 0 {
+			b.WriteString(pintar(estiloSintaxisEtiqueta, base, apertura))
+			b.WriteString(resaltadorLiquid.resaltar(texto[len(apertura):], base))
+			break
+		}
+		fin += len(apertura)
+		final := cierre
+		if fin > len(apertura) && texto[fin-1] == '-' {
+			fin--
+			final = "-" + cierre
+		}
+
+		b.WriteString(pintar(estiloSintaxisEtiqueta, base, apertura))
+		b.WriteString(resaltadorLiquid.resaltar(texto[len(apertura):fin], base))
+		b.WriteString(pintar(estiloSintaxisEtiqueta, base, final))
+		texto = texto[fin+len(final):]
+	}
+	return b.String()
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"runtime"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	ganchoAntesPull      = "antes_pull"
+	ganchoDespuesPull    = "despues_pull"
+	ganchoAntesPush      = "antes_push"
+	ganchoDespuesPush    = "despues_push"
+	ganchoAntesIniciar   = "antes_iniciar"
+	ganchoDespuesIniciar = "despues_iniciar"
+	ganchoAlDetener      = "al_detener"
+	ganchoAlFallar       = "al_fallar"
+)
+
+var eventosGanchos = []string{
+	ganchoAntesPull, ganchoDespuesPull,
+	ganchoAntesPush, ganchoDespuesPush,
+	ganchoAntesIniciar, ganchoDespuesIniciar,
+	ganchoAlDetener, ganchoAlFallar,
+}
+
+var ganchosAcciones = map[string][2]string{
+	accionPull: {ganchoAntesPull, ganchoDespuesPull},
+	accionPush: {ganchoAntesPush, ganchoDespuesPush},
+}
+
+type Ganchos map[string][]string
+
+var GanchosGlobales = Ganchos{}
+
+func validarGanchos(ganchos Ganchos) error {
+	validos := make(map[string]bool, len(eventosGanchos))
+	for _, evento := range eventosGanchos {
+		validos[evento] = true
+	}
+
+	eventos := make([]string, 0, len(ganchos))
+	for evento := range ganchos {
+		eventos = append(eventos, evento)
+	}
+	sort.Strings(eventos)
+
+	for _, evento := range eventos {
+		if !validos[evento] {
+			return errors.New(T("ganchos.evento_desconocido", evento, strings.Join(eventosGanchos, ", ")))
+		}
+	}
+	return nil
+}
+
+func InitGanchos(ganchos Ganchos) error {
+	if err := validarGanchos(ganchos); err != nil {
+		return err
+	}
+	if ganchos == nil {
+		ganchos = Ganchos{}
+	}
+	GanchosGlobales = ganchos
+	return nil
+}
+
+func comandosGancho(evento string, tienda Tienda) []string {
+	comandos := append([]string{}, GanchosGlobales[evento]...)
+	return append(comandos, tienda.Ganchos[evento]...)
+}
+
+func entornoTienda(tienda Tienda, puerto int) []string {
+	entorno := append(os.Environ(),
+		"SHO_TIENDA="+tienda.Nombre,
+		"SHO_URL="+tienda.URL,
+		"SHO_RUTA="+tienda.Ruta,
+	)
+	if puerto > 0 {
+		entorno = append(entorno, "SHO_PUERTO="+strconv.Itoa(puerto))
+	}
+	return entorno
+}
+
+func entornoGancho(evento string, tienda Tienda, puerto int, codigo *int) []string {
+	entorno := append(entornoTienda(tienda, puerto), "SHO_EVENTO="+evento)
+	if codigo != nil {
+		entorno = append(entorno, "SHO_CODIGO="+strconv.Itoa(*codigo))
+	}
+	return entorno
+}
+
+func comandoShell(linea string) *exec.Cmd {
+	if runtime.GOOS == "windows" {
+		return exec.Command("cmd", "/C", linea)
+	}
+	return exec.Command("sh", "-c", linea)
+}
+
+func ejecutarGanchos(evento string, tienda Tienda, puerto int, codigo *int, stdin io.Reader, stdout, stderr io.Writer) error {
+	for _, linea := range comandosGancho(evento, tienda) {
+		fmt.Fprintln(stdout, Icons.Play+" "+T("ganchos.ejecutando", evento, linea))
+
+		cmd := comandoShell(linea)
+		cmd.Dir = tienda.Ruta
+		cmd.Env = entornoGancho(evento, tienda, puerto, codigo)
+		cmd.Stdin = stdin
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+
+		if err := cmd.Run(); err != nil {
+			return errors.New(T("ganchos.fallo", evento, linea, err))
+		}
+	}
+	return nil
+}
+
+type comandoConGanchos struct {
+	cmd     *exec.Cmd
+	tienda  Tienda
+	antes   string
+	despues string
+
+	stdin  io.Reader
+	stdout io.Writer
+	stderr io.Writer
+}
+
+func conGanchos(cmd *exec.Cmd, tienda Tienda, accion string) *comandoConGanchos {
+	ganchos := ganchosAcciones[accion]
+	return &comandoConGanchos{
+		cmd:     cmd,
+		tienda:  tienda,
+		antes:   ganchos[0],
+		despues: ganchos[1],
+		stdin:   os.Stdin,
+		stdout:  os.Stdout,
+		stderr:  os.Stderr,
+	}
+}
+
+func (c *comandoConGanchos) SetStdin(r io.Reader)  { c.stdin = r }
+func (c *comandoConGanchos) SetStdout(w io.Writer) { c.stdout = w }
+func (c *comandoConGanchos) SetStderr(w io.Writer) { c.stderr = w }
+
+func (c *comandoConGanchos) Run() error {
+	if c.antes != "" {
+		if err := ejecutarGanchos(c.antes, c.tienda, 0, nil, c.stdin, c.stdout, c.stderr); err != nil {
+			return err
+		}
+	}
+
+	c.cmd.Stdin = c.stdin
+	c.cmd.Stdout = c.stdout
+	c.cmd.Stderr = c.stderr
+	err := c.cmd.Run()
+	if c.despues == "" {
+		return err
+	}
+
+	codigo := codigoSalida(err)
+	if errGancho := ejecutarGanchos(c.despues, c.tienda, 0, &codigo, c.stdin, c.stdout, c.stderr); errGancho != nil {
+		fmt.Fprintln(c.stderr, IconWarning(errGancho.Error()))
+	}
+
+	return err
+}
+
+type escritorLogsServidor struct {
+	*io.PipeWriter
+	listo chan struct{}
+}
+
+func (e escritorLogsServidor) Close() error {
+	err := e.PipeWriter.Close()
+	<-e.listo
+	return err
+}
+
+func escritorLogs(servidor *ServidorActivo) io.WriteCloser {
+	lector, escritor := io.Pipe()
+	listo := make(chan struct{})
+	go func() {
+		defer close(listo)
+		scanner := bufio.NewScanner(lector)
+		for scanner.Scan() {
+			servidor.AgregarLog(prefijoLog(T("ganchos.prefijo")) + scanner.Text())
+		}
+	}()
+	return escritorLogsServidor{PipeWriter: escritor, listo: listo}
+}
+
+func ejecutarGanchosServidor(evento string, servidor *ServidorActivo, codigo *int) error {
+	if len(comandosGancho(evento, servidor.Tienda)) == 0 {
+		return nil
+	}
+
+	salida := escritorLogs(servidor)
+	defer salida.Close()
+
+	return ejecutarGanchos(evento, servidor.Tienda, servidor.Puerto, codigo, nil, salida, salida)
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestEntornoGancho(t *testing.T) {
+	tienda := Tienda{Nombre: "alpha", URL: "alpha.myshopify.com", Ruta: "/temas/alpha"}
+	codigo := 3
+
+	casos := []struct {
+		nombre    string
+		puerto    int
+		codigo    *int
+		presentes []string
+		ausentes  []string
+	}{
+		{"antes de un pull", 0, nil,
+			[]string{"SHO_TIENDA=alpha", "SHO_URL=alpha.myshopify.com", "SHO_RUTA=/temas/alpha", "SHO_EVENTO=antes_pull"},
+			[]string{"SHO_PUERTO=", "SHO_CODIGO="}},
+		{"servidor con puerto", 9292, nil,
+			[]string{"SHO_PUERTO=9292", "SHO_EVENTO=antes_pull"},
+			[]string{"SHO_CODIGO="}},
+		{"tras una salida con error", 0, &codigo,
+			[]string{"SHO_CODIGO=3"},
+			[]string{"SHO_PUERTO="}},
+	}
+
+	for _, caso := range casos {
+		entorno := strings.Join(entornoGancho(ganchoAntesPull, tienda, caso.puerto, caso.codigo), "\n") + "\n"
+		for _, variable := range caso.presentes {
+			if !strings.Contains(entorno, variable+"\n") {
+				t.Errorf("%s: falta %s", caso.nombre, variable)
+			}
+		}
+		for _, variable := range caso.ausentes {
+			if strings.Contains(entorno, "\n"+variable) {
+				t.Errorf("%s: sobra %s", caso.nombre, variable)
+			}
+		}
+	}
+}
+
+func TestGanchosAcciones(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("los ganchos de prueba usan sh")
+	}
+
+	anteriores := GanchosGlobales
+	t.Cleanup(func() { GanchosGlobales = anteriores })
+
+	registro := filepath.Join(t.TempDir(), "registro")
+	GanchosGlobales = Ganchos{}
+	for _, evento := range eventosGanchos {
+		GanchosGlobales[evento] = []string{"echo $SHO_EVENTO:$SHO_TIENDA:${SHO_CODIGO:-} >> " + registro}
+	}
+
+	casos := []struct {
+		accion   string
+		comando  string
+		esperado []string
+		error    bool
+	}{
+		{accionPull, "true", []string{"antes_pull:alpha:", "principal", "despues_pull:alpha:0"}, false},
+		{accionPush, "exit 4", []string{"antes_push:alpha:", "principal", "despues_push:alpha:4"}, true},
+		{accionCheck, "true", []string{"principal"}, false},
+		{accionDiff, "true", []string{"principal"}, false},
+		{accionGitClone, "true", []string{"principal"}, false},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.accion, func(t *testing.T) {
+			os.Remove(registro)
+			cmd := exec.Command("sh", "-c", "echo principal >> "+registro+"; "+caso.comando)
+
+			var salida bytes.Buffer
+			comando := conGanchos(cmd, Tienda{Nombre: "alpha", Ruta: t.TempDir()}, caso.accion)
+			comando.SetStdin(nil)
+			comando.SetStdout(&salida)
+			comando.SetStderr(&salida)
+			if err := comando.Run(); (err != nil) != caso.error {
+				t.Fatalf("Run() = %v\n%s", err, salida.String())
+			}
+
+			datos, _ := os.ReadFile(registro)
+			if got := strings.Fields(string(datos)); strings.Join(got, " ") != strings.Join(caso.esperado, " ") {
+				t.Fatalf("se ejecutó %v, se esperaba %v", got, caso.esperado)
+			}
+		})
+	}
+}
+
+func TestGanchoAbortaAccion(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("los ganchos de prueba usan sh")
+	}
+
+	anteriores := GanchosGlobales
+	t.Cleanup(func() { GanchosGlobales = anteriores })
+
+	registro := filepath.Join(t.TempDir(), "registro")
+	GanchosGlobales = Ganchos{ganchoAntesPush: {"echo global >> " + registro}}
+	tienda := Tienda{Nombre: "alpha", Ruta: t.TempDir(), Ganchos: Ganchos{
+		ganchoAntesPush:   {"echo tienda >> " + registro + "; exit 2", "echo nunca >> " + registro},
+		ganchoDespuesPush: {"echo despues >> " + registro},
+	}}
+
+	var salida bytes.Buffer
+	comando := conGanchos(exec.Command("sh", "-c", "echo principal >> "+registro), tienda, accionPush)
+	comando.SetStdin(nil)
+	comando.SetStdout(&salida)
+	comando.SetStderr(&salida)
+
+	err := comando.Run()
+	if err == nil || !strings.Contains(err.Error(), ganchoAntesPush) {
+		t.Fatalf("un gancho fallido debería abortar el push: %v", err)
+	}
+
+	datos, _ := os.ReadFile(registro)
+	if got := strings.Fields(string(datos)); strings.Join(got, " ") != "global tienda" {
+		t.Fatalf("se ejecutó %v; tras el fallo no debe correr nada más", got)
+	}
+}
+
+func TestValidarGanchos(t *testing.T) {
+	casos := []struct {
+		ganchos Ganchos
+		error   bool
+	}{
+		{nil, false},
+		{Ganchos{ganchoAntesPull: {"make build"}, ganchoAlFallar: {"notify"}}, false},
+		{Ganchos{"antes_check": {"make lint"}}, true},
+		{Ganchos{"despues_diff": {"echo"}}, true},
+	}
+
+	for _, caso := range casos {
+		if err := validarGanchos(caso.ganchos); (err != nil) != caso.error {
+			t.Errorf("validarGanchos(%v) = %v", caso.ganchos, err)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const idiomaPorDefecto = "es"
+
+var catalogos = map[string]map[string]string{
+	"es": mensajesES,
+	"en": mensajesEN,
+}
+
+var Idioma = idiomaPorDefecto
+
+func T(clave string, args ...any) string {
+	texto, existe := catalogos[Idioma][clave]
+	if !existe {
+		texto, existe = catalogos[idiomaPorDefecto][clave]
+	}
+	if !existe {
+		return clave
+	}
+
+	if len(args) > 0 {
+		return fmt.Sprintf(texto, args...)
+	}
+	return texto
+}
+
+func normalizarIdioma(valor string) string {
+	valor = strings.ToLower(strings.TrimSpace(valor))
+
+	if i := strings.IndexAny(valor, ".@"); i >= 0 {
+		valor = valor[:i]
+	}
+	if i := strings.IndexAny(valor, "_-"); i >= 0 {
+		valor = valor[:i]
+	}
+	return valor
+}
+
+func DetectarIdioma() string {
+	for _, variable := range []string{"SHOPIFY_TUI_LANG", "LC_ALL", "LC_MESSAGES", "LANG"} {
+		valor := normalizarIdioma(os.Getenv(variable))
+		if valor == "" || valor == "c" || valor == "posix" {
+			continue
+		}
+		if _, existe := catalogos[valor]; existe {
+			return valor
+		}
+		return idiomaPorDefecto
+	}
+	return idiomaPorDefecto
+}
+
+func InitIdioma(preferido string) error {
+	Idioma = DetectarIdioma()
+
+	if os.Getenv("SHOPIFY_TUI_LANG") != "" || preferido == "" {
+		return nil
+	}
+
+	idioma := normalizarIdioma(preferido)
+	if _, existe := catalogos[idioma]; !existe {
+		disponibles := make([]string, 0, len(catalogos))
+		for nombre := range catalogos {
+			disponibles = append(disponibles, nombre)
+		}
+		sort.Strings(disponibles)
+		return errors.New(T("idioma.desconocido", preferido, strings.Join(disponibles, ", ")))
+	}
+
+	Idioma = idioma
+	return nil
+}
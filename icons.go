@@ -27,6 +27,7 @@ type IconSet struct {
 	Upload   string
 	Git      string
 	Folder   string
+	Search   string
 
 	Terminal string
 	Editor   string
@@ -63,6 +64,7 @@ var NerdIcons = IconSet{
 	Upload:   "",
 	Git:      "",
 	Folder:   "",
+	Search:   "",
 
 	Terminal: "",
 	Editor:   "",
@@ -99,6 +101,7 @@ var ASCIIIcons = IconSet{
 	Upload:   "[^]",
 	Git:      "[G]",
 	Folder:   "[D]",
+	Search:   "[?]",
 
 	Terminal: "[$]",
 	Editor:   "[E]",
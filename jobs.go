@@ -0,0 +1,1108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	maxLineasTrabajo     = 5000
+	maxTrabajosGuardados = 100
+)
+
+const (
+	temaPublicado  = "live"
+	temaDesarrollo = "development"
+)
+
+const (
+	trabajoPendiente  = "pendiente"
+	trabajoEjecutando = "ejecutando"
+	trabajoCorrecto   = "correcto"
+	trabajoFallido    = "fallido"
+	trabajoCancelado  = "cancelado"
+)
+
+var estadosTrabajo = []string{trabajoPendiente, trabajoEjecutando, trabajoCorrecto, trabajoFallido, trabajoCancelado}
+
+var accionesRepetibles = map[string]bool{
+	accionPull:       true,
+	accionPush:       true,
+	accionCheck:      true,
+	accionEmpaquetar: true,
+}
+
+var comandoTrabajo = func(ctx context.Context, programa string, argumentos ...string) *exec.Cmd {
+	return exec.CommandContext(ctx, programa, argumentos...)
+}
+
+type Trabajo struct {
+	ID         int
+	Accion     string
+	Tienda     Tienda
+	Programa   string
+	Argumentos []string
+	Destino    string
+	Estimado   time.Duration
+
+	mutex       sync.Mutex
+	estado      string
+	inicio      time.Time
+	fin         time.Time
+	lineas      []string
+	parcial     string
+	err         error
+	codigo      int
+	archivo     string
+	diferencias []ArchivoDiff
+	cancelar    context.CancelFunc
+}
+
+type InfoTrabajo struct {
+	ID       int
+	Accion   string
+	Tienda   Tienda
+	Estado   string
+	Inicio   time.Time
+	Fin      time.Time
+	Estimado time.Duration
+	Salida   string
+	Error    string
+	Codigo   int
+}
+
+func (i InfoTrabajo) Activo() bool {
+	return i.Estado == trabajoPendiente || i.Estado == trabajoEjecutando
+}
+
+func (i InfoTrabajo) Duracion() time.Duration {
+	switch {
+	case i.Inicio.IsZero():
+		return 0
+	case i.Fin.IsZero():
+		return time.Since(i.Inicio)
+	}
+	return i.Fin.Sub(i.Inicio)
+}
+
+func (i InfoTrabajo) Progreso() (float64, time.Duration, bool) {
+	transcurrido := i.Duracion()
+	if i.Estado != trabajoEjecutando || i.Estimado <= 0 || transcurrido >= i.Estimado {
+		return 0, 0, false
+	}
+	return float64(transcurrido) / float64(i.Estimado), i.Estimado - transcurrido, true
+}
+
+type RegistroTrabajo struct {
+	ID      int       `json:"id"`
+	Accion  string    `json:"accion"`
+	Tienda  string    `json:"tienda"`
+	URL     string    `json:"url"`
+	Ruta    string    `json:"ruta"`
+	Comando []string  `json:"comando"`
+	Estado  string    `json:"estado"`
+	Inicio  time.Time `json:"inicio"`
+	Fin     time.Time `json:"fin"`
+	Codigo  int       `json:"codigo"`
+	Error   string    `json:"error,omitempty"`
+}
+
+type GestorTrabajos struct {
+	mutex     sync.Mutex
+	trabajos  []*Trabajo
+	cola      []*Trabajo
+	siguiente int
+	cambios   chan struct{}
+	carga     sync.Once
+}
+
+var gestorTrabajos = &GestorTrabajos{cambios: make(chan struct{}, 1)}
+
+func ObtenerTrabajos() *GestorTrabajos {
+	gestorTrabajos.carga.Do(func() {
+		gestorTrabajos.cargar()
+	})
+	return gestorTrabajos
+}
+
+type trabajosActualizadosMsg struct{}
+
+type trabajoLanzadoMsg struct {
+	trabajo *Trabajo
+	nuevo   bool
+}
+
+func definirTrabajo(accion string, tienda Tienda) *Trabajo {
+	trabajo := &Trabajo{Accion: accion, Tienda: tienda, Programa: "shopify"}
+	switch accion {
+	case accionGitClone:
+		trabajo.Programa = "git"
+		trabajo.Argumentos = []string{"clone", tienda.GitURL, "."}
+	case accionShopifyPull:
+		trabajo.Argumentos = []string{"theme", "pull", "--store", tienda.URL, "--path", "."}
+	case accionCheck, accionEmpaquetar:
+		trabajo.Argumentos = []string{"theme", accion}
+	case accionDiff:
+		trabajo.Destino = filepath.Join(os.TempDir(), "sho-diff-"+strconv.FormatInt(time.Now().UnixNano(), 36))
+		trabajo.Argumentos = append([]string{"theme", "pull", "--store", tienda.URL}, argumentosTema(accionPull, tienda)...)
+		trabajo.Argumentos = append(trabajo.Argumentos, "--path", trabajo.Destino)
+	case accionPull, accionPush:
+		trabajo.Argumentos = append([]string{"theme", accion, "--store", tienda.URL}, argumentosTema(accion, tienda)...)
+	default:
+		trabajo.Argumentos = []string{"theme", accion, "--store", tienda.URL}
+	}
+	return trabajo
+}
+
+func argumentosTema(accion string, tienda Tienda) []string {
+	switch tienda.TemaRemoto {
+	case "":
+		if accion == accionPush {
+			return []string{"--development"}
+		}
+		return []string{"--live"}
+	case temaPublicado:
+		if accion == accionPush {
+			return []string{"--live", "--allow-live"}
+		}
+		return []string{"--live"}
+	case temaDesarrollo:
+		return []string{"--development"}
+	}
+	return []string{"--theme", tienda.TemaRemoto}
+}
+
+func nombreAccion(accion string) string {
+	return T("actividad." + accion)
+}
+
+func obtenerRutaTrabajos() (string, error) {
+	dirBase, err := obtenerDirectorioBase()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dirBase, "jobs.json"), nil
+}
+
+func obtenerRutaSalidaTrabajo(id int) (string, error) {
+	dirBase, err := obtenerDirectorioBase()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dirBase, "jobs", strconv.Itoa(id)+".log"), nil
+}
+
+func (g *GestorTrabajos) cargar() {
+	rutaArchivo, err := obtenerRutaTrabajos()
+	if err != nil {
+		return
+	}
+
+	datos, err := os.ReadFile(rutaArchivo)
+	if err != nil {
+		return
+	}
+
+	var registros []RegistroTrabajo
+	if err := json.Unmarshal(datos, &registros); err != nil {
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	for _, registro := range registros {
+		trabajo := &Trabajo{
+			ID:     registro.ID,
+			Accion: registro.Accion,
+			Tienda: Tienda{Nombre: registro.Tienda, URL: registro.URL, Ruta: registro.Ruta},
+			estado: registro.Estado,
+			inicio: registro.Inicio,
+			fin:    registro.Fin,
+			codigo: registro.Codigo,
+		}
+		if len(registro.Comando) > 0 {
+			trabajo.Programa = registro.Comando[0]
+			trabajo.Argumentos = registro.Comando[1:]
+		}
+		if registro.Error != "" {
+			trabajo.err = errors.New(registro.Error)
+		}
+		trabajo.archivo, _ = obtenerRutaSalidaTrabajo(registro.ID)
+
+		g.trabajos = append(g.trabajos, trabajo)
+		g.siguiente = max(g.siguiente, registro.ID)
+	}
+}
+
+func (g *GestorTrabajos) guardar(trabajo *Trabajo) error {
+	if err := crearDirectorioBase(); err != nil {
+		return err
+	}
+
+	rutaSalida, err := obtenerRutaSalidaTrabajo(trabajo.ID)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(rutaSalida), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(rutaSalida, []byte(strings.Join(trabajo.Lineas(), "\n")), 0644); err != nil {
+		return err
+	}
+
+	rutaArchivo, err := obtenerRutaTrabajos()
+	if err != nil {
+		return err
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	registros := []RegistroTrabajo{}
+	conservados := make(map[string]bool)
+	for _, t := range g.trabajos {
+		if registro, ok := t.registro(); ok {
+			registros = append(registros, registro)
+			conservados[strconv.Itoa(registro.ID)+".log"] = true
+		}
+	}
+
+	datos, err := json.MarshalIndent(registros, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(rutaArchivo, datos, 0644); err != nil {
+		return err
+	}
+
+	archivos, _ := os.ReadDir(filepath.Dir(rutaSalida))
+	for _, archivo := range archivos {
+		if !conservados[archivo.Name()] {
+			os.Remove(filepath.Join(filepath.Dir(rutaSalida), archivo.Name()))
+		}
+	}
+	return nil
+}
+
+func (g *GestorTrabajos) registrar(trabajo *Trabajo) {
+	g.siguiente++
+	trabajo.ID = g.siguiente
+	trabajo.Estimado = ObtenerHistorial().DuracionHabitual(trabajo.Tienda.Nombre, trabajo.Accion)
+	trabajo.estado = trabajoPendiente
+	g.trabajos = append(g.trabajos, trabajo)
+
+	exceso := len(g.trabajos) - maxTrabajosGuardados
+	if exceso <= 0 {
+		return
+	}
+	conservados := make([]*Trabajo, 0, len(g.trabajos)-exceso)
+	for _, t := range g.trabajos {
+		if exceso > 0 && !t.Activo() {
+			exceso--
+			continue
+		}
+		conservados = append(conservados, t)
+	}
+	g.trabajos = conservados
+}
+
+func (g *GestorTrabajos) Nuevo(accion string, tienda Tienda) *Trabajo {
+	trabajo := definirTrabajo(accion, tienda)
+
+	g.mutex.Lock()
+	g.registrar(trabajo)
+	g.mutex.Unlock()
+
+	g.notificar()
+	return trabajo
+}
+
+func (g *GestorTrabajos) Lanzar(accion string, tienda Tienda) (*Trabajo, bool) {
+	g.mutex.Lock()
+	for _, trabajo := range g.cola {
+		if trabajo.Tienda.Nombre == tienda.Nombre && trabajo.Accion == accion && trabajo.Estado() == trabajoPendiente {
+			g.mutex.Unlock()
+			return trabajo, false
+		}
+	}
+	trabajo := definirTrabajo(accion, tienda)
+	g.registrar(trabajo)
+	g.cola = append(g.cola, trabajo)
+	g.mutex.Unlock()
+
+	g.despachar()
+	g.notificar()
+	return trabajo, true
+}
+
+func (g *GestorTrabajos) despachar() {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	ocupadas := make(map[string]bool)
+	for _, trabajo := range g.trabajos {
+		if trabajo.Estado() == trabajoEjecutando {
+			ocupadas[trabajo.Tienda.Nombre] = true
+		}
+	}
+
+	var cola []*Trabajo
+	for _, trabajo := range g.cola {
+		switch {
+		case trabajo.Estado() != trabajoPendiente:
+		case ocupadas[trabajo.Tienda.Nombre]:
+			cola = append(cola, trabajo)
+		default:
+			if ctx, ok := trabajo.comenzar(context.Background()); ok {
+				ocupadas[trabajo.Tienda.Nombre] = true
+				go trabajo.correr(ctx)
+			}
+		}
+	}
+	g.cola = cola
+}
+
+func (g *GestorTrabajos) Trabajos() []*Trabajo {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return append([]*Trabajo(nil), g.trabajos...)
+}
+
+func (g *GestorTrabajos) Activos() []InfoTrabajo {
+	var ejecutando, pendientes []InfoTrabajo
+	for _, trabajo := range g.Trabajos() {
+		switch info := trabajo.Info(); info.Estado {
+		case trabajoEjecutando:
+			ejecutando = append(ejecutando, info)
+		case trabajoPendiente:
+			pendientes = append(pendientes, info)
+		}
+	}
+	return append(ejecutando, pendientes...)
+}
+
+func (g *GestorTrabajos) Reciente() *Trabajo {
+	trabajos := g.Trabajos()
+	for i := len(trabajos) - 1; i >= 0; i-- {
+		if trabajos[i].Estado() == trabajoEjecutando {
+			return trabajos[i]
+		}
+	}
+	if len(trabajos) == 0 {
+		return nil
+	}
+	return trabajos[len(trabajos)-1]
+}
+
+func (g *GestorTrabajos) CancelarTodos() {
+	for _, trabajo := range g.Trabajos() {
+		trabajo.Cancelar()
+	}
+}
+
+func (g *GestorTrabajos) notificar() {
+	select {
+	case g.cambios <- struct{}{}:
+	default:
+	}
+}
+
+func esperarTrabajos() tea.Cmd {
+	return func() tea.Msg {
+		<-gestorTrabajos.cambios
+		return trabajosActualizadosMsg{}
+	}
+}
+
+func (t *Trabajo) Comando() string {
+	return strings.Join(append([]string{t.Programa}, t.Argumentos...), " ")
+}
+
+func (t *Trabajo) Estado() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.estado
+}
+
+func (t *Trabajo) Activo() bool {
+	estado := t.Estado()
+	return estado == trabajoPendiente || estado == trabajoEjecutando
+}
+
+func (t *Trabajo) Ejecutar(ctx context.Context) {
+	if ctx, ok := t.comenzar(ctx); ok {
+		t.correr(ctx)
+	}
+}
+
+func (t *Trabajo) comenzar(ctx context.Context) (context.Context, bool) {
+	ctx, cancelar := context.WithCancel(ctx)
+
+	t.mutex.Lock()
+	if t.estado != trabajoPendiente || ctx.Err() != nil {
+		if t.estado == trabajoPendiente {
+			t.estado = trabajoCancelado
+			t.fin = time.Now()
+		}
+		t.mutex.Unlock()
+		cancelar()
+		ObtenerTrabajos().notificar()
+		return ctx, false
+	}
+	t.estado = trabajoEjecutando
+	t.inicio = time.Now()
+	t.cancelar = cancelar
+	t.mutex.Unlock()
+
+	ObtenerTrabajos().notificar()
+	return ctx, true
+}
+
+func (t *Trabajo) correr(ctx context.Context) {
+	t.terminar(ctx, t.ejecutarComando(ctx))
+}
+
+func (t *Trabajo) terminar(ctx context.Context, err error) {
+	ObtenerHistorial().Registrar(t.Tienda.Nombre, t.Accion, t.inicio, err)
+
+	t.mutex.Lock()
+	t.fin = time.Now()
+	t.err = err
+	t.codigo = codigoSalida(err)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		t.estado = trabajoCancelado
+	case err != nil:
+		t.estado = trabajoFallido
+	default:
+		t.estado = trabajoCorrecto
+	}
+	t.cancelar()
+	t.mutex.Unlock()
+
+	gestor := ObtenerTrabajos()
+	gestor.guardar(t)
+	gestor.despachar()
+	gestor.notificar()
+}
+
+func (t *Trabajo) comando(ctx context.Context) *exec.Cmd {
+	cmd := comandoTrabajo(ctx, t.Programa, t.Argumentos...)
+	cmd.Dir = t.Tienda.Ruta
+	cmd.Cancel = func() error { return terminarGrupo(cmd) }
+	return cmd
+}
+
+func (t *Trabajo) ejecutarComando(ctx context.Context) error {
+	if !existeDirectorio(t.Tienda.Ruta) {
+		return errors.New(T("tiendas.directorio_inexistente", t.Tienda.Ruta))
+	}
+
+	if t.Destino != "" {
+		if err := os.MkdirAll(t.Destino, 0755); err != nil {
+			return err
+		}
+		defer os.RemoveAll(t.Destino)
+	}
+
+	cmd := t.comando(ctx)
+	configurarGrupoProcesos(cmd)
+
+	comando := conGanchos(cmd, t.Tienda, t.Accion)
+	comando.SetStdin(nil)
+	comando.SetStdout(t)
+	comando.SetStderr(t)
+	if err := comando.Run(); err != nil || t.Accion != accionDiff {
+		return err
+	}
+
+	diferencias, err := compararTemas(t.Tienda.Ruta, t.Destino)
+	t.mutex.Lock()
+	t.diferencias = diferencias
+	t.mutex.Unlock()
+	return err
+}
+
+type trabajoEnPanel struct {
+	trabajo *Trabajo
+	ctx     context.Context
+	stdin   io.Reader
+	stdout  io.Writer
+	stderr  io.Writer
+}
+
+func (p *trabajoEnPanel) SetStdin(r io.Reader)  { p.stdin = r }
+func (p *trabajoEnPanel) SetStdout(w io.Writer) { p.stdout = w }
+func (p *trabajoEnPanel) SetStderr(w io.Writer) { p.stderr = w }
+
+func (p *trabajoEnPanel) Run() error {
+	ctx, ok := p.trabajo.comenzar(context.Background())
+	if !ok {
+		return context.Canceled
+	}
+	p.ctx = ctx
+
+	cmd := p.trabajo.comando(ctx)
+	configurarTerminalControl(cmd)
+	cmd.Stdin = p.stdin
+	cmd.Stdout = p.stdout
+	cmd.Stderr = p.stderr
+	return cmd.Run()
+}
+
+func (p *trabajoEnPanel) Terminar() error {
+	p.trabajo.Cancelar()
+	return nil
+}
+
+func ejecutarTrabajoEnPanel(trabajo *Trabajo, alTerminar tea.ExecCallback) tea.Cmd {
+	panel := &trabajoEnPanel{trabajo: trabajo}
+	return ejecutarEnPanel(trabajo.Comando(), trabajo.Tienda, panel, trabajo, func(err error) tea.Msg {
+		if panel.ctx != nil {
+			trabajo.terminar(panel.ctx, err)
+		}
+		return alTerminar(err)
+	})
+}
+
+func (t *Trabajo) Cancelar() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	switch t.estado {
+	case trabajoPendiente:
+		t.estado = trabajoCancelado
+		t.fin = time.Now()
+		go ObtenerTrabajos().notificar()
+		return true
+	case trabajoEjecutando:
+		t.cancelar()
+		return true
+	}
+	return false
+}
+
+func (t *Trabajo) Write(datos []byte) (int, error) {
+	t.mutex.Lock()
+	lineas := strings.Split(t.parcial+ansi.Strip(string(datos)), "\n")
+	for _, linea := range lineas[:len(lineas)-1] {
+		t.lineas = append(t.lineas, lineaVisible(linea))
+	}
+	if exceso := len(t.lineas) - maxLineasTrabajo; exceso > 0 {
+		t.lineas = append([]string(nil), t.lineas[exceso:]...)
+	}
+	t.parcial = lineas[len(lineas)-1]
+	t.mutex.Unlock()
+
+	ObtenerTrabajos().notificar()
+	return len(datos), nil
+}
+
+func lineaVisible(linea string) string {
+	linea = strings.TrimRight(linea, "\r")
+	if i := strings.LastIndex(linea, "\r"); i >= 0 {
+		return linea[i+1:]
+	}
+	return linea
+}
+
+func (t *Trabajo) Lineas() []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.archivo != "" {
+		if datos, err := os.ReadFile(t.archivo); err == nil && len(datos) > 0 {
+			t.lineas = strings.Split(string(datos), "\n")
+		}
+		t.archivo = ""
+	}
+
+	lineas := append([]string(nil), t.lineas...)
+	if parcial := lineaVisible(t.parcial); parcial != "" {
+		lineas = append(lineas, parcial)
+	}
+	return lineas
+}
+
+func (t *Trabajo) Diferencias() []ArchivoDiff {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.diferencias
+}
+
+func (t *Trabajo) Info() InfoTrabajo {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	info := InfoTrabajo{
+		ID:       t.ID,
+		Accion:   t.Accion,
+		Tienda:   t.Tienda,
+		Estado:   t.estado,
+		Inicio:   t.inicio,
+		Fin:      t.fin,
+		Estimado: t.Estimado,
+		Codigo:   t.codigo,
+	}
+	info.Salida = strings.TrimSpace(lineaVisible(t.parcial))
+	for i := len(t.lineas) - 1; i >= 0 && info.Salida == ""; i-- {
+		info.Salida = strings.TrimSpace(t.lineas[i])
+	}
+	if t.err != nil {
+		info.Error = info.Salida
+		if info.Error == "" {
+			info.Error = t.err.Error()
+		}
+	}
+	return info
+}
+
+func (t *Trabajo) registro() (RegistroTrabajo, bool) {
+	info := t.Info()
+	if info.Activo() || info.Inicio.IsZero() {
+		return RegistroTrabajo{}, false
+	}
+
+	return RegistroTrabajo{
+		ID:      info.ID,
+		Accion:  info.Accion,
+		Tienda:  info.Tienda.Nombre,
+		URL:     info.Tienda.URL,
+		Ruta:    info.Tienda.Ruta,
+		Comando: append([]string{t.Programa}, t.Argumentos...),
+		Estado:  info.Estado,
+		Inicio:  info.Inicio,
+		Fin:     info.Fin,
+		Codigo:  info.Codigo,
+		Error:   info.Error,
+	}, true
+}
+
+func estiloEstadoTrabajo(estado string) lipgloss.Style {
+	switch estado {
+	case trabajoEjecutando:
+		return estiloAviso
+	case trabajoCorrecto:
+		return estiloExito
+	case trabajoFallido:
+		return estiloError
+	}
+	return estiloDesc
+}
+
+func iconoEstadoTrabajo(estado string) string {
+	switch estado {
+	case trabajoEjecutando:
+		return Icons.Play
+	case trabajoCorrecto:
+		return Icons.Success
+	case trabajoFallido:
+		return Icons.Error
+	case trabajoCancelado:
+		return Icons.Stop
+	}
+	return Icons.Dot
+}
+
+func lanzarTrabajo(accion string, tienda Tienda) tea.Cmd {
+	return func() tea.Msg {
+		trabajo, nuevo := ObtenerTrabajos().Lanzar(accion, tienda)
+		return trabajoLanzadoMsg{trabajo: trabajo, nuevo: nuevo}
+	}
+}
+
+func (m Model) trabajoLanzado(msg trabajoLanzadoMsg) (tea.Model, tea.Cmd) {
+	info := msg.trabajo.Info()
+	accion := nombreAccion(info.Accion)
+	switch {
+	case !msg.nuevo:
+		m.mensaje = IconWarning(T("trabajos.ya_en_cola", accion, info.Tienda.Nombre))
+		return m, nil
+	case info.Estado == trabajoPendiente:
+		m.mensaje = IconInfo(T("trabajos.en_cola", accion, info.Tienda.Nombre))
+	default:
+		m.mensaje = IconInfo(T("trabajos.lanzado", accion, info.Tienda.Nombre, ayudaTecla(Teclas.SalidaTrabajo)))
+	}
+	m.seguidos[info.ID] = true
+	return m, nil
+}
+
+func (m Model) trabajosActualizados() (tea.Model, tea.Cmd) {
+	cmds := []tea.Cmd{esperarTrabajos()}
+
+	for _, trabajo := range ObtenerTrabajos().Trabajos() {
+		if !m.seguidos[trabajo.ID] {
+			continue
+		}
+		info := trabajo.Info()
+		accion := nombreAccion(info.Accion)
+		switch info.Estado {
+		case trabajoCorrecto:
+			m.mensaje = IconSuccess(T("trabajos.terminado", accion, info.Tienda.Nombre, formatearLapso(info.Duracion())))
+		case trabajoFallido:
+			m.mensaje = IconError(T("trabajos.fallido", accion, info.Tienda.Nombre, info.Error))
+		case trabajoCancelado:
+			m.mensaje = IconWarning(T("trabajos.cancelado", accion, info.Tienda.Nombre))
+		default:
+			continue
+		}
+		delete(m.seguidos, trabajo.ID)
+	}
+
+	if m.loteEnCurso && !m.lote.EnCurso() {
+		m.loteEnCurso = false
+		m.mensaje = m.resumenLote()
+	}
+
+	if !m.girando && len(ObtenerTrabajos().Activos()) > 0 {
+		m.girando = true
+		cmds = append(cmds, m.spinner.Tick)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m Model) girarSpinner(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if len(ObtenerTrabajos().Activos()) == 0 {
+		m.girando = false
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.spinner, cmd = m.spinner.Update(msg)
+	return m, cmd
+}
+
+func barraProgreso(fraccion float64, ancho int) string {
+	llenos := min(int(fraccion*float64(ancho)), ancho)
+	return estiloExito.Render(strings.Repeat("▰", llenos)) + estiloDesc.Render(strings.Repeat("▱", ancho-llenos))
+}
+
+func (m Model) barraTrabajos() string {
+	activos := ObtenerTrabajos().Activos()
+	if len(activos) == 0 {
+		return ""
+	}
+
+	principal := activos[0]
+	texto := m.spinner.View() + " " + estiloLabel.Render(principal.Tienda.Nombre) + " " + estiloDesc.Render(nombreAccion(principal.Accion))
+	switch fraccion, restante, ok := principal.Progreso(); {
+	case ok:
+		texto += " " + barraProgreso(fraccion, 10) + " " +
+			estiloDesc.Render(T("trabajos.progreso", int(fraccion*100), formatearLapso(restante)))
+	case principal.Estado == trabajoEjecutando:
+		texto += " " + estiloDesc.Render(formatearLapso(principal.Duracion()))
+	default:
+		texto += " " + estiloDesc.Render(T("trabajos.estado."+principal.Estado))
+	}
+	if len(activos) > 1 {
+		texto += estiloDesc.Render(" · " + T("trabajos.mas", len(activos)-1))
+	}
+	texto += " · " + estiloDesc.Render(T("trabajos.ver_salida", ayudaTecla(Teclas.SalidaTrabajo)))
+	return ansi.Truncate(texto, max(m.ancho, 20), "…")
+}
+
+func (m Model) abrirSalidaTrabajo(trabajo *Trabajo) (tea.Model, tea.Cmd) {
+	if trabajo == nil {
+		m.mensaje = IconInfo(T("trabajos.ninguno"))
+		return m, nil
+	}
+
+	if m.vista != VistaTrabajo {
+		m.vistaAntesTrabajo = m.vista
+	}
+	m.vista = VistaTrabajo
+	m.trabajo = trabajo
+	m.trabajoSeguir = true
+	m.trabajoScroll = m.maxScrollTrabajo()
+	m.mensaje = ""
+	return m, nil
+}
+
+func (m Model) maxScrollTrabajo() int {
+	if m.trabajo == nil {
+		return 0
+	}
+	return max(len(m.trabajo.Lineas())-m.lineasPorPagina(), 0)
+}
+
+func (m Model) updateTrabajo(msg tea.Msg) (tea.Model, tea.Cmd) {
+	teclaMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.trabajo == nil {
+		return m, nil
+	}
+
+	if m.trabajoSeguir {
+		m.trabajoScroll = m.maxScrollTrabajo()
+	}
+
+	switch {
+	case key.Matches(teclaMsg, Teclas.Arriba):
+		m.trabajoScroll = max(m.trabajoScroll-1, 0)
+
+	case key.Matches(teclaMsg, Teclas.Abajo):
+		m.trabajoScroll = min(m.trabajoScroll+1, m.maxScrollTrabajo())
+
+	case key.Matches(teclaMsg, Teclas.PaginaArriba):
+		m.trabajoScroll = max(m.trabajoScroll-m.lineasPorPagina(), 0)
+
+	case key.Matches(teclaMsg, Teclas.PaginaAbajo):
+		m.trabajoScroll = min(m.trabajoScroll+m.lineasPorPagina(), m.maxScrollTrabajo())
+
+	case key.Matches(teclaMsg, Teclas.Inicio):
+		m.trabajoScroll = 0
+
+	case key.Matches(teclaMsg, Teclas.Final):
+		m.trabajoScroll = m.maxScrollTrabajo()
+
+	case key.Matches(teclaMsg, Teclas.Detener):
+		if m.trabajo.Cancelar() {
+			m.mensaje = IconWarning(T("trabajos.cancelando"))
+		}
+
+	case key.Matches(teclaMsg, Teclas.Repetir):
+		return m.repetirTrabajo(m.trabajo)
+	}
+	m.trabajoSeguir = m.trabajoScroll >= m.maxScrollTrabajo()
+	return m, nil
+}
+
+func (m Model) vistaTrabajo() string {
+	var b strings.Builder
+
+	info := m.trabajo.Info()
+	b.WriteString(estiloTitulo.Render("📜 " + T("trabajos.titulo", nombreAccion(info.Accion), info.Tienda.Nombre)))
+	b.WriteString("\n")
+	b.WriteString(estiloDesc.Render("$ " + m.trabajo.Comando()))
+	b.WriteString("\n")
+
+	estado := estiloEstadoTrabajo(info.Estado).Render(T("trabajos.estado." + info.Estado))
+	if info.Estado == trabajoEjecutando {
+		estado = m.spinner.View() + " " + estado
+	}
+	if !info.Inicio.IsZero() {
+		estado += estiloDesc.Render(" · " + info.Inicio.Format("2006-01-02 15:04:05") + " · " + formatearLapso(info.Duracion()))
+	}
+	if info.Estado == trabajoFallido {
+		estado += estiloDesc.Render(" · " + T("trabajos.codigo", info.Codigo))
+	}
+	if fraccion, restante, ok := info.Progreso(); ok {
+		estado += " " + barraProgreso(fraccion, 10) + " " +
+			estiloDesc.Render(T("trabajos.progreso", int(fraccion*100), formatearLapso(restante)))
+	}
+	b.WriteString(estado)
+	b.WriteString("\n\n")
+
+	lineas := m.trabajo.Lineas()
+	scroll := m.trabajoScroll
+	if m.trabajoSeguir {
+		scroll = m.maxScrollTrabajo()
+	}
+	if len(lineas) == 0 {
+		b.WriteString(estiloDesc.Render(T("trabajos.sin_salida")))
+		b.WriteString("\n")
+	}
+	inicio := min(scroll, len(lineas))
+	fin := min(inicio+m.lineasPorPagina(), len(lineas))
+	for _, linea := range lineas[inicio:fin] {
+		b.WriteString(ansi.Truncate(linea, max(m.anchoNovedades(), 20), "…"))
+		b.WriteString("\n")
+	}
+	if len(lineas) > m.lineasPorPagina() {
+		b.WriteString(estiloDesc.Render(T("trabajos.posicion", inicio+1, fin, len(lineas))))
+		b.WriteString("\n")
+	}
+
+	if m.mensaje != "" {
+		b.WriteString("\n")
+		b.WriteString(estiloAviso.Render(m.mensaje))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(estiloAyuda.Render(T("trabajos.ayuda",
+		ayudaTecla(Teclas.Arriba), ayudaTecla(Teclas.Abajo), ayudaTecla(Teclas.Final),
+		ayudaTecla(Teclas.Detener), ayudaTecla(Teclas.Repetir), ayudaTecla(Teclas.Volver))))
+	return estiloContenedor.Render(b.String())
+}
+
+func (m Model) repetirTrabajo(trabajo *Trabajo) (tea.Model, tea.Cmd) {
+	if !accionesRepetibles[trabajo.Accion] {
+		m.mensaje = IconWarning(T("trabajos.no_repetible", nombreAccion(trabajo.Accion)))
+		return m, nil
+	}
+
+	for _, tienda := range m.tiendas {
+		if tienda.Nombre == trabajo.Tienda.Nombre {
+			return m, lanzarTrabajo(trabajo.Accion, tienda)
+		}
+	}
+	m.mensaje = IconWarning(T("trabajos.tienda_eliminada", trabajo.Tienda.Nombre))
+	return m, nil
+}
+
+func (m Model) listaTrabajos() []*Trabajo {
+	trabajos := ObtenerTrabajos().Trabajos()
+	for i, j := 0, len(trabajos)-1; i < j; i, j = i+1, j-1 {
+		trabajos[i], trabajos[j] = trabajos[j], trabajos[i]
+	}
+	return trabajos
+}
+
+func (m Model) abrirTrabajos() (tea.Model, tea.Cmd) {
+	m.vista = VistaTrabajos
+	m.trabajosCursor = 0
+	m.trabajosScroll = 0
+	m.mensaje = ""
+	return m, nil
+}
+
+func (m Model) filasTrabajos() int {
+	return max(m.lineasPorPagina()-2, 3)
+}
+
+func (m Model) updateTrabajos(msg tea.Msg) (tea.Model, tea.Cmd) {
+	teclaMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	trabajos := m.listaTrabajos()
+	if len(trabajos) == 0 {
+		return m, nil
+	}
+	m.trabajosCursor = min(m.trabajosCursor, len(trabajos)-1)
+	seleccionado := trabajos[m.trabajosCursor]
+
+	switch {
+	case key.Matches(teclaMsg, Teclas.Arriba):
+		m.trabajosCursor = max(m.trabajosCursor-1, 0)
+
+	case key.Matches(teclaMsg, Teclas.Abajo):
+		m.trabajosCursor = min(m.trabajosCursor+1, len(trabajos)-1)
+
+	case key.Matches(teclaMsg, Teclas.Inicio):
+		m.trabajosCursor = 0
+
+	case key.Matches(teclaMsg, Teclas.Final):
+		m.trabajosCursor = len(trabajos) - 1
+
+	case key.Matches(teclaMsg, Teclas.Seleccionar):
+		return m.abrirSalidaTrabajo(seleccionado)
+
+	case key.Matches(teclaMsg, Teclas.Detener):
+		if seleccionado.Cancelar() {
+			m.mensaje = IconWarning(T("trabajos.cancelando"))
+		}
+
+	case key.Matches(teclaMsg, Teclas.Repetir):
+		m.trabajosCursor = 0
+		return m.repetirTrabajo(seleccionado)
+	}
+
+	m.trabajosScroll = min(m.trabajosScroll, m.trabajosCursor)
+	m.trabajosScroll = max(m.trabajosScroll, m.trabajosCursor-m.filasTrabajos()+1)
+	return m, nil
+}
+
+func (m Model) vistaTrabajos() string {
+	var b strings.Builder
+
+	trabajos := m.listaTrabajos()
+	b.WriteString(estiloTitulo.Render("🗂  " + T("trabajos.lista.titulo")))
+	b.WriteString("\n\n")
+
+	if len(trabajos) == 0 {
+		b.WriteString(estiloDesc.Render(T("trabajos.lista.vacia")))
+		b.WriteString("\n\n")
+		b.WriteString(estiloAyuda.Render(T("ayuda.volver", ayudaTecla(Teclas.Volver))))
+		return estiloContenedor.Render(b.String())
+	}
+
+	infos := make([]InfoTrabajo, len(trabajos))
+	anchoAccion := len([]rune(T("trabajos.columna.accion")))
+	anchoTienda := len([]rune(T("trabajos.columna.tienda")))
+	anchoEstado := len([]rune(T("trabajos.columna.estado")))
+	anchoIcono := 0
+	for i, trabajo := range trabajos {
+		infos[i] = trabajo.Info()
+		anchoAccion = max(anchoAccion, len([]rune(nombreAccion(infos[i].Accion))))
+		anchoTienda = max(anchoTienda, len([]rune(infos[i].Tienda.Nombre)))
+	}
+	anchoTienda = min(anchoTienda, 24)
+	for _, estado := range estadosTrabajo {
+		anchoEstado = max(anchoEstado, len([]rune(T("trabajos.estado."+estado))))
+		anchoIcono = max(anchoIcono, lipgloss.Width(iconoEstadoTrabajo(estado)))
+	}
+	anchoID := len(strconv.Itoa(infos[0].ID)) + 1
+	anchoInicio := 11
+	anchoDetalle := max(m.anchoNovedades()-anchoIcono-anchoID-anchoAccion-anchoTienda-anchoEstado-anchoInicio-22, 10)
+
+	b.WriteString(estiloLabel.Render(fmt.Sprintf("  %*s %-*s  %-*s  %-*s  %-*s  %-*s  %-8s  %s", anchoIcono, "",
+		anchoID, "#", anchoAccion, T("trabajos.columna.accion"), anchoTienda, T("trabajos.columna.tienda"),
+		anchoEstado, T("trabajos.columna.estado"), anchoInicio, T("trabajos.columna.inicio"),
+		T("trabajos.columna.duracion"), T("trabajos.columna.detalle"))))
+	b.WriteString("\n")
+
+	inicio := min(m.trabajosScroll, len(infos))
+	fin := min(inicio+m.filasTrabajos(), len(infos))
+	for i, info := range infos[inicio:fin] {
+		estilo := estiloEstadoTrabajo(info.Estado)
+		duracion, hace := "", ""
+		if !info.Inicio.IsZero() {
+			duracion = formatearLapso(info.Duracion())
+			hace = formatearHace(info.Inicio)
+		}
+
+		detalle := ""
+		switch info.Estado {
+		case trabajoFallido:
+			detalle = estiloError.Render(ansi.Truncate(T("trabajos.codigo", info.Codigo)+" · "+info.Error, anchoDetalle, "…"))
+		case trabajoEjecutando:
+			detalle = estiloDesc.Render(ansi.Truncate(info.Salida, anchoDetalle, "…"))
+		}
+
+		cursor := "  "
+		if inicio+i == m.trabajosCursor {
+			cursor = "> "
+		}
+		icono := iconoEstadoTrabajo(info.Estado)
+		icono += strings.Repeat(" ", anchoIcono-lipgloss.Width(icono))
+		b.WriteString(fmt.Sprintf("%s%s %-*s  %-*s  %-*s  %s  %-*s  %-8s  %s\n", cursor, estilo.Render(icono),
+			anchoID, "#"+strconv.Itoa(info.ID), anchoAccion, nombreAccion(info.Accion),
+			anchoTienda, ansi.Truncate(info.Tienda.Nombre, anchoTienda, "…"),
+			estilo.Render(fmt.Sprintf("%-*s", anchoEstado, T("trabajos.estado."+info.Estado))),
+			anchoInicio, hace, duracion, detalle))
+	}
+	if len(infos) > m.filasTrabajos() {
+		b.WriteString(estiloDesc.Render(T("trabajos.lista.posicion", inicio+1, fin, len(infos))))
+		b.WriteString("\n")
+	}
+
+	if m.mensaje != "" {
+		b.WriteString("\n")
+		b.WriteString(estiloAviso.Render(m.mensaje))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(estiloAyuda.Render(T("trabajos.lista.ayuda", ayudaTecla(Teclas.Arriba), ayudaTecla(Teclas.Abajo),
+		ayudaTecla(Teclas.Seleccionar), ayudaTecla(Teclas.Detener), ayudaTecla(Teclas.Repetir), ayudaTecla(Teclas.Volver))))
+	return estiloContenedor.Render(b.String())
+}
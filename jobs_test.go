@@ -0,0 +1,306 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDefinirTrabajo(t *testing.T) {
+	tienda := Tienda{Nombre: "alpha", URL: "alpha.myshopify.com", GitURL: "git@github.com:acme/alpha.git"}
+
+	casos := []struct {
+		accion     string
+		programa   string
+		argumentos []string
+	}{
+		{accionPull, "shopify", []string{"theme", "pull", "--store", "alpha.myshopify.com", "--live"}},
+		{accionPush, "shopify", []string{"theme", "push", "--store", "alpha.myshopify.com", "--development"}},
+		{accionCheck, "shopify", []string{"theme", "check"}},
+		{accionEmpaquetar, "shopify", []string{"theme", "package"}},
+		{accionShopifyPull, "shopify", []string{"theme", "pull", "--store", "alpha.myshopify.com", "--path", "."}},
+		{accionGitClone, "git", []string{"clone", "git@github.com:acme/alpha.git", "."}},
+	}
+
+	for _, caso := range casos {
+		trabajo := definirTrabajo(caso.accion, tienda)
+		if trabajo.Programa != caso.programa || !reflect.DeepEqual(trabajo.Argumentos, caso.argumentos) {
+			t.Errorf("definirTrabajo(%s) = %s %v, se esperaba %s %v", caso.accion, trabajo.Programa, trabajo.Argumentos, caso.programa, caso.argumentos)
+		}
+		if trabajo.Destino != "" {
+			t.Errorf("definirTrabajo(%s) no debería usar un destino temporal", caso.accion)
+		}
+	}
+
+	diff := definirTrabajo(accionDiff, tienda)
+	if diff.Destino == "" || diff.Argumentos[len(diff.Argumentos)-1] != diff.Destino {
+		t.Errorf("el diff debe descargar a un destino temporal: %v", diff.Argumentos)
+	}
+
+	for tema, argumentos := range map[string][]string{"": {"--live"}, temaDesarrollo: {"--development"}, "123456789": {"--theme", "123456789"}} {
+		tienda.TemaRemoto = tema
+		diff := definirTrabajo(accionDiff, tienda)
+		esperados := append(append([]string{"theme", "pull", "--store", "alpha.myshopify.com"}, argumentos...), "--path", diff.Destino)
+		if !reflect.DeepEqual(diff.Argumentos, esperados) {
+			t.Errorf("tema %q: argumentos del diff = %q, se esperaba %q", tema, diff.Argumentos, esperados)
+		}
+	}
+}
+
+func TestArgumentosTema(t *testing.T) {
+	casos := []struct {
+		tema       string
+		accion     string
+		argumentos []string
+	}{
+		{"", accionPull, []string{"--live"}},
+		{"", accionPush, []string{"--development"}},
+		{temaPublicado, accionPull, []string{"--live"}},
+		{temaPublicado, accionPush, []string{"--live", "--allow-live"}},
+		{temaDesarrollo, accionPull, []string{"--development"}},
+		{temaDesarrollo, accionPush, []string{"--development"}},
+		{"123456789", accionPull, []string{"--theme", "123456789"}},
+		{"Rediseño 2025", accionPush, []string{"--theme", "Rediseño 2025"}},
+	}
+
+	for _, caso := range casos {
+		tienda := Tienda{Nombre: "alpha", URL: "alpha.myshopify.com", TemaRemoto: caso.tema}
+		trabajo := definirTrabajo(caso.accion, tienda)
+		esperados := append([]string{"theme", caso.accion, "--store", "alpha.myshopify.com"}, caso.argumentos...)
+		if !reflect.DeepEqual(trabajo.Argumentos, esperados) {
+			t.Errorf("tema %q, %s: argumentos = %q, se esperaba %q", caso.tema, caso.accion, trabajo.Argumentos, esperados)
+		}
+	}
+}
+
+func TestSalidaTrabajo(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	casos := []struct {
+		nombre   string
+		bloques  []string
+		lineas   []string
+		ultima   string
+		conError bool
+	}{
+		{
+			nombre:  "líneas completas",
+			bloques: []string{"Descargando tema\n", "Listo\n"},
+			lineas:  []string{"Descargando tema", "Listo"},
+			ultima:  "Listo",
+		},
+		{
+			nombre:  "línea partida en varios bloques",
+			bloques: []string{"Subien", "do archivos\nSin ", "terminar"},
+			lineas:  []string{"Subiendo archivos", "Sin terminar"},
+			ultima:  "Sin terminar",
+		},
+		{
+			nombre:  "progreso con retorno de carro",
+			bloques: []string{"10%\r", "50%\r", "100%\r\n", "Hecho\r\n"},
+			lineas:  []string{"100%", "Hecho"},
+			ultima:  "Hecho",
+		},
+		{
+			nombre:  "parcial con retorno de carro",
+			bloques: []string{"Inicio\n", "1/3\r2/3\r"},
+			lineas:  []string{"Inicio", "2/3"},
+			ultima:  "2/3",
+		},
+		{
+			nombre:  "colores ANSI",
+			bloques: []string{"\x1b[32m✓ Subido\x1b[0m\n"},
+			lineas:  []string{"✓ Subido"},
+			ultima:  "✓ Subido",
+		},
+		{
+			nombre:   "error con líneas en blanco al final",
+			bloques:  []string{"Error: tema no encontrado\n", "\n", "  \n"},
+			lineas:   []string{"Error: tema no encontrado", "", "  "},
+			ultima:   "Error: tema no encontrado",
+			conError: true,
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			trabajo := &Trabajo{estado: trabajoEjecutando}
+			for _, bloque := range caso.bloques {
+				if n, err := trabajo.Write([]byte(bloque)); err != nil || n != len(bloque) {
+					t.Fatalf("Write(%q) = %d, %v", bloque, n, err)
+				}
+			}
+			if caso.conError {
+				trabajo.err = errors.New("exit status 1")
+			}
+
+			if lineas := trabajo.Lineas(); !reflect.DeepEqual(lineas, caso.lineas) {
+				t.Errorf("Lineas() = %q, se esperaba %q", lineas, caso.lineas)
+			}
+			info := trabajo.Info()
+			if info.Salida != caso.ultima {
+				t.Errorf("Salida = %q, se esperaba %q", info.Salida, caso.ultima)
+			}
+			if caso.conError && info.Error != caso.ultima {
+				t.Errorf("Error = %q, se esperaba %q", info.Error, caso.ultima)
+			}
+		})
+	}
+
+	t.Run("límite de líneas", func(t *testing.T) {
+		trabajo := &Trabajo{estado: trabajoEjecutando}
+		trabajo.Write([]byte(strings.Repeat("línea\n", maxLineasTrabajo+10)))
+		if total := len(trabajo.Lineas()); total != maxLineasTrabajo {
+			t.Fatalf("se guardaron %d líneas, el máximo es %d", total, maxLineasTrabajo)
+		}
+	})
+}
+
+func TestProgresoTrabajo(t *testing.T) {
+	ahora := time.Now()
+
+	casos := []struct {
+		nombre string
+		info   InfoTrabajo
+		ok     bool
+		minimo float64
+		maximo float64
+	}{
+		{"a mitad", InfoTrabajo{Estado: trabajoEjecutando, Inicio: ahora.Add(-30 * time.Second), Estimado: time.Minute}, true, 0.45, 0.55},
+		{"recién empezado", InfoTrabajo{Estado: trabajoEjecutando, Inicio: ahora, Estimado: time.Minute}, true, 0, 0.05},
+		{"sin estimación", InfoTrabajo{Estado: trabajoEjecutando, Inicio: ahora.Add(-30 * time.Second)}, false, 0, 0},
+		{"estimación superada", InfoTrabajo{Estado: trabajoEjecutando, Inicio: ahora.Add(-2 * time.Minute), Estimado: time.Minute}, false, 0, 0},
+		{"pendiente", InfoTrabajo{Estado: trabajoPendiente, Estimado: time.Minute}, false, 0, 0},
+		{"terminado", InfoTrabajo{Estado: trabajoCorrecto, Inicio: ahora.Add(-time.Minute), Fin: ahora.Add(-30 * time.Second), Estimado: time.Minute}, false, 0, 0},
+	}
+
+	for _, caso := range casos {
+		fraccion, restante, ok := caso.info.Progreso()
+		if ok != caso.ok {
+			t.Errorf("%s: Progreso() ok = %v, se esperaba %v", caso.nombre, ok, caso.ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if fraccion < caso.minimo || fraccion > caso.maximo {
+			t.Errorf("%s: fracción %.2f fuera de [%.2f, %.2f]", caso.nombre, fraccion, caso.minimo, caso.maximo)
+		}
+		if restante <= 0 || restante > caso.info.Estimado {
+			t.Errorf("%s: restante = %s", caso.nombre, restante)
+		}
+	}
+}
+
+func TestHistorialTrabajos(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	inicio := time.Now().Add(-time.Minute).Round(time.Second)
+	alpha := Tienda{Nombre: "alpha", URL: "alpha.myshopify.com", Ruta: "/temas/alpha"}
+
+	terminado := func(id int, estado string, err error, salida string) *Trabajo {
+		trabajo := definirTrabajo(accionPush, alpha)
+		trabajo.ID, trabajo.estado, trabajo.inicio, trabajo.fin, trabajo.err = id, estado, inicio, inicio.Add(20*time.Second), err
+		trabajo.Write([]byte(salida))
+		return trabajo
+	}
+
+	correcto := terminado(1, trabajoCorrecto, nil, "Subiendo\nListo\n")
+	fallido := terminado(2, trabajoFallido, errors.New("exit status 2"), "Error: sin permisos\n")
+	fallido.codigo = 2
+	ejecutando := definirTrabajo(accionPull, alpha)
+	ejecutando.ID, ejecutando.estado, ejecutando.inicio = 3, trabajoEjecutando, inicio
+	pendiente := definirTrabajo(accionCheck, alpha)
+	pendiente.ID, pendiente.estado = 4, trabajoPendiente
+
+	gestor := &GestorTrabajos{trabajos: []*Trabajo{correcto, fallido, ejecutando, pendiente}}
+
+	rutaHuerfana, _ := obtenerRutaSalidaTrabajo(99)
+	os.MkdirAll(filepath.Dir(rutaHuerfana), 0755)
+	os.WriteFile(rutaHuerfana, []byte("antigua"), 0644)
+
+	if err := gestor.guardar(correcto); err != nil {
+		t.Fatalf("guardar: %v", err)
+	}
+	if err := gestor.guardar(fallido); err != nil {
+		t.Fatalf("guardar: %v", err)
+	}
+	if _, err := os.Stat(rutaHuerfana); !os.IsNotExist(err) {
+		t.Error("la salida de un trabajo que ya no está en el historial debería borrarse")
+	}
+
+	cargado := &GestorTrabajos{}
+	cargado.cargar()
+
+	trabajos := cargado.Trabajos()
+	if len(trabajos) != 2 {
+		t.Fatalf("se cargaron %d trabajos, solo se guardan los terminados", len(trabajos))
+	}
+	if cargado.siguiente != 2 {
+		t.Errorf("siguiente = %d, se esperaba 2", cargado.siguiente)
+	}
+
+	casos := []struct {
+		trabajo *Trabajo
+		estado  string
+		codigo  int
+		error   string
+		lineas  []string
+	}{
+		{trabajos[0], trabajoCorrecto, 0, "", []string{"Subiendo", "Listo"}},
+		{trabajos[1], trabajoFallido, 2, "Error: sin permisos", []string{"Error: sin permisos"}},
+	}
+
+	for _, caso := range casos {
+		info := caso.trabajo.Info()
+		if info.Estado != caso.estado || info.Codigo != caso.codigo || info.Error != caso.error {
+			t.Errorf("trabajo %d: estado %s, código %d, error %q", info.ID, info.Estado, info.Codigo, info.Error)
+		}
+		if !reflect.DeepEqual(info.Tienda, alpha) {
+			t.Errorf("trabajo %d: tienda %+v", info.ID, info.Tienda)
+		}
+		if !info.Inicio.Equal(inicio) || info.Duracion() != 20*time.Second {
+			t.Errorf("trabajo %d: inicio %s, duración %s", info.ID, info.Inicio, info.Duracion())
+		}
+		if comando := caso.trabajo.Comando(); comando != "shopify theme push --store alpha.myshopify.com --development" {
+			t.Errorf("trabajo %d: comando %q", info.ID, comando)
+		}
+		if lineas := caso.trabajo.Lineas(); !reflect.DeepEqual(lineas, caso.lineas) {
+			t.Errorf("trabajo %d: Lineas() = %q, se esperaba %q", info.ID, lineas, caso.lineas)
+		}
+	}
+}
+
+func TestLimiteHistorialTrabajos(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	gestor := &GestorTrabajos{}
+	activo := &Trabajo{Tienda: Tienda{Nombre: "alpha"}}
+	gestor.registrar(activo)
+	for i := 0; i < maxTrabajosGuardados+5; i++ {
+		trabajo := &Trabajo{Tienda: Tienda{Nombre: "alpha"}}
+		gestor.registrar(trabajo)
+		trabajo.estado = trabajoCorrecto
+	}
+
+	trabajos := gestor.Trabajos()
+	if len(trabajos) != maxTrabajosGuardados {
+		t.Fatalf("hay %d trabajos, el máximo es %d", len(trabajos), maxTrabajosGuardados)
+	}
+	if trabajos[0] != activo {
+		t.Fatal("un trabajo pendiente no debe salir del historial")
+	}
+	if ultimo := trabajos[len(trabajos)-1]; ultimo.ID != maxTrabajosGuardados+6 {
+		t.Fatalf("el último trabajo tiene ID %d", ultimo.ID)
+	}
+}
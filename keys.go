@@ -0,0 +1,421 @@
+package main
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
+
+type MapaTeclas struct {
+	Salir  key.Binding
+	Volver key.Binding
+
+	Arriba      key.Binding
+	Abajo       key.Binding
+	Seleccionar key.Binding
+	Aceptar     key.Binding
+
+	Login         key.Binding
+	AgregarTienda key.Binding
+	Desarrollo    key.Binding
+	Servidores    key.Binding
+	Trabajos      key.Binding
+
+	CampoSiguiente key.Binding
+	CampoAnterior  key.Binding
+
+	ShopifyPull key.Binding
+	GitClone    key.Binding
+
+	SeleccionRapida key.Binding
+	Eliminar        key.Binding
+	Confirmar       key.Binding
+	Filtrar         key.Binding
+	Ordenar         key.Binding
+	Favorita        key.Binding
+	Etiquetar       key.Binding
+	Marcar          key.Binding
+	MarcarTodas     key.Binding
+
+	Iniciar  key.Binding
+	Logs     key.Binding
+	Detener  key.Binding
+	Pull     key.Binding
+	Push     key.Binding
+	Editor   key.Binding
+	Terminal key.Binding
+
+	Check      key.Binding
+	Empaquetar key.Binding
+	Diff       key.Binding
+
+	AdjuntarTerminal key.Binding
+	SalidaTrabajo    key.Binding
+
+	ModoRed  key.Binding
+	CodigoQR key.Binding
+
+	Actualizar key.Binding
+	Novedades  key.Binding
+
+	DetenerTodos key.Binding
+	Reiniciar    key.Binding
+	Reintentar   key.Binding
+	Repetir      key.Binding
+
+	Panel         key.Binding
+	FocoSiguiente key.Binding
+	FocoAnterior  key.Binding
+	Combinar      key.Binding
+
+	Buscar                key.Binding
+	SiguienteCoincidencia key.Binding
+	AnteriorCoincidencia  key.Binding
+	CopiarPagina          key.Binding
+	CopiarTodo            key.Binding
+	CopiarCoincidencias   key.Binding
+	ExportarTexto         key.Binding
+	ExportarJSON          key.Binding
+
+	Menu          key.Binding
+	ModoSeleccion key.Binding
+	Escribir      key.Binding
+	Descartar     key.Binding
+	SoltarTeclado key.Binding
+	DetenerRapido key.Binding
+	Inicio        key.Binding
+	Final         key.Binding
+	PaginaArriba  key.Binding
+	PaginaAbajo   key.Binding
+}
+
+func teclasPorDefecto() MapaTeclas {
+	return MapaTeclas{
+		Salir:  key.NewBinding(key.WithKeys("ctrl+q"), key.WithHelp("Ctrl+Q", T("tecla.salir"))),
+		Volver: key.NewBinding(key.WithKeys("q", "esc"), key.WithHelp("q", T("tecla.volver"))),
+
+		Arriba:      key.NewBinding(key.WithKeys("k", "up"), key.WithHelp("k", T("tecla.arriba"))),
+		Abajo:       key.NewBinding(key.WithKeys("j", "down"), key.WithHelp("j", T("tecla.abajo"))),
+		Seleccionar: key.NewBinding(key.WithKeys("enter", "l"), key.WithHelp("l/enter", T("tecla.seleccionar"))),
+		Aceptar:     key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", T("tecla.aceptar"))),
+
+		Login:         key.NewBinding(key.WithKeys("a"), key.WithHelp("a", T("tecla.login"))),
+		AgregarTienda: key.NewBinding(key.WithKeys("t"), key.WithHelp("t", T("tecla.agregar_tienda"))),
+		Desarrollo:    key.NewBinding(key.WithKeys("d"), key.WithHelp("d", T("tecla.desarrollo"))),
+		Servidores:    key.NewBinding(key.WithKeys("v"), key.WithHelp("v", T("tecla.servidores"))),
+		Trabajos:      key.NewBinding(key.WithKeys("h"), key.WithHelp("h", T("tecla.trabajos"))),
+
+		CampoSiguiente: key.NewBinding(key.WithKeys("tab", "down"), key.WithHelp("tab", T("tecla.campo_siguiente"))),
+		CampoAnterior:  key.NewBinding(key.WithKeys("shift+tab", "up"), key.WithHelp("shift+tab", T("tecla.campo_anterior"))),
+
+		ShopifyPull: key.NewBinding(key.WithKeys("s"), key.WithHelp("s", T("tecla.shopify_pull"))),
+		GitClone:    key.NewBinding(key.WithKeys("g"), key.WithHelp("g", T("tecla.git_clone"))),
+
+		SeleccionRapida: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"),
+			key.WithHelp("1-9", T("tecla.seleccion_rapida")),
+		),
+		Eliminar:  key.NewBinding(key.WithKeys("d"), key.WithHelp("d", T("tecla.eliminar"))),
+		Confirmar: key.NewBinding(key.WithKeys("y"), key.WithHelp("y", T("tecla.confirmar"))),
+		Filtrar:   key.NewBinding(key.WithKeys("/"), key.WithHelp("/", T("tecla.filtrar"))),
+		Ordenar:   key.NewBinding(key.WithKeys("o"), key.WithHelp("o", T("tecla.ordenar"))),
+		Favorita:  key.NewBinding(key.WithKeys("f"), key.WithHelp("f", T("tecla.favorita"))),
+		Etiquetar: key.NewBinding(key.WithKeys("e"), key.WithHelp("e", T("tecla.etiquetar"))),
+
+		Marcar:      key.NewBinding(key.WithKeys(" "), key.WithHelp("space", T("tecla.marcar"))),
+		MarcarTodas: key.NewBinding(key.WithKeys("a"), key.WithHelp("a", T("tecla.marcar_todas"))),
+
+		Iniciar:  key.NewBinding(key.WithKeys("i"), key.WithHelp("i", T("tecla.iniciar"))),
+		Logs:     key.NewBinding(key.WithKeys("l"), key.WithHelp("l", T("tecla.logs"))),
+		Detener:  key.NewBinding(key.WithKeys("s"), key.WithHelp("s", T("tecla.detener"))),
+		Pull:     key.NewBinding(key.WithKeys("p"), key.WithHelp("p", T("tecla.pull"))),
+		Push:     key.NewBinding(key.WithKeys("u"), key.WithHelp("u", T("tecla.push"))),
+		Editor:   key.NewBinding(key.WithKeys("e"), key.WithHelp("e", T("tecla.editor"))),
+		Terminal: key.NewBinding(key.WithKeys("t"), key.WithHelp("t", T("tecla.terminal"))),
+
+		Check:      key.NewBinding(key.WithKeys("c"), key.WithHelp("c", T("tecla.check"))),
+		Empaquetar: key.NewBinding(key.WithKeys("z"), key.WithHelp("z", T("tecla.empaquetar"))),
+		Diff:       key.NewBinding(key.WithKeys("d"), key.WithHelp("d", T("tecla.diff"))),
+
+		AdjuntarTerminal: key.NewBinding(key.WithKeys("ctrl+t"), key.WithHelp("ctrl+t", T("tecla.adjuntar_terminal"))),
+		SalidaTrabajo:    key.NewBinding(key.WithKeys("ctrl+o"), key.WithHelp("ctrl+o", T("tecla.salida_trabajo"))),
+
+		ModoRed:  key.NewBinding(key.WithKeys("w"), key.WithHelp("w", T("tecla.modo_red"))),
+		CodigoQR: key.NewBinding(key.WithKeys("Q"), key.WithHelp("Q", T("tecla.codigo_qr"))),
+
+		Actualizar: key.NewBinding(key.WithKeys("U"), key.WithHelp("U", T("tecla.actualizar"))),
+		Novedades:  key.NewBinding(key.WithKeys("n"), key.WithHelp("n", T("tecla.novedades"))),
+
+		DetenerTodos: key.NewBinding(key.WithKeys("S"), key.WithHelp("S", T("tecla.detener_todos"))),
+		Reiniciar:    key.NewBinding(key.WithKeys("r"), key.WithHelp("r", T("tecla.reiniciar"))),
+		Reintentar:   key.NewBinding(key.WithKeys("r"), key.WithHelp("r", T("tecla.reintentar"))),
+		Repetir:      key.NewBinding(key.WithKeys("r"), key.WithHelp("r", T("tecla.repetir"))),
+
+		Panel:         key.NewBinding(key.WithKeys("p"), key.WithHelp("p", T("tecla.panel"))),
+		FocoSiguiente: key.NewBinding(key.WithKeys("tab", "right"), key.WithHelp("tab", T("tecla.foco_siguiente"))),
+		FocoAnterior:  key.NewBinding(key.WithKeys("shift+tab", "left"), key.WithHelp("shift+tab", T("tecla.foco_anterior"))),
+		Combinar:      key.NewBinding(key.WithKeys("c"), key.WithHelp("c", T("tecla.combinar"))),
+
+		Buscar:                key.NewBinding(key.WithKeys("/"), key.WithHelp("/", T("tecla.buscar"))),
+		SiguienteCoincidencia: key.NewBinding(key.WithKeys("n"), key.WithHelp("n", T("tecla.siguiente_coincidencia"))),
+		AnteriorCoincidencia:  key.NewBinding(key.WithKeys("N"), key.WithHelp("N", T("tecla.anterior_coincidencia"))),
+		CopiarPagina:          key.NewBinding(key.WithKeys("y"), key.WithHelp("y", T("tecla.copiar_pagina"))),
+		CopiarTodo:            key.NewBinding(key.WithKeys("Y"), key.WithHelp("Y", T("tecla.copiar_todo"))),
+		CopiarCoincidencias:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", T("tecla.copiar_coincidencias"))),
+		ExportarTexto:         key.NewBinding(key.WithKeys("x"), key.WithHelp("x", T("tecla.exportar_texto"))),
+		ExportarJSON:          key.NewBinding(key.WithKeys("X"), key.WithHelp("X", T("tecla.exportar_json"))),
+
+		Menu:          key.NewBinding(key.WithKeys(" ", "m", "ctrl+p"), key.WithHelp("space/m", T("tecla.menu"))),
+		ModoSeleccion: key.NewBinding(key.WithKeys("v"), key.WithHelp("v", T("tecla.modo_seleccion"))),
+		Escribir:      key.NewBinding(key.WithKeys("i"), key.WithHelp("i", T("tecla.escribir"))),
+		Descartar:     key.NewBinding(key.WithKeys("x"), key.WithHelp("x", T("tecla.descartar"))),
+		SoltarTeclado: key.NewBinding(key.WithKeys("ctrl+]"), key.WithHelp("ctrl+]", T("tecla.soltar_teclado"))),
+		DetenerRapido: key.NewBinding(key.WithKeys("ctrl+s"), key.WithHelp("Ctrl+S", T("tecla.detener_rapido"))),
+		Inicio:        key.NewBinding(key.WithKeys("g"), key.WithHelp("g", T("tecla.inicio"))),
+		Final:         key.NewBinding(key.WithKeys("G", "ctrl+g"), key.WithHelp("G", T("tecla.final"))),
+		PaginaArriba:  key.NewBinding(key.WithKeys("pgup", "ctrl+u"), key.WithHelp("pgup", T("tecla.pagina_arriba"))),
+		PaginaAbajo:   key.NewBinding(key.WithKeys("pgdown", "ctrl+d"), key.WithHelp("pgdown", T("tecla.pagina_abajo"))),
+	}
+}
+
+var Teclas = teclasPorDefecto()
+
+func (t *MapaTeclas) acciones() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"salir":  &t.Salir,
+		"volver": &t.Volver,
+
+		"arriba":      &t.Arriba,
+		"abajo":       &t.Abajo,
+		"seleccionar": &t.Seleccionar,
+		"aceptar":     &t.Aceptar,
+
+		"login":          &t.Login,
+		"agregar_tienda": &t.AgregarTienda,
+		"desarrollo":     &t.Desarrollo,
+		"servidores":     &t.Servidores,
+		"trabajos":       &t.Trabajos,
+
+		"campo_siguiente": &t.CampoSiguiente,
+		"campo_anterior":  &t.CampoAnterior,
+
+		"shopify_pull": &t.ShopifyPull,
+		"git_clone":    &t.GitClone,
+
+		"seleccion_rapida": &t.SeleccionRapida,
+		"eliminar":         &t.Eliminar,
+		"confirmar":        &t.Confirmar,
+		"filtrar":          &t.Filtrar,
+		"ordenar":          &t.Ordenar,
+		"favorita":         &t.Favorita,
+		"etiquetar":        &t.Etiquetar,
+		"marcar":           &t.Marcar,
+		"marcar_todas":     &t.MarcarTodas,
+
+		"iniciar":  &t.Iniciar,
+		"logs":     &t.Logs,
+		"detener":  &t.Detener,
+		"pull":     &t.Pull,
+		"push":     &t.Push,
+		"editor":   &t.Editor,
+		"terminal": &t.Terminal,
+
+		"check":      &t.Check,
+		"empaquetar": &t.Empaquetar,
+		"diff":       &t.Diff,
+
+		"adjuntar_terminal": &t.AdjuntarTerminal,
+		"salida_trabajo":    &t.SalidaTrabajo,
+
+		"modo_red":  &t.ModoRed,
+		"codigo_qr": &t.CodigoQR,
+
+		"actualizar": &t.Actualizar,
+		"novedades":  &t.Novedades,
+
+		"detener_todos": &t.DetenerTodos,
+		"reiniciar":     &t.Reiniciar,
+		"reintentar":    &t.Reintentar,
+		"repetir":       &t.Repetir,
+
+		"panel":          &t.Panel,
+		"foco_siguiente": &t.FocoSiguiente,
+		"foco_anterior":  &t.FocoAnterior,
+		"combinar":       &t.Combinar,
+
+		"buscar":                 &t.Buscar,
+		"siguiente_coincidencia": &t.SiguienteCoincidencia,
+		"anterior_coincidencia":  &t.AnteriorCoincidencia,
+		"copiar_pagina":          &t.CopiarPagina,
+		"copiar_todo":            &t.CopiarTodo,
+		"copiar_coincidencias":   &t.CopiarCoincidencias,
+		"exportar_texto":         &t.ExportarTexto,
+		"exportar_json":          &t.ExportarJSON,
+
+		"menu":           &t.Menu,
+		"modo_seleccion": &t.ModoSeleccion,
+		"escribir":       &t.Escribir,
+		"descartar":      &t.Descartar,
+		"soltar_teclado": &t.SoltarTeclado,
+		"detener_rapido": &t.DetenerRapido,
+		"inicio":         &t.Inicio,
+		"final":          &t.Final,
+		"pagina_arriba":  &t.PaginaArriba,
+		"pagina_abajo":   &t.PaginaAbajo,
+	}
+}
+
+var contextosTeclas = map[string][]string{
+	"menu": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "seleccionar",
+		"login", "agregar_tienda", "desarrollo", "servidores", "trabajos", "actualizar", "novedades",
+	},
+	"novedades": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "inicio", "final",
+		"pagina_arriba", "pagina_abajo", "actualizar",
+	},
+	"formulario": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "aceptar", "campo_siguiente", "campo_anterior",
+	},
+	"metodo": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "seleccionar",
+		"shopify_pull", "git_clone",
+	},
+	"tiendas": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "seleccionar",
+		"seleccion_rapida", "eliminar", "confirmar", "filtrar", "ordenar", "favorita", "etiquetar",
+		"marcar", "marcar_todas", "pull", "push",
+	},
+	"lote": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "inicio", "final", "seleccionar", "detener", "reintentar",
+	},
+	"trabajo": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "inicio", "final", "pagina_arriba", "pagina_abajo", "detener", "repetir",
+	},
+	"trabajos": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "inicio", "final", "seleccionar", "detener", "repetir",
+	},
+	"modo": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "aceptar",
+		"iniciar", "logs", "detener", "pull", "push", "check", "empaquetar", "diff", "editor", "terminal", "modo_red",
+	},
+	"diff": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "inicio", "final", "seleccionar", "repetir",
+	},
+	"diff_archivo": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "inicio", "final", "pagina_arriba", "pagina_abajo",
+		"foco_siguiente", "foco_anterior",
+	},
+	"servidores": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "detener", "detener_todos", "reiniciar", "panel",
+	},
+	"panel": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "aceptar", "foco_siguiente", "foco_anterior", "combinar",
+	},
+	"logs": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "menu", "modo_seleccion",
+		"detener_rapido", "inicio", "final", "pagina_arriba", "pagina_abajo",
+		"buscar", "siguiente_coincidencia", "anterior_coincidencia", "copiar_pagina", "copiar_todo",
+		"codigo_qr", "escribir", "soltar_teclado", "descartar",
+	},
+	"popup": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "arriba", "abajo", "seleccionar", "menu",
+		"detener", "pull", "push", "editor", "terminal", "modo_red",
+		"copiar_pagina", "copiar_todo", "copiar_coincidencias", "exportar_texto", "exportar_json",
+	},
+	"terminal": {
+		"salir", "adjuntar_terminal", "salida_trabajo", "volver", "aceptar",
+	},
+}
+
+func (t *MapaTeclas) aplicar(atajos map[string][]string) error {
+	acciones := t.acciones()
+
+	nombres := make([]string, 0, len(atajos))
+	for nombre := range atajos {
+		nombres = append(nombres, nombre)
+	}
+	sort.Strings(nombres)
+
+	for _, nombre := range nombres {
+		binding, existe := acciones[nombre]
+		if !existe {
+			return errors.New(T("atajos.accion_desconocida", nombre))
+		}
+
+		teclas := atajos[nombre]
+		if len(teclas) == 0 {
+			return errors.New(T("atajos.sin_teclas", nombre))
+		}
+
+		binding.SetKeys(teclas...)
+		binding.SetHelp(strings.Join(teclas, "/"), binding.Help().Desc)
+	}
+
+	return nil
+}
+
+func (t *MapaTeclas) validar() error {
+	acciones := t.acciones()
+
+	contextos := make([]string, 0, len(contextosTeclas))
+	for contexto := range contextosTeclas {
+		contextos = append(contextos, contexto)
+	}
+	sort.Strings(contextos)
+
+	var conflictos []string
+	for _, contexto := range contextos {
+		usadas := make(map[string]string)
+		for _, nombre := range contextosTeclas[contexto] {
+			for _, tecla := range acciones[nombre].Keys() {
+				if otra, ocupada := usadas[tecla]; ocupada && otra != nombre {
+					conflictos = append(conflictos, T("atajos.conflicto", tecla, otra, nombre, contexto))
+					continue
+				}
+				usadas[tecla] = nombre
+			}
+		}
+	}
+
+	if len(conflictos) > 0 {
+		return errors.New(T("atajos.conflictos", strings.Join(conflictos, "\n  ")))
+	}
+	return nil
+}
+
+func InitTeclas(atajos map[string][]string) error {
+	teclas := teclasPorDefecto()
+
+	if err := teclas.aplicar(atajos); err != nil {
+		return err
+	}
+	if err := teclas.validar(); err != nil {
+		return err
+	}
+
+	Teclas = teclas
+	return nil
+}
+
+func atajoPrincipal(b key.Binding) string {
+	if teclas := b.Keys(); len(teclas) > 0 {
+		return teclas[0]
+	}
+	return ""
+}
+
+func indiceRapido(b key.Binding, tecla string) int {
+	for i, k := range b.Keys() {
+		if k == tecla {
+			return i
+		}
+	}
+	return -1
+}
+
+func ayudaTecla(b key.Binding) string {
+	return b.Help().Key
+}
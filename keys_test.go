@@ -0,0 +1,57 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestInitTeclas(t *testing.T) {
+	anteriores := Teclas
+	t.Cleanup(func() { Teclas = anteriores })
+
+	casos := []struct {
+		nombre string
+		atajos map[string][]string
+		error  []string
+	}{
+		{"por defecto", nil, nil},
+		{"tecla libre", map[string][]string{"login": {"ctrl+l"}}, nil},
+		{"misma tecla en otro contexto", map[string][]string{"login": {"s"}}, nil},
+		{"conflicto resuelto al reasignar", map[string][]string{"login": {"d"}, "desarrollo": {"ctrl+d"}}, nil},
+		{"conflicto en el menú", map[string][]string{"login": {"d"}}, []string{"'d'", "login", "desarrollo", "menu"}},
+		{"conflicto en las tiendas", map[string][]string{"eliminar": {"j"}}, []string{"'j'", "abajo", "eliminar", "tiendas"}},
+		{"acción desconocida", map[string][]string{"volar": {"x"}}, []string{"volar"}},
+		{"sin teclas", map[string][]string{"salir": {}}, []string{"salir"}},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			Teclas = anteriores
+			err := InitTeclas(caso.atajos)
+
+			if caso.error == nil {
+				if err != nil {
+					t.Fatalf("InitTeclas(%v): %v", caso.atajos, err)
+				}
+				for nombre, teclas := range caso.atajos {
+					if got := Teclas.acciones()[nombre].Keys(); strings.Join(got, ",") != strings.Join(teclas, ",") {
+						t.Errorf("%s = %v, se esperaba %v", nombre, got, teclas)
+					}
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("InitTeclas(%v) debería fallar", caso.atajos)
+			}
+			for _, fragmento := range caso.error {
+				if !strings.Contains(err.Error(), fragmento) {
+					t.Errorf("el error %q no menciona %q", err, fragmento)
+				}
+			}
+			if Teclas.Login.Keys()[0] != anteriores.Login.Keys()[0] {
+				t.Error("un mapa inválido no debe reemplazar las teclas actuales")
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"errors"
+	"net"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+const margenQR = 2
+
+func detectarIPLocal() (string, error) {
+	if conexion, err := net.Dial("udp4", "192.0.2.1:9"); err == nil {
+		direccion, ok := conexion.LocalAddr().(*net.UDPAddr)
+		conexion.Close()
+		if ok && !direccion.IP.IsLoopback() && !direccion.IP.IsUnspecified() {
+			return direccion.IP.String(), nil
+		}
+	}
+
+	interfaces, err := net.Interfaces()
+	if err != nil {
+		return "", errors.New(T("red.sin_ip"))
+	}
+	for _, iface := range interfaces {
+		if iface.Flags&net.FlagUp == 0 || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+		direcciones, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		for _, direccion := range direcciones {
+			if red, ok := direccion.(*net.IPNet); ok && red.IP.To4() != nil && red.IP.IsPrivate() {
+				return red.IP.String(), nil
+			}
+		}
+	}
+	return "", errors.New(T("red.sin_ip"))
+}
+
+func renderQR(texto string) (string, error) {
+	codigo, err := qrcode.New(texto, qrcode.Low)
+	if err != nil {
+		return "", err
+	}
+	codigo.DisableBorder = true
+	modulos := codigo.Bitmap()
+	lado := len(modulos)
+
+	claro := frenteQRClaro()
+	pintado := func(x, y int) bool {
+		oscuro := x >= 0 && y >= 0 && x < lado && y < lado && modulos[y][x]
+		return oscuro != claro
+	}
+
+	var b strings.Builder
+	for y := -margenQR; y < lado+margenQR; y += 2 {
+		if y > -margenQR {
+			b.WriteString("\n")
+		}
+		for x := -margenQR; x < lado+margenQR; x++ {
+			arriba, abajo := pintado(x, y), pintado(x, y+1)
+			switch {
+			case arriba && abajo:
+				b.WriteString("█")
+			case arriba:
+				b.WriteString("▀")
+			case abajo:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+	}
+	return estiloQR.Render(b.String()), nil
+}
+
+func (m Model) conCodigoQR(contenido string, servidor *InstantaneaServidor) string {
+	if m.ocultarQR || servidor == nil || !servidor.Activo() || servidor.URLRed == "" || m.ancho == 0 {
+		return contenido
+	}
+
+	qr, err := renderQR(servidor.URLRed)
+	if err != nil {
+		return contenido
+	}
+
+	anchoQR := lipgloss.Width(qr)
+	anchoLogs := m.ancho - anchoQR - 2
+	if anchoLogs < 40 || lipgloss.Height(qr)+1 > m.lineasPorPagina() {
+		return contenido
+	}
+
+	lineas := strings.Split(strings.TrimRight(contenido, "\n"), "\n")
+	for i, linea := range lineas {
+		lineas[i] = ansi.Truncate(linea, anchoLogs, "…")
+	}
+	logs := lipgloss.PlaceHorizontal(anchoLogs, lipgloss.Left, strings.Join(lineas, "\n"))
+
+	leyenda := estiloDesc.Render(ansi.Truncate(T("red.escanear", ayudaTecla(Teclas.CodigoQR)), anchoQR, "…"))
+	return lipgloss.JoinHorizontal(lipgloss.Top, logs, "  ", qr+"\n"+leyenda) + "\n"
+}
+
+func (m Model) alternarModoRed() (tea.Model, tea.Cmd) {
+	for i := range m.tiendas {
+		if m.tiendas[i].Nombre != m.tiendaParaDev.Nombre {
+			continue
+		}
+
+		m.tiendas[i].ModoRed = !m.tiendas[i].ModoRed
+		if err := guardarTiendas(m.tiendas); err != nil {
+			m.mensaje = IconError(T("error.generico", err.Error()))
+			return m, nil
+		}
+		m.tiendaParaDev = m.tiendas[i]
+
+		if m.vista == VistaSeleccionarModo {
+			indice := m.lista.Index()
+			m.lista.SetItems(crearListaModos(m.tiendaParaDev, ObtenerGestor().TieneServidorActivo(m.tiendaParaDev.Nombre)))
+			m.lista.Select(indice)
+		}
+
+		estado := T("red.desactivado", m.tiendaParaDev.Nombre)
+		if m.tiendaParaDev.ModoRed {
+			estado = T("red.activado", m.tiendaParaDev.Nombre)
+		}
+
+		if ObtenerGestor().TieneServidorActivo(m.tiendaParaDev.Nombre) {
+			m.mensaje = IconInfo(estado + " " + T("red.reiniciando"))
+			return m, reiniciarServidor(m.tiendaParaDev)
+		}
+		m.mensaje = IconSuccess(estado)
+		return m, nil
+	}
+	return m, nil
+}
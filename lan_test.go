@@ -0,0 +1,128 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	qrcode "github.com/skip2/go-qrcode"
+)
+
+func TestRenderQR(t *testing.T) {
+	casos := []string{
+		"http://192.168.1.20:9292",
+		"http://10.0.0.5:9292/?preview_theme_id=123456789",
+		"https://tienda-con-un-nombre-bastante-largo.myshopify.com/products/camiseta?variant=42",
+	}
+
+	for _, texto := range casos {
+		qr, err := renderQR(texto)
+		if err != nil {
+			t.Fatalf("renderQR(%q): %v", texto, err)
+		}
+
+		codigo, _ := qrcode.New(texto, qrcode.Low)
+		codigo.DisableBorder = true
+		lado := len(codigo.Bitmap()) + 2*margenQR
+
+		filas := strings.Split(ansi.Strip(qr), "\n")
+		if len(filas) != (lado+1)/2 {
+			t.Errorf("%q: %d filas, se esperaban %d", texto, len(filas), (lado+1)/2)
+		}
+		for i, fila := range filas {
+			if ancho := ansi.StringWidth(fila); ancho != lado {
+				t.Errorf("%q: la fila %d mide %d, se esperaba %d", texto, i, ancho, lado)
+			}
+		}
+		if filas[0] != strings.Repeat("█", lado) {
+			t.Errorf("%q: falta el margen claro superior: %q", texto, filas[0])
+		}
+	}
+}
+
+func TestConCodigoQR(t *testing.T) {
+	activo := &InstantaneaServidor{URLRed: "http://192.168.1.20:9292", Ciclo: CicloVida{Estado: EstadoListo}}
+	sinRed := &InstantaneaServidor{Ciclo: CicloVida{Estado: EstadoListo}}
+	detenido := &InstantaneaServidor{URLRed: "http://192.168.1.20:9292", Ciclo: CicloVida{Estado: EstadoDetenido}}
+	contenido := strings.Repeat("[12:00:00] una línea de log bastante larga que habría que recortar al lado del código\n", 3)
+
+	casos := []struct {
+		nombre    string
+		modelo    Model
+		servidor  *InstantaneaServidor
+		conCodigo bool
+	}{
+		{"con espacio", Model{ancho: 120, alto: 50}, activo, true},
+		{"oculto", Model{ancho: 120, alto: 50, ocultarQR: true}, activo, false},
+		{"sin servidor", Model{ancho: 120, alto: 50}, nil, false},
+		{"sin modo red", Model{ancho: 120, alto: 50}, sinRed, false},
+		{"servidor detenido", Model{ancho: 120, alto: 50}, detenido, false},
+		{"sin tamaño", Model{}, activo, false},
+		{"terminal estrecha", Model{ancho: 60, alto: 50}, activo, false},
+		{"terminal baja", Model{ancho: 120, alto: 20}, activo, false},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			resultado := caso.modelo.conCodigoQR(contenido, caso.servidor)
+			if !caso.conCodigo {
+				if resultado != contenido {
+					t.Fatalf("se esperaba el contenido sin código QR:\n%s", resultado)
+				}
+				return
+			}
+
+			qr, _ := renderQR(activo.URLRed)
+			anchoLogs := caso.modelo.ancho - ansi.StringWidth(strings.Split(qr, "\n")[0]) - 2
+			for i, fila := range strings.Split(strings.TrimRight(resultado, "\n"), "\n") {
+				if ancho := ansi.StringWidth(fila); ancho > caso.modelo.ancho {
+					t.Errorf("la fila %d mide %d, más que la terminal (%d)", i, ancho, caso.modelo.ancho)
+				}
+			}
+			primera := ansi.Strip(strings.Split(resultado, "\n")[0])
+			if !strings.HasPrefix(primera, ansi.Truncate(strings.Split(contenido, "\n")[0], anchoLogs, "…")) {
+				t.Errorf("los logs no se recortaron a %d columnas: %q", anchoLogs, primera)
+			}
+			if !strings.Contains(ansi.Strip(resultado), "█") {
+				t.Error("no se dibujó el código QR")
+			}
+		})
+	}
+}
+
+func TestColoresQR(t *testing.T) {
+	anterior := Tema
+	t.Cleanup(func() {
+		Tema = anterior
+		aplicarTema(Tema)
+	})
+
+	casos := []struct {
+		nombre string
+		paleta Paleta
+		margen string
+	}{
+		{"oscuro", PaletaOscura, "█"},
+		{"claro", PaletaClara, " "},
+		{"alto contraste", PaletaAltoContraste, "█"},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			Tema = caso.paleta
+			aplicarTema(Tema)
+
+			qr, err := renderQR("http://192.168.1.20:9292")
+			if err != nil {
+				t.Fatal(err)
+			}
+			fila := strings.Split(ansi.Strip(qr), "\n")[0]
+			if fila != strings.Repeat(caso.margen, ansi.StringWidth(fila)) {
+				t.Fatalf("el margen claro debería dibujarse con %q: %q", caso.margen, fila)
+			}
+			if estiloQR.GetForeground() != colorTema(caso.paleta.Texto) || estiloQR.GetBackground() != colorTema(caso.paleta.FondoPopup) {
+				t.Fatalf("el código QR no usa los colores de la paleta: %v sobre %v", estiloQR.GetForeground(), estiloQR.GetBackground())
+			}
+		})
+	}
+}
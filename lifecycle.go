@@ -0,0 +1,122 @@
+package main
+
+import (
+	"os/exec"
+	"slices"
+	"time"
+)
+
+type EstadoServidor string
+
+const (
+	EstadoIniciando  EstadoServidor = "iniciando"
+	EstadoListo      EstadoServidor = "listo"
+	EstadoDeteniendo EstadoServidor = "deteniendo"
+	EstadoDetenido   EstadoServidor = "detenido"
+	EstadoCaido      EstadoServidor = "caido"
+)
+
+var transicionesServidor = map[EstadoServidor][]EstadoServidor{
+	EstadoIniciando:  {EstadoListo, EstadoDeteniendo, EstadoDetenido, EstadoCaido},
+	EstadoListo:      {EstadoDeteniendo, EstadoDetenido, EstadoCaido},
+	EstadoDeteniendo: {EstadoDetenido},
+}
+
+var eventosEstado = map[EstadoServidor]TipoEvento{
+	EstadoListo:      EventoListo,
+	EstadoDeteniendo: EventoDeteniendo,
+	EstadoDetenido:   EventoDetenido,
+	EstadoCaido:      EventoCaido,
+}
+
+var esperaTerminacion = 2 * time.Second
+
+var comandoServidor = func(argumentos ...string) *exec.Cmd {
+	return exec.Command("shopify", argumentos...)
+}
+
+type CicloVida struct {
+	Estado     EstadoServidor
+	Listo      time.Time
+	Deteniendo time.Time
+	Finalizado time.Time
+	Codigo     int
+}
+
+func (e EstadoServidor) Activo() bool {
+	return e == EstadoIniciando || e == EstadoListo
+}
+
+func (e EstadoServidor) Final() bool {
+	return e == EstadoDetenido || e == EstadoCaido
+}
+
+func (e EstadoServidor) Texto() string {
+	return T("estado." + string(e))
+}
+
+func puedeTransicionar(desde, hacia EstadoServidor) bool {
+	return slices.Contains(transicionesServidor[desde], hacia)
+}
+
+func (s *ServidorActivo) CicloVida() CicloVida {
+	s.LogsMutex.RLock()
+	defer s.LogsMutex.RUnlock()
+
+	return s.ciclo
+}
+
+func (s *ServidorActivo) Estado() EstadoServidor {
+	return s.CicloVida().Estado
+}
+
+func (s *ServidorActivo) Activo() bool {
+	return s.Estado().Activo()
+}
+
+func (s *ServidorActivo) transicionar(hacia EstadoServidor) bool {
+	s.LogsMutex.Lock()
+	defer s.LogsMutex.Unlock()
+
+	if !puedeTransicionar(s.ciclo.Estado, hacia) {
+		return false
+	}
+
+	ahora := time.Now()
+	s.ciclo.Estado = hacia
+	switch hacia {
+	case EstadoListo:
+		s.ciclo.Listo = ahora
+	case EstadoDeteniendo:
+		s.ciclo.Deteniendo = ahora
+	case EstadoDetenido, EstadoCaido:
+		s.ciclo.Finalizado = ahora
+	}
+	return true
+}
+
+func (s *ServidorActivo) registrarSalida(err error) (EstadoServidor, error) {
+	s.LogsMutex.Lock()
+	defer s.LogsMutex.Unlock()
+
+	if s.ciclo.Estado.Final() {
+		return s.ciclo.Estado, err
+	}
+
+	s.ciclo.Codigo = codigoSalida(err)
+	hacia := EstadoCaido
+	if err == nil || s.ciclo.Estado == EstadoDeteniendo {
+		hacia, err = EstadoDetenido, nil
+	}
+	s.ciclo.Estado = hacia
+	s.ciclo.Finalizado = time.Now()
+	return hacia, err
+}
+
+func (g *GestorServidores) cambiarEstado(servidor *ServidorActivo, hacia EstadoServidor) bool {
+	if !servidor.transicionar(hacia) {
+		return false
+	}
+	g.publicar(eventosEstado[hacia], servidor.Tienda.Nombre, string(hacia))
+	return true
+}
@@ -0,0 +1,356 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+)
+
+const variableProcesoFalso = "SHO_PROCESO_FALSO"
+
+func TestProcesoFalso(t *testing.T) {
+	modo := os.Getenv(variableProcesoFalso)
+	if modo == "" {
+		return
+	}
+
+	fmt.Println("proceso falso:", modo)
+	switch {
+	case modo == "esperar":
+		time.Sleep(time.Minute)
+	case modo == "ignorar":
+		signal.Ignore(syscall.SIGTERM)
+		time.Sleep(time.Minute)
+	case modo == "servir":
+		puerto := ""
+		for i, argumento := range os.Args {
+			if argumento == "--port" && i+1 < len(os.Args) {
+				puerto = os.Args[i+1]
+			}
+		}
+		http.ListenAndServe("127.0.0.1:"+puerto, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	case strings.HasPrefix(modo, "salir:"):
+		codigo, _ := strconv.Atoi(strings.TrimPrefix(modo, "salir:"))
+		time.Sleep(100 * time.Millisecond)
+		os.Exit(codigo)
+	}
+	os.Exit(0)
+}
+
+func usarProcesoFalso(t *testing.T, modo string) {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+	t.Setenv("TERM", "dumb")
+	t.Setenv(variableProcesoFalso, modo)
+
+	comandoAnterior, saludAnterior := comandoServidor, SaludGlobal
+	comandoServidor = func(argumentos ...string) *exec.Cmd {
+		return exec.Command(os.Args[0], append([]string{"-test.run=^TestProcesoFalso$", "--"}, argumentos...)...)
+	}
+	SaludGlobal.Desactivada = true
+	t.Cleanup(func() {
+		comandoServidor, SaludGlobal = comandoAnterior, saludAnterior
+	})
+}
+
+func iniciarFalso(t *testing.T, nombre string) *ServidorActivo {
+	t.Helper()
+
+	servidor, err := ObtenerGestor().IniciarServidor(Tienda{Nombre: nombre, URL: nombre + ".myshopify.com", Ruta: t.TempDir()})
+	if err != nil {
+		t.Fatalf("IniciarServidor(%s): %v", nombre, err)
+	}
+	t.Cleanup(func() {
+		ObtenerGestor().DetenerServidor(nombre)
+		esperarFin(t, servidor)
+	})
+	return servidor
+}
+
+func esperarEstado(t *testing.T, servidor *ServidorActivo, estado EstadoServidor) {
+	t.Helper()
+
+	limite := time.Now().Add(10 * time.Second)
+	for servidor.Estado() != estado {
+		if time.Now().After(limite) {
+			t.Fatalf("estado = %s, se esperaba %s", servidor.Estado(), estado)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func esperarFin(t *testing.T, servidor *ServidorActivo) {
+	t.Helper()
+
+	select {
+	case <-servidor.terminado:
+	case <-time.After(10 * time.Second):
+		t.Fatalf("el servidor de %s no terminó", servidor.Tienda.Nombre)
+	}
+}
+
+func TestTransicionesServidor(t *testing.T) {
+	casos := []struct {
+		desde, hacia EstadoServidor
+		valida       bool
+	}{
+		{EstadoIniciando, EstadoListo, true},
+		{EstadoIniciando, EstadoCaido, true},
+		{EstadoListo, EstadoDeteniendo, true},
+		{EstadoListo, EstadoListo, false},
+		{EstadoListo, EstadoIniciando, false},
+		{EstadoDeteniendo, EstadoDetenido, true},
+		{EstadoDeteniendo, EstadoCaido, false},
+		{EstadoDeteniendo, EstadoListo, false},
+		{EstadoDetenido, EstadoIniciando, false},
+		{EstadoCaido, EstadoDetenido, false},
+	}
+
+	for _, caso := range casos {
+		if got := puedeTransicionar(caso.desde, caso.hacia); got != caso.valida {
+			t.Errorf("puedeTransicionar(%s, %s) = %v, se esperaba %v", caso.desde, caso.hacia, got, caso.valida)
+		}
+	}
+}
+
+func TestRegistrarSalidaDeteniendo(t *testing.T) {
+	servidor := &ServidorActivo{ciclo: CicloVida{Estado: EstadoDeteniendo}}
+
+	estado, err := servidor.registrarSalida(errors.New("signal: killed"))
+	if estado != EstadoDetenido || err != nil {
+		t.Fatalf("registrarSalida = (%s, %v), se esperaba (%s, nil)", estado, err, EstadoDetenido)
+	}
+	if codigo := servidor.CicloVida().Codigo; codigo != -1 {
+		t.Fatalf("código = %d, se esperaba el de la salida real (-1)", codigo)
+	}
+
+	estado, _ = servidor.registrarSalida(errors.New("otra salida"))
+	if estado != EstadoDetenido {
+		t.Fatalf("un estado final no debe cambiar, se obtuvo %s", estado)
+	}
+}
+
+func TestCicloVidaDetener(t *testing.T) {
+	usarProcesoFalso(t, "esperar")
+	servidor := iniciarFalso(t, "ciclo-detener")
+
+	esperarEstado(t, servidor, EstadoListo)
+	if err := ObtenerGestor().DetenerServidor("ciclo-detener"); err != nil {
+		t.Fatalf("DetenerServidor: %v", err)
+	}
+	if estado := servidor.Estado(); estado != EstadoDeteniendo && estado != EstadoDetenido {
+		t.Fatalf("estado tras detener = %s", estado)
+	}
+	if err := ObtenerGestor().DetenerServidor("ciclo-detener"); err == nil {
+		t.Fatal("detener dos veces debería fallar")
+	}
+	if ObtenerGestor().Descartar("ciclo-detener") && servidor.Estado() == EstadoDeteniendo {
+		t.Fatal("no se debe descartar un servidor que aún no terminó")
+	}
+
+	esperarFin(t, servidor)
+	ciclo := servidor.CicloVida()
+	if ciclo.Estado != EstadoDetenido || ciclo.Codigo == 0 {
+		t.Fatalf("ciclo = %+v, se esperaba detenido con el código de la señal", ciclo)
+	}
+	if ciclo.Listo.IsZero() || ciclo.Deteniendo.Before(ciclo.Listo) || ciclo.Finalizado.Before(ciclo.Deteniendo) {
+		t.Fatalf("marcas de tiempo desordenadas: %+v", ciclo)
+	}
+	if ObtenerGestor().TieneServidorActivo("ciclo-detener") {
+		t.Fatal("el servidor sigue activo")
+	}
+}
+
+func TestCicloVidaDetenerIgnorandoSenal(t *testing.T) {
+	usarProcesoFalso(t, "ignorar")
+	anterior := esperaTerminacion
+	esperaTerminacion = 200 * time.Millisecond
+	t.Cleanup(func() { esperaTerminacion = anterior })
+	servidor := iniciarFalso(t, "ciclo-ignorar")
+
+	esperarEstado(t, servidor, EstadoListo)
+	for !strings.Contains(strings.Join(servidor.ObtenerLogs(), "\n"), "proceso falso") {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if err := ObtenerGestor().DetenerServidor("ciclo-ignorar"); err != nil {
+		t.Fatalf("DetenerServidor: %v", err)
+	}
+
+	esperarFin(t, servidor)
+	if estado := servidor.Estado(); estado != EstadoDetenido {
+		t.Fatalf("estado = %s, se esperaba %s", estado, EstadoDetenido)
+	}
+}
+
+func TestReiniciarConservaLogs(t *testing.T) {
+	usarProcesoFalso(t, "esperar")
+	anterior := iniciarFalso(t, "ciclo-reiniciar")
+	esperarEstado(t, anterior, EstadoListo)
+	for i := range maxLogsServidor {
+		anterior.AgregarLog(fmt.Sprintf("linea %d", i))
+	}
+	anterior.AgregarLog("Error: algo falló")
+
+	servidor, err := ObtenerGestor().ReiniciarServidor(anterior.Tienda)
+	if err != nil {
+		t.Fatalf("ReiniciarServidor: %v", err)
+	}
+	t.Cleanup(func() {
+		ObtenerGestor().DetenerServidor("ciclo-reiniciar")
+		esperarFin(t, servidor)
+	})
+
+	logs := servidor.ObtenerLogs()
+	if len(logs) > maxLogsServidor {
+		t.Fatalf("%d líneas tras reiniciar, el máximo es %d", len(logs), maxLogsServidor)
+	}
+	marca := slices.Index(logs, "--- "+T("servidor.reiniciado")+" ---")
+	if marca < 0 || !slices.Contains(logs[:marca], "Error: algo falló") {
+		t.Fatalf("logs tras reiniciar = %q, se esperaba el historial anterior y la marca", logs)
+	}
+	if errores := servidor.ContarErrores(); errores < 1 {
+		t.Fatalf("ContarErrores = %d, se esperaba conservar los errores anteriores", errores)
+	}
+
+	indice := servidor.agregarLineaTerminal()
+	servidor.escribirLineaTerminal(indice, "nueva")
+	if logs := servidor.ObtenerLogs(); logs[len(logs)-1] != "nueva" {
+		t.Fatalf("última línea = %q, se esperaba la escrita por la terminal", logs[len(logs)-1])
+	}
+}
+
+func TestPuertoReservadoHastaTerminar(t *testing.T) {
+	servidor := &ServidorActivo{Tienda: Tienda{Nombre: "puerto"}, Puerto: 9292, ciclo: CicloVida{Estado: EstadoListo}}
+	gestor := &GestorServidores{
+		servidores: map[string]*ServidorActivo{"puerto": servidor},
+		puertos:    map[int]bool{9292: true},
+	}
+
+	if err := gestor.DetenerServidor("puerto"); err != nil {
+		t.Fatalf("DetenerServidor: %v", err)
+	}
+	if puerto := gestor.ObtenerPuertoDisponible(); puerto == 9292 {
+		t.Fatal("el puerto se liberó antes de que el proceso terminara")
+	}
+
+	gestor.finalizar(servidor, nil)
+	if puerto := gestor.ObtenerPuertoDisponible(); puerto != 9292 {
+		t.Fatalf("puerto disponible = %d, se esperaba 9292 tras terminar", puerto)
+	}
+}
+
+func TestCicloVidaCaida(t *testing.T) {
+	usarProcesoFalso(t, "salir:3")
+	servidor := iniciarFalso(t, "ciclo-caida")
+
+	esperarFin(t, servidor)
+	ciclo := servidor.CicloVida()
+	if ciclo.Estado != EstadoCaido || ciclo.Codigo != 3 {
+		t.Fatalf("ciclo = %+v, se esperaba caído con código 3", ciclo)
+	}
+	if ciclo.Finalizado.IsZero() {
+		t.Fatal("falta la marca de fin")
+	}
+	if ObtenerGestor().ObtenerServidor("ciclo-caida") != servidor {
+		t.Fatal("un servidor caído debe seguir disponible hasta descartarlo")
+	}
+	if !ObtenerGestor().Descartar("ciclo-caida") || ObtenerGestor().ObtenerServidor("ciclo-caida") != nil {
+		t.Fatal("Descartar no quitó el servidor terminado")
+	}
+}
+
+func TestCicloVidaSalidaLimpia(t *testing.T) {
+	usarProcesoFalso(t, "salir:0")
+	servidor := iniciarFalso(t, "ciclo-limpia")
+
+	esperarFin(t, servidor)
+	if ciclo := servidor.CicloVida(); ciclo.Estado != EstadoDetenido || ciclo.Codigo != 0 {
+		t.Fatalf("ciclo = %+v, se esperaba detenido con código 0", ciclo)
+	}
+}
+
+func TestCicloVidaListoPorSalud(t *testing.T) {
+	usarProcesoFalso(t, "servir")
+	SaludGlobal = ConfigSalud{Intervalo: 1, Timeout: 1, Lenta: 1000, Fallos: 3, Arranque: 120}
+	servidor := iniciarFalso(t, "ciclo-salud")
+
+	if estado := servidor.Estado(); estado != EstadoIniciando {
+		t.Fatalf("estado inicial = %s, se esperaba %s", estado, EstadoIniciando)
+	}
+	esperarEstado(t, servidor, EstadoListo)
+}
+
+func TestCicloVidaDetenerConcurrente(t *testing.T) {
+	usarProcesoFalso(t, "esperar")
+
+	var servidores []*ServidorActivo
+	for i := range 3 {
+		servidores = append(servidores, iniciarFalso(t, fmt.Sprintf("ciclo-concurrente-%d", i)))
+	}
+
+	var lectores, detenciones sync.WaitGroup
+	parar := make(chan struct{})
+	for range 4 {
+		lectores.Add(1)
+		go func() {
+			defer lectores.Done()
+			for {
+				select {
+				case <-parar:
+					return
+				default:
+				}
+				for _, servidor := range ObtenerGestor().ObtenerServidoresActivos() {
+					servidor.CicloVida()
+					servidor.Activo()
+				}
+				ObtenerGestor().ContarActivos()
+			}
+		}()
+	}
+
+	for _, servidor := range servidores {
+		var exitos sync.Map
+		for intento := range 5 {
+			detenciones.Add(1)
+			go func() {
+				defer detenciones.Done()
+				if ObtenerGestor().DetenerServidor(servidor.Tienda.Nombre) == nil {
+					exitos.Store(intento, true)
+				}
+			}()
+		}
+		detenciones.Wait()
+
+		total := 0
+		exitos.Range(func(any, any) bool {
+			total++
+			return true
+		})
+		if total != 1 {
+			t.Errorf("%s: %d detenciones correctas, se esperaba 1", servidor.Tienda.Nombre, total)
+		}
+	}
+
+	for _, servidor := range servidores {
+		esperarFin(t, servidor)
+		if estado := servidor.Estado(); estado != EstadoDetenido {
+			t.Errorf("%s: estado = %s, se esperaba %s", servidor.Tienda.Nombre, estado, EstadoDetenido)
+		}
+	}
+	close(parar)
+	lectores.Wait()
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const (
+	formatoTexto = "texto"
+	formatoJSON  = "json"
+)
+
+const (
+	alcancePagina        = "pagina"
+	alcanceTodo          = "todo"
+	alcanceCoincidencias = "coincidencias"
+)
+
+type lineaExportada struct {
+	Momento time.Time `json:"momento"`
+	Fuente  string    `json:"fuente,omitempty"`
+	Texto   string    `json:"texto"`
+}
+
+type logsExportados struct {
+	Tienda    string           `json:"tienda"`
+	URL       string           `json:"url"`
+	Exportado time.Time        `json:"exportado"`
+	Lineas    []lineaExportada `json:"lineas"`
+}
+
+func secuenciaPortapapeles(texto string) osc52.Sequence {
+	secuencia := osc52.New(texto)
+	switch {
+	case os.Getenv("TMUX") != "":
+		return secuencia.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		return secuencia.Screen()
+	}
+	return secuencia
+}
+
+func copiarAlPortapapeles(texto string) error {
+	_, err := secuenciaPortapapeles(texto).WriteTo(os.Stderr)
+	return err
+}
+
+func coincideLog(linea, termino string) bool {
+	return termino != "" && strings.Contains(strings.ToLower(ansi.Strip(linea)), strings.ToLower(termino))
+}
+
+func coincidenciasLogs(logs []string, termino string) []int {
+	var indices []int
+	for i, linea := range logs {
+		if coincideLog(linea, termino) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+func (m Model) lineasPorPagina() int {
+	if m.alto == 0 {
+		return 15
+	}
+	return max(m.alto-12, 5)
+}
+
+func (m Model) paginaLogs(total int) (int, int) {
+	inicio := min(m.logsScroll, total)
+	fin := min(inicio+m.lineasPorPagina(), total)
+	return inicio, fin
+}
+
+func (m Model) copiarLogs(alcance string) Model {
+	servidor := ObtenerGestor().ObtenerServidor(m.tiendaParaDev.Nombre)
+	if servidor == nil {
+		m.mensaje = IconError(T("logs.sin_servidor"))
+		return m
+	}
+
+	logs := servidor.ObtenerLogs()
+	var seleccion []string
+	switch alcance {
+	case alcancePagina:
+		inicio, fin := m.paginaLogs(len(logs))
+		seleccion = logs[inicio:fin]
+	case alcanceTodo:
+		seleccion = logs
+	case alcanceCoincidencias:
+		if m.busquedaLogs == "" {
+			m.mensaje = IconWarning(T("logs.sin_busqueda", ayudaTecla(Teclas.Buscar)))
+			return m
+		}
+		for _, i := range coincidenciasLogs(logs, m.busquedaLogs) {
+			seleccion = append(seleccion, logs[i])
+		}
+	}
+
+	if len(seleccion) == 0 {
+		m.mensaje = IconWarning(T("logs.nada_que_copiar"))
+		return m
+	}
+
+	lineas := make([]string, len(seleccion))
+	for i, linea := range seleccion {
+		lineas[i] = ansi.Strip(linea)
+	}
+
+	if err := copiarAlPortapapeles(strings.Join(lineas, "\n")); err != nil {
+		m.mensaje = IconError(T("logs.error_copiar", err))
+		return m
+	}
+	m.mensaje = IconSuccess(T("logs.copiadas", len(lineas)))
+	return m
+}
+
+func exportarLogs(servidor *ServidorActivo, formato string) (string, error) {
+	dirBase, err := obtenerDirectorioBase()
+	if err != nil {
+		return "", err
+	}
+
+	dirLogs := filepath.Join(dirBase, "logs")
+	if err := os.MkdirAll(dirLogs, 0755); err != nil {
+		return "", err
+	}
+
+	ahora := time.Now()
+	extension := ".log"
+	if formato == formatoJSON {
+		extension = ".json"
+	}
+	ruta := filepath.Join(dirLogs, sanitizarNombre(servidor.Tienda.Nombre)+"-"+ahora.Format("20060102-150405")+extension)
+
+	lineas := servidor.ObtenerLineas()
+	var datos []byte
+
+	if formato == formatoJSON {
+		exportados := logsExportados{
+			Tienda:    servidor.Tienda.Nombre,
+			URL:       servidor.Tienda.URL,
+			Exportado: ahora,
+			Lineas:    make([]lineaExportada, 0, len(lineas)),
+		}
+		for _, linea := range lineas {
+			fuente, texto := separarFuenteLog(linea.Texto, servidor.Tienda)
+			exportados.Lineas = append(exportados.Lineas, lineaExportada{
+				Momento: linea.Momento,
+				Fuente:  fuente,
+				Texto:   ansi.Strip(texto),
+			})
+		}
+		datos, err = json.MarshalIndent(exportados, "", "  ")
+		if err != nil {
+			return "", err
+		}
+	} else {
+		var b strings.Builder
+		for _, linea := range lineas {
+			fmt.Fprintf(&b, "%s  %s\n", linea.Momento.Format("2006-01-02 15:04:05"), ansi.Strip(linea.Texto))
+		}
+		datos = []byte(b.String())
+	}
+
+	if err := os.WriteFile(ruta, datos, 0644); err != nil {
+		return "", err
+	}
+	return ruta, nil
+}
+
+func (m Model) exportarLogs(formato string) Model {
+	servidor := ObtenerGestor().ObtenerServidor(m.tiendaParaDev.Nombre)
+	if servidor == nil {
+		m.mensaje = IconError(T("logs.sin_servidor"))
+		return m
+	}
+
+	ruta, err := exportarLogs(servidor, formato)
+	if err != nil {
+		m.mensaje = IconError(T("logs.error_exportar", err))
+		return m
+	}
+	m.mensaje = IconSuccess(T("logs.exportados", ruta))
+	return m
+}
+
+func (m Model) irACoincidencia(desde int, adelante bool) Model {
+	servidor := ObtenerGestor().ObtenerServidor(m.tiendaParaDev.Nombre)
+	if servidor == nil || m.busquedaLogs == "" {
+		return m
+	}
+
+	indices := coincidenciasLogs(servidor.ObtenerLogs(), m.busquedaLogs)
+	if len(indices) == 0 {
+		m.mensaje = IconWarning(T("logs.sin_coincidencias", m.busquedaLogs))
+		return m
+	}
+
+	destino := indices[0]
+	if adelante {
+		for _, i := range indices {
+			if i >= desde {
+				destino = i
+				break
+			}
+		}
+	} else {
+		destino = indices[len(indices)-1]
+		for j := len(indices) - 1; j >= 0; j-- {
+			if indices[j] <= desde {
+				destino = indices[j]
+				break
+			}
+		}
+	}
+
+	m.logsScroll = destino
+	m.mensaje = ""
+	return m
+}
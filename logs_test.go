@@ -0,0 +1,194 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+type entradaFalsa struct {
+	strings.Builder
+}
+
+func (e *entradaFalsa) Close() error { return nil }
+
+func TestCoincidenciasLogs(t *testing.T) {
+	logs := []string{
+		"Syncing theme",
+		"\x1b[31mError\x1b[0m: missing snippet",
+		"[w] compiled in 20ms",
+		"error again",
+	}
+
+	casos := []struct {
+		termino  string
+		esperado []int
+	}{
+		{"", nil},
+		{"error", []int{1, 3}},
+		{"ERROR", []int{1, 3}},
+		{"error: missing", []int{1}},
+		{"31m", nil},
+		{"[w]", []int{2}},
+		{"nada", nil},
+	}
+
+	for _, caso := range casos {
+		if got := coincidenciasLogs(logs, caso.termino); !reflect.DeepEqual(got, caso.esperado) {
+			t.Errorf("coincidenciasLogs(%q) = %v, se esperaba %v", caso.termino, got, caso.esperado)
+		}
+	}
+}
+
+func TestSecuenciaPortapapeles(t *testing.T) {
+	contenido := base64.StdEncoding.EncodeToString([]byte("hola"))
+
+	casos := []struct {
+		nombre, tmux, term string
+		prefijo, sufijo    string
+	}{
+		{"terminal directa", "", "xterm-256color", "\x1b]52;c;" + contenido, "\x07"},
+		{"tmux", "/tmp/tmux-1000/default,123,0", "screen-256color", "\x1bPtmux;\x1b\x1b]52;c;" + contenido, "\x1b\\"},
+		{"tmux con TERM propio", "/tmp/tmux-1000/default,123,0", "tmux-256color", "\x1bPtmux;\x1b\x1b]52;c;", "\x1b\\"},
+		{"screen", "", "screen", "\x1bP\x1b]52;c;", "\x1b\\"},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			t.Setenv("TMUX", caso.tmux)
+			t.Setenv("TERM", caso.term)
+
+			secuencia := secuenciaPortapapeles("hola").String()
+			if !strings.HasPrefix(secuencia, caso.prefijo) || !strings.HasSuffix(secuencia, caso.sufijo) {
+				t.Fatalf("secuenciaPortapapeles() = %q", secuencia)
+			}
+		})
+	}
+}
+
+func TestExportarLogs(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	momento := time.Date(2026, 10, 1, 12, 30, 0, 0, time.Local)
+	servidor := &ServidorActivo{
+		Tienda: Tienda{Nombre: "Mi Tienda", URL: "mi-tienda.myshopify.com", Acompanantes: []Acompanante{{Nombre: "w"}}},
+		Logs: []LineaLog{
+			{Momento: momento, Texto: "\x1b[32mlisto\x1b[0m"},
+			{Momento: momento, Texto: "[w] compilado"},
+		},
+	}
+
+	casos := []struct {
+		formato   string
+		extension string
+		comprobar func(t *testing.T, datos []byte)
+	}{
+		{formatoTexto, ".log", func(t *testing.T, datos []byte) {
+			esperado := "2026-10-01 12:30:00  listo\n2026-10-01 12:30:00  [w] compilado\n"
+			if string(datos) != esperado {
+				t.Fatalf("texto exportado = %q, se esperaba %q", datos, esperado)
+			}
+		}},
+		{formatoJSON, ".json", func(t *testing.T, datos []byte) {
+			var exportados logsExportados
+			if err := json.Unmarshal(datos, &exportados); err != nil {
+				t.Fatal(err)
+			}
+			esperadas := []lineaExportada{
+				{Momento: momento, Texto: "listo"},
+				{Momento: momento, Fuente: "w", Texto: "compilado"},
+			}
+			if exportados.Tienda != "Mi Tienda" || len(exportados.Lineas) != len(esperadas) {
+				t.Fatalf("exportados = %+v", exportados)
+			}
+			for i, linea := range exportados.Lineas {
+				if !linea.Momento.Equal(esperadas[i].Momento) || linea.Fuente != esperadas[i].Fuente || linea.Texto != esperadas[i].Texto {
+					t.Errorf("línea %d = %+v, se esperaba %+v", i, linea, esperadas[i])
+				}
+			}
+		}},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.formato, func(t *testing.T) {
+			ruta, err := exportarLogs(servidor, caso.formato)
+			if err != nil {
+				t.Fatalf("exportarLogs: %v", err)
+			}
+			if !strings.HasSuffix(ruta, caso.extension) || !strings.Contains(ruta, "mi-tienda-") {
+				t.Fatalf("ruta = %s", ruta)
+			}
+			datos, err := os.ReadFile(ruta)
+			if err != nil {
+				t.Fatal(err)
+			}
+			caso.comprobar(t, datos)
+		})
+	}
+}
+
+func TestModoEscrituraLogs(t *testing.T) {
+	entrada := &entradaFalsa{}
+	servidor := &ServidorActivo{Tienda: Tienda{Nombre: "escritura"}, Stdin: entrada, ciclo: CicloVida{Estado: EstadoListo}}
+
+	gestor := ObtenerGestor()
+	gestor.mutex.Lock()
+	gestor.servidores["escritura"] = servidor
+	gestor.mutex.Unlock()
+	t.Cleanup(func() {
+		gestor.mutex.Lock()
+		delete(gestor.servidores, "escritura")
+		gestor.mutex.Unlock()
+	})
+
+	m := Model{vista: VistaLogs, tiendaParaDev: servidor.Tienda, erroresVistos: make(map[string]int)}
+	pulsar := func(tecla tea.KeyMsg) {
+		t.Helper()
+		modelo, _ := m.Update(tecla)
+		m = modelo.(Model)
+	}
+
+	pulsar(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if entrada.Len() != 0 || m.escribiendo {
+		t.Fatalf("fuera del modo escritura no se debe enviar nada (enviado %q)", entrada.String())
+	}
+
+	pulsar(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("i")})
+	if !m.escribiendo {
+		t.Fatal("'i' debería activar el modo escritura")
+	}
+
+	for _, tecla := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("y")},
+		{Type: tea.KeyRunes, Runes: []rune("N")},
+		{Type: tea.KeyRunes, Runes: []rune("q")},
+		{Type: tea.KeyRunes, Runes: []rune("/")},
+		{Type: tea.KeyEscape},
+		{Type: tea.KeyCtrlT},
+		{Type: tea.KeyEnter},
+	} {
+		pulsar(tecla)
+		if m.vista != VistaLogs || !m.escribiendo {
+			t.Fatalf("%q no debe salir del modo escritura", tecla.String())
+		}
+	}
+	if entrada.String() != "yNq/\n" {
+		t.Fatalf("enviado = %q, se esperaba %q", entrada.String(), "yNq/\n")
+	}
+
+	pulsar(tea.KeyMsg{Type: tea.KeyCtrlCloseBracket})
+	if m.escribiendo {
+		t.Fatal("ctrl+] debería salir del modo escritura")
+	}
+	if entrada.String() != "yNq/\n" {
+		t.Fatalf("ctrl+] no se debe enviar al proceso (enviado %q)", entrada.String())
+	}
+}
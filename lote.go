@@ -0,0 +1,454 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+const paralelismoLoteDefecto = 4
+
+type ConfigLotes struct {
+	Paralelismo int `json:"paralelismo,omitempty"`
+}
+
+var LotesGlobal = ConfigLotes{Paralelismo: paralelismoLoteDefecto}
+
+func InitLotes(config ConfigLotes) error {
+	if config.Paralelismo < 0 {
+		return errors.New(T("lote.paralelismo_invalido", config.Paralelismo))
+	}
+	if config.Paralelismo == 0 {
+		config.Paralelismo = paralelismoLoteDefecto
+	}
+
+	LotesGlobal = config
+	return nil
+}
+
+type ResumenLote struct {
+	Pendientes int
+	Ejecutando int
+	Correctas  int
+	Fallidas   int
+	Canceladas int
+	Total      int
+}
+
+type Lote struct {
+	Accion string
+
+	mutex    sync.Mutex
+	inicio   time.Time
+	fin      time.Time
+	trabajos []*Trabajo
+	enCurso  bool
+	cancelar context.CancelFunc
+}
+
+func iniciarLote(accion string, tiendas []Tienda) *Lote {
+	lote := &Lote{Accion: accion}
+	indices := make([]int, len(tiendas))
+	for i, tienda := range tiendas {
+		lote.trabajos = append(lote.trabajos, ObtenerTrabajos().Nuevo(accion, tienda))
+		indices[i] = i
+	}
+
+	lote.lanzar(indices)
+	return lote
+}
+
+func (l *Lote) lanzar(indices []int) {
+	ctx, cancelar := context.WithCancel(context.Background())
+
+	l.mutex.Lock()
+	l.inicio = time.Now()
+	l.fin = time.Time{}
+	l.enCurso = true
+	l.cancelar = cancelar
+	trabajos := make([]*Trabajo, len(indices))
+	for i, indice := range indices {
+		trabajos[i] = l.trabajos[indice]
+	}
+	l.mutex.Unlock()
+
+	go l.ejecutar(ctx, cancelar, trabajos)
+}
+
+func (l *Lote) ejecutar(ctx context.Context, cancelar context.CancelFunc, trabajos []*Trabajo) {
+	defer cancelar()
+
+	pendientes := make(chan *Trabajo, len(trabajos))
+	for _, trabajo := range trabajos {
+		pendientes <- trabajo
+	}
+	close(pendientes)
+
+	var trabajadores sync.WaitGroup
+	for range min(LotesGlobal.Paralelismo, len(trabajos)) {
+		trabajadores.Add(1)
+		go func() {
+			defer trabajadores.Done()
+			for trabajo := range pendientes {
+				trabajo.Ejecutar(ctx)
+			}
+		}()
+	}
+	trabajadores.Wait()
+
+	l.mutex.Lock()
+	l.enCurso = false
+	l.fin = time.Now()
+	l.mutex.Unlock()
+	ObtenerTrabajos().notificar()
+}
+
+func (l *Lote) Trabajos() []*Trabajo {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return append([]*Trabajo(nil), l.trabajos...)
+}
+
+func (l *Lote) Tareas() []InfoTrabajo {
+	var tareas []InfoTrabajo
+	for _, trabajo := range l.Trabajos() {
+		tareas = append(tareas, trabajo.Info())
+	}
+	return tareas
+}
+
+func (l *Lote) EnCurso() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	return l.enCurso
+}
+
+func (l *Lote) Duracion() time.Duration {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if l.fin.IsZero() {
+		return time.Since(l.inicio)
+	}
+	return l.fin.Sub(l.inicio)
+}
+
+func (l *Lote) Cancelar() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	if !l.enCurso {
+		return false
+	}
+	l.cancelar()
+	return true
+}
+
+func (l *Lote) ReintentarFallidas() int {
+	if l.EnCurso() {
+		return 0
+	}
+
+	var indices []int
+	for i, tarea := range l.Tareas() {
+		if tarea.Estado == trabajoFallido || tarea.Estado == trabajoCancelado {
+			indices = append(indices, i)
+		}
+	}
+	if len(indices) == 0 {
+		return 0
+	}
+
+	l.mutex.Lock()
+	for _, i := range indices {
+		l.trabajos[i] = ObtenerTrabajos().Nuevo(l.Accion, l.trabajos[i].Tienda)
+	}
+	l.mutex.Unlock()
+
+	l.lanzar(indices)
+	return len(indices)
+}
+
+func (l *Lote) Resumen() ResumenLote {
+	var resumen ResumenLote
+	for _, tarea := range l.Tareas() {
+		resumen.Total++
+		switch tarea.Estado {
+		case trabajoPendiente:
+			resumen.Pendientes++
+		case trabajoEjecutando:
+			resumen.Ejecutando++
+		case trabajoCorrecto:
+			resumen.Correctas++
+		case trabajoFallido:
+			resumen.Fallidas++
+		case trabajoCancelado:
+			resumen.Canceladas++
+		}
+	}
+	return resumen
+}
+
+func (m Model) tiendasDeItem(item list.Item) []Tienda {
+	switch item := item.(type) {
+	case itemTienda:
+		return []Tienda{item.tienda}
+	case itemGrupo:
+		var tiendas []Tienda
+		for _, tienda := range m.tiendas {
+			if tienda.Grupo == item.nombre && !tienda.Favorita {
+				tiendas = append(tiendas, tienda)
+			}
+		}
+		return tiendas
+	}
+	return nil
+}
+
+func (m Model) marcaTienda(tienda Tienda) string {
+	switch {
+	case len(m.marcadas) == 0:
+		return ""
+	case m.marcadas[tienda.Nombre]:
+		return estiloExito.Render("◉ ")
+	}
+	return estiloDesc.Render("○ ")
+}
+
+func (m Model) marcaGrupo(grupo itemGrupo) string {
+	if len(m.marcadas) == 0 {
+		return ""
+	}
+
+	tiendas := m.tiendasDeItem(grupo)
+	switch {
+	case m.todasMarcadas(tiendas):
+		return estiloExito.Render("◉ ")
+	case len(m.tiendasMarcadasDe(tiendas)) > 0:
+		return estiloAviso.Render("◐ ")
+	}
+	return estiloDesc.Render("○ ")
+}
+
+func (m Model) tiendasMarcadasDe(tiendas []Tienda) []Tienda {
+	var marcadas []Tienda
+	for _, tienda := range tiendas {
+		if m.marcadas[tienda.Nombre] {
+			marcadas = append(marcadas, tienda)
+		}
+	}
+	return marcadas
+}
+
+func (m Model) tiendasMarcadas() []Tienda {
+	return m.tiendasMarcadasDe(m.tiendas)
+}
+
+func (m Model) todasMarcadas(tiendas []Tienda) bool {
+	for _, tienda := range tiendas {
+		if !m.marcadas[tienda.Nombre] {
+			return false
+		}
+	}
+	return len(tiendas) > 0
+}
+
+func (m *Model) alternarMarcas(tiendas []Tienda) {
+	desmarcar := m.todasMarcadas(tiendas)
+	for _, tienda := range tiendas {
+		if desmarcar {
+			delete(m.marcadas, tienda.Nombre)
+		} else {
+			m.marcadas[tienda.Nombre] = true
+		}
+	}
+}
+
+func (m Model) abrirLote(accion string) (tea.Model, tea.Cmd) {
+	if m.lote != nil && m.lote.EnCurso() {
+		m.vista = VistaLote
+		m.mensaje = IconWarning(T("lote.en_curso"))
+		return m, nil
+	}
+
+	tiendas := m.tiendasMarcadas()
+	if len(tiendas) == 0 {
+		tiendas = m.tiendasDeItem(m.lista.SelectedItem())
+	}
+	if len(tiendas) == 0 {
+		return m, nil
+	}
+
+	m.lote = iniciarLote(accion, tiendas)
+	m.loteEnCurso = true
+	m.marcadas = make(map[string]bool)
+	m.vista = VistaLote
+	m.loteCursor = 0
+	m.loteScroll = 0
+	m.mensaje = ""
+	return m, nil
+}
+
+func (m Model) resumenLote() string {
+	resumen := m.lote.Resumen()
+	if fallidas := resumen.Fallidas + resumen.Canceladas; fallidas > 0 {
+		return IconWarning(T("lote.terminado_fallos", m.lote.Accion, resumen.Correctas, resumen.Total, fallidas))
+	}
+	return IconSuccess(T("lote.terminado", m.lote.Accion, resumen.Correctas, resumen.Total))
+}
+
+func (m Model) filasLote() int {
+	return max(m.lineasPorPagina()-2, 3)
+}
+
+func (m Model) maxScrollLote() int {
+	if m.lote == nil {
+		return 0
+	}
+	return max(len(m.lote.Tareas())-m.filasLote(), 0)
+}
+
+func (m Model) updateLote(msg tea.Msg) (tea.Model, tea.Cmd) {
+	teclaMsg, ok := msg.(tea.KeyMsg)
+	if !ok || m.lote == nil {
+		return m, nil
+	}
+
+	total := len(m.lote.Trabajos())
+	switch {
+	case key.Matches(teclaMsg, Teclas.Arriba):
+		m.loteCursor = max(m.loteCursor-1, 0)
+
+	case key.Matches(teclaMsg, Teclas.Abajo):
+		m.loteCursor = min(m.loteCursor+1, total-1)
+
+	case key.Matches(teclaMsg, Teclas.Inicio):
+		m.loteCursor = 0
+
+	case key.Matches(teclaMsg, Teclas.Final):
+		m.loteCursor = total - 1
+
+	case key.Matches(teclaMsg, Teclas.Seleccionar):
+		if trabajos := m.lote.Trabajos(); m.loteCursor < len(trabajos) {
+			return m.abrirSalidaTrabajo(trabajos[m.loteCursor])
+		}
+
+	case key.Matches(teclaMsg, Teclas.Detener):
+		if m.lote.Cancelar() {
+			m.mensaje = IconWarning(T("lote.cancelando"))
+		}
+
+	case key.Matches(teclaMsg, Teclas.Reintentar):
+		if reintentos := m.lote.ReintentarFallidas(); reintentos > 0 {
+			m.loteEnCurso = true
+			m.mensaje = IconInfo(T("lote.reintentando", reintentos))
+		}
+	}
+
+	m.loteScroll = min(m.loteScroll, m.loteCursor)
+	m.loteScroll = max(m.loteScroll, m.loteCursor-m.filasLote()+1)
+	return m, nil
+}
+
+func (m Model) vistaLote() string {
+	var b strings.Builder
+
+	tareas := m.lote.Tareas()
+	resumen := m.lote.Resumen()
+
+	icono := Icons.Download
+	if m.lote.Accion == accionPush {
+		icono = Icons.Upload
+	}
+	b.WriteString(estiloTitulo.Render(icono + " " + T("lote.titulo", m.lote.Accion, resumen.Total)))
+	b.WriteString("\n\n")
+
+	progreso := T("lote.resumen", resumen.Correctas+resumen.Fallidas+resumen.Canceladas, resumen.Total,
+		resumen.Ejecutando, LotesGlobal.Paralelismo, formatearLapso(m.lote.Duracion()))
+	b.WriteString(estiloDesc.Render(progreso))
+	b.WriteString("\n\n")
+
+	anchoNombre := len([]rune(T("lote.columna.tienda")))
+	for _, tarea := range tareas {
+		anchoNombre = max(anchoNombre, len([]rune(tarea.Tienda.Nombre)))
+	}
+	anchoNombre = min(anchoNombre, 28)
+	anchoEstado := len([]rune(T("lote.columna.estado")))
+	anchoIcono := 0
+	for _, estado := range estadosTrabajo {
+		anchoEstado = max(anchoEstado, len([]rune(T("trabajos.estado."+estado))))
+		anchoIcono = max(anchoIcono, lipgloss.Width(iconoEstadoTrabajo(estado)))
+	}
+	anchoDetalle := max(m.anchoNovedades()-anchoIcono-anchoNombre-anchoEstado-18, 10)
+
+	b.WriteString(estiloLabel.Render(fmt.Sprintf("  %*s %-*s  %-*s  %-8s  %s", anchoIcono, "", anchoNombre, T("lote.columna.tienda"),
+		anchoEstado, T("lote.columna.estado"), T("lote.columna.duracion"), T("lote.columna.detalle"))))
+	b.WriteString("\n")
+
+	inicio := min(m.loteScroll, len(tareas))
+	fin := min(inicio+m.filasLote(), len(tareas))
+	for i, tarea := range tareas[inicio:fin] {
+		estilo := estiloEstadoTrabajo(tarea.Estado)
+		nombre := ansi.Truncate(tarea.Tienda.Nombre, anchoNombre, "…")
+		duracion := ""
+		if !tarea.Inicio.IsZero() {
+			duracion = formatearLapso(tarea.Duracion())
+		}
+
+		detalle := ""
+		switch tarea.Estado {
+		case trabajoFallido:
+			detalle = estiloError.Render(ansi.Truncate(tarea.Error, anchoDetalle, "…"))
+		case trabajoEjecutando:
+			detalle = estiloDesc.Render(ansi.Truncate(tarea.Salida, anchoDetalle, "…"))
+		}
+
+		cursor := "  "
+		if inicio+i == m.loteCursor {
+			cursor = "> "
+		}
+		icono := iconoEstadoTrabajo(tarea.Estado)
+		icono += strings.Repeat(" ", anchoIcono-lipgloss.Width(icono))
+		b.WriteString(fmt.Sprintf("%s%s %-*s  %s  %-8s  %s\n", cursor, estilo.Render(icono),
+			anchoNombre, nombre, estilo.Render(fmt.Sprintf("%-*s", anchoEstado, T("trabajos.estado."+tarea.Estado))),
+			duracion, detalle))
+	}
+	if len(tareas) > m.filasLote() {
+		b.WriteString(estiloDesc.Render(T("lote.posicion", inicio+1, fin, len(tareas))))
+		b.WriteString("\n")
+	}
+
+	if !m.lote.EnCurso() {
+		b.WriteString("\n")
+		if fallidas := resumen.Fallidas + resumen.Canceladas; fallidas == 0 {
+			b.WriteString(estiloExito.Render(m.resumenLote()))
+		} else {
+			b.WriteString(estiloError.Render(m.resumenLote()))
+		}
+		b.WriteString("\n")
+	} else if m.mensaje != "" {
+		b.WriteString("\n")
+		b.WriteString(estiloAviso.Render(m.mensaje))
+		b.WriteString("\n")
+	}
+
+	if m.lote.EnCurso() {
+		b.WriteString(estiloAyuda.Render(T("lote.ayuda_en_curso", ayudaTecla(Teclas.Arriba), ayudaTecla(Teclas.Abajo),
+			ayudaTecla(Teclas.Seleccionar), ayudaTecla(Teclas.Detener), ayudaTecla(Teclas.Volver))))
+	} else {
+		b.WriteString(estiloAyuda.Render(T("lote.ayuda", ayudaTecla(Teclas.Arriba), ayudaTecla(Teclas.Abajo),
+			ayudaTecla(Teclas.Seleccionar), ayudaTecla(Teclas.Reintentar), ayudaTecla(Teclas.Volver))))
+	}
+	return estiloContenedor.Render(b.String())
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func usarTrabajosFalsos(t *testing.T, modos map[string]string) {
+	t.Helper()
+
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	comandoAnterior, lotesAnterior := comandoTrabajo, LotesGlobal
+	comandoTrabajo = func(ctx context.Context, programa string, argumentos ...string) *exec.Cmd {
+		modo := "salir:0"
+		for i, argumento := range argumentos {
+			if argumento == "--store" && i+1 < len(argumentos) {
+				modo = modos[argumentos[i+1]]
+			}
+		}
+		cmd := exec.CommandContext(ctx, os.Args[0], append([]string{"-test.run=^TestProcesoFalso$", "--"}, argumentos...)...)
+		cmd.Env = append(os.Environ(), variableProcesoFalso+"="+modo)
+		return cmd
+	}
+	t.Cleanup(func() {
+		comandoTrabajo, LotesGlobal = comandoAnterior, lotesAnterior
+	})
+}
+
+func tiendasFalsas(t *testing.T, urls ...string) []Tienda {
+	t.Helper()
+
+	var tiendas []Tienda
+	for _, url := range urls {
+		tiendas = append(tiendas, Tienda{Nombre: url, URL: url, Ruta: t.TempDir()})
+	}
+	return tiendas
+}
+
+func esperarLote(t *testing.T, lote *Lote) {
+	t.Helper()
+
+	limite := time.Now().Add(10 * time.Second)
+	for lote.EnCurso() {
+		if time.Now().After(limite) {
+			t.Fatalf("el lote no terminó: %+v", lote.Resumen())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func esperarTarea(t *testing.T, lote *Lote, indice int, estado string) {
+	t.Helper()
+
+	limite := time.Now().Add(10 * time.Second)
+	for lote.Tareas()[indice].Estado != estado {
+		if time.Now().After(limite) {
+			t.Fatalf("tarea %d = %s, se esperaba %s", indice, lote.Tareas()[indice].Estado, estado)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestLoteResumen(t *testing.T) {
+	casos := []struct {
+		nombre      string
+		paralelismo int
+		modos       map[string]string
+		esperado    ResumenLote
+	}{
+		{
+			nombre:      "todas correctas",
+			paralelismo: 4,
+			modos:       map[string]string{"a": "salir:0", "b": "salir:0", "c": "salir:0"},
+			esperado:    ResumenLote{Correctas: 3, Total: 3},
+		},
+		{
+			nombre:      "fallos mezclados",
+			paralelismo: 2,
+			modos:       map[string]string{"a": "salir:0", "b": "salir:3", "c": "salir:0", "d": "salir:1"},
+			esperado:    ResumenLote{Correctas: 2, Fallidas: 2, Total: 4},
+		},
+		{
+			nombre:      "en serie",
+			paralelismo: 1,
+			modos:       map[string]string{"a": "salir:2", "b": "salir:0"},
+			esperado:    ResumenLote{Correctas: 1, Fallidas: 1, Total: 2},
+		},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			usarTrabajosFalsos(t, caso.modos)
+			LotesGlobal.Paralelismo = caso.paralelismo
+
+			var urls []string
+			for url := range caso.modos {
+				urls = append(urls, url)
+			}
+			lote := iniciarLote(accionPull, tiendasFalsas(t, urls...))
+			esperarLote(t, lote)
+
+			if resumen := lote.Resumen(); resumen != caso.esperado {
+				t.Fatalf("Resumen() = %+v, se esperaba %+v", resumen, caso.esperado)
+			}
+			if lote.Duracion() <= 0 {
+				t.Fatal("un lote terminado debe tener duración")
+			}
+		})
+	}
+}
+
+func TestLoteCancelarAMitad(t *testing.T) {
+	usarTrabajosFalsos(t, map[string]string{"primera": "salir:0", "segunda": "esperar", "tercera": "salir:0"})
+	LotesGlobal.Paralelismo = 1
+
+	lote := iniciarLote(accionPush, tiendasFalsas(t, "primera", "segunda", "tercera"))
+	esperarTarea(t, lote, 0, trabajoCorrecto)
+	esperarTarea(t, lote, 1, trabajoEjecutando)
+
+	if !lote.Cancelar() {
+		t.Fatal("Cancelar() debería detener un lote en curso")
+	}
+	esperarLote(t, lote)
+
+	esperado := ResumenLote{Correctas: 1, Canceladas: 2, Total: 3}
+	if resumen := lote.Resumen(); resumen != esperado {
+		t.Fatalf("Resumen() = %+v, se esperaba %+v", resumen, esperado)
+	}
+	if lote.Cancelar() {
+		t.Fatal("un lote terminado no se puede cancelar")
+	}
+}
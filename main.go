@@ -11,14 +11,73 @@ func main() {
 
 	InitIcons()
 
+	ajustes, errAjustes := cargarAjustes()
+
+	if err := InitIdioma(ajustes.Idioma); err != nil {
+		fmt.Println(T("main.error_idioma", err))
+		os.Exit(1)
+	}
+
+	if errAjustes != nil {
+		fmt.Println(T("main.error_ajustes", errAjustes))
+		os.Exit(1)
+	}
+
+	if err := InitGanchos(ajustes.Ganchos); err != nil {
+		fmt.Println(T("main.error_ganchos", err))
+		os.Exit(1)
+	}
+
+	if err := InitSalud(ajustes.Salud); err != nil {
+		fmt.Println(T("main.error_salud", err))
+		os.Exit(1)
+	}
+
+	if err := InitProxy(ajustes.Proxy); err != nil {
+		fmt.Println(T("main.error_proxy", err))
+		os.Exit(1)
+	}
+
+	if err := InitActualizaciones(ajustes.Actualizaciones); err != nil {
+		fmt.Println(T("main.error_actualizaciones", err))
+		os.Exit(1)
+	}
+
+	if err := InitLotes(ajustes.Lotes); err != nil {
+		fmt.Println(T("main.error_lotes", err))
+		os.Exit(1)
+	}
+
+	if manejado, codigo := ejecutarCLI(os.Args[1:]); manejado {
+		os.Exit(codigo)
+	}
+
+	if err := InitTeclas(ajustes.Atajos); err != nil {
+		fmt.Println(T("main.error_atajos", err))
+		os.Exit(1)
+	}
+
+	if err := InitTema(ajustes.Tema, ajustes.Paletas); err != nil {
+		fmt.Println(T("main.error_tema", err))
+		os.Exit(1)
+	}
+
 	p := tea.NewProgram(
-		modeloInicial(),
+		modeloInicial(ajustes),
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 	)
 
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error al ejecutar Shopify TUI: %v\n", err)
+	final, err := p.Run()
+	if err != nil {
+		fmt.Println(T("main.error_ejecutar", err))
 		os.Exit(1)
 	}
+
+	if modelo, ok := final.(Model); ok && modelo.reiniciar {
+		if err := reiniciarEjecutable(); err != nil {
+			fmt.Println(T("main.error_reiniciar", err))
+			os.Exit(1)
+		}
+	}
 }
@@ -0,0 +1,468 @@
+package main
+
+var mensajesEN = map[string]string{
+	"main.error_idioma":   "Language error: %v",
+	"main.error_ajustes":  "Could not read settings.json: %v",
+	"main.error_atajos":   "Keybinding error: %v",
+	"main.error_tema":     "Theme error: %v",
+	"main.error_ganchos":  "Hook error: %v",
+	"main.error_salud":    "Health check error: %v",
+	"main.error_proxy":    "Proxy configuration error: %v",
+	"main.error_ejecutar": "Error running Shopify TUI: %v",
+
+	"main.error_actualizaciones": "Update check configuration error: %v",
+	"main.error_reiniciar":       "Could not restart sho: %v",
+	"main.error_lotes":           "Batch configuration error: %v",
+
+	"idioma.desconocido": "unknown language '%s' (available: %s)",
+
+	"atajos.accion_desconocida": "unknown action '%s'",
+	"atajos.sin_teclas":         "action '%s' has no keys assigned",
+	"atajos.conflicto":          "'%s' bound to both '%s' and '%s' (%s)",
+	"atajos.conflictos":         "keybinding conflicts:\n  %s",
+
+	"tema.base_circular":    "palette '%s' cannot use itself as its base",
+	"tema.base_desconocida": "palette '%s' uses an unknown base '%s'",
+	"tema.desconocido":      "unknown theme '%s' (available: %s)",
+
+	"ganchos.evento_desconocido": "unknown hook event '%s' (available: %s)",
+	"ganchos.ejecutando":         "hook %s: %s",
+	"ganchos.fallo":              "hook %s failed (%s): %v",
+	"ganchos.prefijo":            "hook",
+	"ganchos.abortado":           "Start cancelled by a hook",
+	"ganchos.cancelado":          "server stopped before starting",
+
+	"acompanante.iniciado":        "started: %s",
+	"acompanante.detenido":        "stopped",
+	"acompanante.fallo":           "exited with code %d",
+	"acompanante.error_iniciar":   "could not start: %v",
+	"acompanante.estado_activo":   "running",
+	"acompanante.estado_detenido": "stopped",
+	"acompanante.estado_fallo":    "failed (code %d)",
+
+	"salud.iniciando":      "waiting for response",
+	"salud.sana":           "healthy (%v)",
+	"salud.lenta":          "slow (%v)",
+	"salud.sin_respuesta":  "not responding: %[2]s (failure %[1]d)",
+	"salud.caida":          "down after %d attempts: %s",
+	"salud.recuperada":     "Server is responding again",
+	"salud.reiniciando":    "Restarting server because it stopped responding",
+	"salud.ultimo_exito":   "last OK %s ago",
+	"salud.valor_invalido": "'%s' cannot be negative (%d)",
+
+	"recursos.subprocesos":    "child processes: %d",
+	"recursos.no_disponibles": "resource usage unavailable",
+
+	"proxy.puerto_invalido": "invalid proxy port: %d",
+	"proxy.error_iniciar":   "could not start the proxy on port %d: %v",
+	"proxy.titulo":          "Local stores",
+	"proxy.desconocida":     "There is no store called '%s'",
+	"proxy.sin_servidor":    "The server for '%s' is stopped",
+	"proxy.sin_respuesta":   "The server for '%s' is not responding: %s",
+	"proxy.detenido":        "stopped",
+	"proxy.colision":        "%s.localhost matches several stores (%s); rename one to use the proxy",
+	"proxy.pie":             "This page reloads automatically; start the server from sho.",
+
+	"tecla.salir":            "quit",
+	"tecla.volver":           "back",
+	"tecla.arriba":           "up",
+	"tecla.abajo":            "down",
+	"tecla.seleccionar":      "select",
+	"tecla.aceptar":          "accept",
+	"tecla.login":            "log in",
+	"tecla.agregar_tienda":   "add store",
+	"tecla.desarrollo":       "local development",
+	"tecla.servidores":       "active servers",
+	"tecla.trabajos":         "job history",
+	"tecla.campo_siguiente":  "next field",
+	"tecla.campo_anterior":   "previous field",
+	"tecla.shopify_pull":     "shopify pull",
+	"tecla.git_clone":        "git clone",
+	"tecla.seleccion_rapida": "quick select",
+	"tecla.eliminar":         "delete",
+	"tecla.confirmar":        "confirm",
+	"tecla.filtrar":          "filter",
+	"tecla.ordenar":          "sort",
+	"tecla.favorita":         "favorite",
+	"tecla.etiquetar":        "tags",
+	"tecla.iniciar":          "start",
+	"tecla.logs":             "logs",
+	"tecla.detener":          "stop",
+	"tecla.pull":             "pull",
+	"tecla.push":             "push",
+	"tecla.editor":           "editor",
+	"tecla.terminal":         "terminal",
+	"tecla.check":            "check theme",
+	"tecla.empaquetar":       "package theme",
+	"tecla.diff":             "compare with Shopify",
+	"tecla.detener_todos":    "stop all",
+	"tecla.reiniciar":        "restart",
+	"tecla.panel":            "log dashboard",
+	"tecla.foco_siguiente":   "next pane",
+	"tecla.foco_anterior":    "previous pane",
+	"tecla.combinar":         "merged timeline",
+	"tecla.menu":             "menu",
+	"tecla.modo_seleccion":   "select",
+	"tecla.escribir":         "type into Shopify CLI",
+	"tecla.soltar_teclado":   "release keyboard",
+	"tecla.descartar":        "dismiss finished server",
+	"tecla.detener_rapido":   "stop",
+	"tecla.inicio":           "top",
+	"tecla.final":            "bottom",
+	"tecla.pagina_arriba":    "page up",
+	"tecla.pagina_abajo":     "page down",
+
+	"tecla.buscar":                 "search logs",
+	"tecla.siguiente_coincidencia": "next match",
+	"tecla.anterior_coincidencia":  "previous match",
+	"tecla.copiar_pagina":          "copy page",
+	"tecla.copiar_todo":            "copy all",
+	"tecla.copiar_coincidencias":   "copy matches",
+	"tecla.exportar_texto":         "export as text",
+	"tecla.exportar_json":          "export as JSON",
+	"tecla.adjuntar_terminal":      "show/hide terminal",
+	"tecla.modo_red":               "toggle LAN mode",
+	"tecla.codigo_qr":              "show/hide QR code",
+	"tecla.actualizar":             "download and install update",
+	"tecla.novedades":              "show release notes",
+	"tecla.marcar":                 "mark store",
+	"tecla.marcar_todas":           "mark/unmark all",
+	"tecla.reintentar":             "retry failed",
+	"tecla.repetir":                "re-run job",
+	"tecla.salida_trabajo":         "view last job output",
+
+	"ayuda.menu":       "[%s] %s/%s %s: select | %s: quit",
+	"ayuda.agregar":    "%s: switch field • %s: continue • %s: cancel",
+	"ayuda.editar":     "%s: switch field • %s: save • %s: cancel",
+	"ayuda.metodo":     "[%s] Shopify Pull | [%s] Git Clone | %s | %s: back",
+	"ayuda.git":        "%s: clone | %s: back",
+	"ayuda.tiendas":    "[%s] %s: start server | %s: filter | %s: sort | %s: favorite | %s: tags | %s: delete | %s: back",
+	"ayuda.logs":       "%s: menu | %s/%s: scroll | %s: search | %s/%s: copy page/all | %s: select | %s: back",
+	"ayuda.servidores": "%s: stop | %s: stop all | %s: restart | %s: dashboard | %s: back",
+	"ayuda.panel":      "%s/%s: switch pane | %s: merge | %s: open logs | %s: back",
+	"ayuda.popup":      "%s/%s navigate | %s run | %s close",
+	"ayuda.volver":     "%s: back",
+
+	"error.generico": "Error: %s",
+
+	"etiqueta.tienda": "Store:",
+	"etiqueta.url":    "URL:",
+
+	"menu.login":                 "Log in",
+	"menu.login.desc":            "Authenticate with Shopify",
+	"menu.agregar":               "Add store",
+	"menu.agregar.desc":          "Register a store and download its theme",
+	"menu.desarrollo":            "Local development",
+	"menu.desarrollo.desc":       "Start a server",
+	"menu.servidores":            "Active servers",
+	"menu.servidores.desc":       "View and manage processes",
+	"menu.trabajos":              "Jobs",
+	"menu.trabajos.desc":         "History of pulls, pushes, clones and checks",
+	"menu.resumen":               "Stores: %d | Servers: %d",
+	"menu.sin_tiendas":           "No stores yet. Add one first.",
+	"menu.sin_tiendas_guardadas": "No saved stores. Add one first.",
+
+	"version.disponible": "New version available:",
+	"version.actual":     "(current: %s)",
+	"version.actualiza":  "Update:",
+
+	"version.horas_invalidas":   "'horas' cannot be negative (%d)",
+	"version.tecla":             "or press %s",
+	"version.descargando":       "Downloading sho %s...",
+	"version.actualizada":       "sho %s installed. Press %s to restart (running servers will stop).",
+	"version.instalada":         "sho %s installed at %s",
+	"version.al_dia":            "You already have the latest version (%s)",
+	"version.error_consulta":    "Could not check the latest version: %v",
+	"version.plataforma":        "no release binaries for %s/%s",
+	"version.error_descarga":    "could not download %s: %s",
+	"version.sin_checksum":      "%s is missing from checksums.txt",
+	"version.checksum_invalido": "checksum mismatch for %s; nothing was installed",
+	"version.error_reemplazar":  "could not replace %s: %v",
+	"version.error_npm":         "npm install failed: %s",
+
+	"version.novedades":       "Press %s to see what's new",
+	"novedades.titulo":        "What's new %s → %s",
+	"novedades.cargando":      "Loading release notes...",
+	"novedades.error":         "Could not load release notes: %s",
+	"novedades.vacio":         "No release notes published for these versions.",
+	"novedades.resumen":       "%d new versions",
+	"novedades.rupturas":      "%d with breaking changes",
+	"novedades.version_mayor": "major version",
+	"novedades.incompletas":   "list truncated: only the most recent releases were fetched",
+	"novedades.posicion":      "lines %d-%d of %d",
+	"novedades.ayuda":         "%s/%s/%s: scroll • %s: update • %s: back",
+
+	"lote.paralelismo_invalido": "invalid parallelism: %d (must be greater than 0)",
+	"lote.titulo":               "Batch %s · %d stores",
+	"lote.resumen":              "%d/%d finished · %d running (max %d in parallel) · %s",
+	"lote.columna.tienda":       "Store",
+	"lote.columna.estado":       "Status",
+	"lote.columna.duracion":     "Duration",
+	"lote.columna.detalle":      "Detail",
+	"lote.posicion":             "stores %d-%d of %d",
+	"lote.terminado":            "%s finished: %d/%d stores succeeded",
+	"lote.terminado_fallos":     "%s finished: %d/%d stores succeeded, %d incomplete",
+	"lote.en_curso":             "A batch is already running",
+	"lote.cancelando":           "Cancelling the batch...",
+	"lote.reintentando":         "Retrying %d stores...",
+	"lote.ayuda_en_curso":       "%s/%s: scroll • %s: view output • %s: cancel • %s: back (keeps running in the background)",
+	"lote.ayuda":                "%s/%s: scroll • %s: view output • %s: retry failed • %s: back",
+
+	"trabajos.estado.pendiente":  "pending",
+	"trabajos.estado.ejecutando": "running",
+	"trabajos.estado.correcto":   "done",
+	"trabajos.estado.fallido":    "failed",
+	"trabajos.estado.cancelado":  "cancelled",
+	"trabajos.ya_en_cola":        "%s of %s is already queued",
+	"trabajos.en_cola":           "%s of %s queued: the store has another job running",
+	"trabajos.lanzado":           "%s of %s running in the background (%s: view output)",
+	"trabajos.terminado":         "%s of %s finished in %s",
+	"trabajos.fallido":           "%s of %s failed: %s",
+	"trabajos.cancelado":         "%s of %s cancelled",
+	"trabajos.progreso":          "%d%% · ~%s left",
+	"trabajos.mas":               "+%d more",
+	"trabajos.ver_salida":        "%s: view output",
+	"trabajos.ninguno":           "No job has run yet",
+	"trabajos.cancelando":        "Cancelling the job...",
+	"trabajos.titulo":            "%s · %s",
+	"trabajos.codigo":            "exit code %d",
+	"trabajos.sin_salida":        "(no output yet)",
+	"trabajos.posicion":          "lines %d-%d of %d",
+	"trabajos.ayuda":             "%s/%s: scroll • %s: follow • %s: cancel • %s: re-run • %s: back",
+	"trabajos.no_repetible":      "%s cannot be re-run from here",
+	"trabajos.tienda_eliminada":  "Store %s no longer exists",
+	"trabajos.lista.titulo":      "Jobs",
+	"trabajos.lista.vacia":       "No job has run yet",
+	"trabajos.lista.posicion":    "jobs %d-%d of %d",
+	"trabajos.lista.ayuda":       "%s/%s: move • %s: view output • %s: cancel • %s: re-run • %s: back",
+	"trabajos.columna.accion":    "Action",
+	"trabajos.columna.tienda":    "Store",
+	"trabajos.columna.estado":    "Status",
+	"trabajos.columna.inicio":    "Started",
+	"trabajos.columna.duracion":  "Duration",
+	"trabajos.columna.detalle":   "Detail",
+
+	"diff.titulo":              "Diff · %s",
+	"diff.leyenda":             "− remote (Shopify, %s) · + local (%s)",
+	"diff.tema_publicado":      "live theme",
+	"diff.tema_desarrollo":     "development theme",
+	"diff.tema":                "theme %s",
+	"diff.descargando":         "Downloading the remote theme to compare…",
+	"diff.en_cola":             "Waiting for another job on this store to finish…",
+	"diff.error":               "Could not compare: %s",
+	"diff.cancelado":           "Comparison cancelled",
+	"diff.sin_cambios":         "Local files match the theme on Shopify",
+	"diff.resumen":             "%d files differ · +%d −%d lines",
+	"diff.binario":             "binary",
+	"diff.binario_sin_detalle": "Binary file: only the fact that it changed is known",
+	"diff.estado.modificado":   "modified",
+	"diff.estado.solo_local":   "local only",
+	"diff.estado.solo_remoto":  "Shopify only",
+	"diff.lista.posicion":      "files %d-%d of %d",
+	"diff.posicion":            "lines %d-%d of %d",
+	"diff.archivo.posicion":    "file %d of %d",
+	"diff.ayuda":               "%s/%s: move • %s: view diff • %s: compare again • %s: back",
+	"diff.archivo.ayuda":       "%s/%s: scroll • %s/%s: next/previous file • %s: back",
+
+	"tiendas.marcadas":   "%d stores marked · %s: pull · %s: push",
+	"ayuda.tiendas_lote": "%s: mark | %s: mark all | %s: pull | %s: push (marked or current)",
+
+	"agregar.titulo":             "Add New Store",
+	"agregar.paso":               "Step 1 of 2: Basic information",
+	"agregar.nombre":             "Store name:",
+	"agregar.nombre.ayuda":       "A name to identify the store",
+	"agregar.nombre.placeholder": "My Main Store",
+	"agregar.url":                "Shopify URL:",
+	"agregar.url.placeholder":    "my-store",
+	"agregar.campos_vacios":      "Please fill in both fields",
+	"agregar.error_directorio":   "Could not create directory: %s",
+
+	"metodo.titulo":       "Download method",
+	"metodo.elige":        "Choose a method",
+	"metodo.shopify":      "Shopify Pull",
+	"metodo.shopify.desc": "Straight from Shopify",
+	"metodo.git":          "Git Clone",
+	"metodo.git.desc":     "From a Git repository",
+
+	"git.titulo":          "Clone from Git",
+	"git.url":             "Repository URL:",
+	"git.url.placeholder": "git@github.com:user/theme.git or https://...",
+	"git.url_vacia":       "Please enter the repository URL",
+	"git.ejemplos":        "Examples:",
+	"git.ejemplo.ssh":     "git@github.com:user/theme.git",
+	"git.ejemplo.https":   "https://github.com/user/theme.git",
+
+	"tienda.metodo.pull": "pull",
+	"tienda.metodo.git":  "git",
+
+	"tiendas.titulo":                 "Select a store",
+	"tiendas.vacio.titulo":           "Run Theme Dev",
+	"tiendas.vacio":                  "No saved stores.",
+	"tiendas.vacio.ayuda":            "Add a store from the main menu first.",
+	"tiendas.agregada":               "Store '%s' added",
+	"tiendas.eliminada":              "Store '%s' deleted",
+	"tiendas.error_eliminar":         "Could not delete: %s",
+	"tiendas.confirmar_eliminar":     "Delete '%s'? %s: confirm | any other key: cancel",
+	"tiendas.sin_coincidencias":      "No store matches '%s'",
+	"tiendas.filtro_ayuda":           "%s: apply | %s: cancel",
+	"tiendas.directorio_inexistente": "Directory does not exist: %s",
+	"tiendas.orden_reciente":         "recent first",
+
+	"editar.titulo":                "Tags for %s",
+	"editar.etiquetas":             "Tags:",
+	"editar.etiquetas.placeholder": "client-x, agency, eu",
+	"editar.etiquetas.ayuda":       "Comma separated. Filter with /#tag",
+	"editar.grupo":                 "Group:",
+	"editar.grupo.placeholder":     "Clients",
+	"editar.grupo.ayuda":           "Leave empty to keep it out of groups",
+	"editar.guardado":              "Store '%s' updated",
+
+	"modo.iniciar":         "Start",
+	"modo.iniciar.desc":    "Run theme dev",
+	"modo.logs":            "View logs",
+	"modo.logs.desc":       "Live logs",
+	"modo.logs.desc_final": "Logs from the last run",
+	"modo.detener":         "Stop",
+	"modo.detener.desc":    "Stop the server",
+	"modo.pull":            "Pull",
+	"modo.pull.desc":       "Download theme changes",
+	"modo.push":            "Push",
+	"modo.push.desc":       "Upload theme changes",
+	"modo.check":           "Check",
+	"modo.check.desc":      "Lint the theme with theme check",
+	"modo.empaquetar":      "Package",
+	"modo.empaquetar.desc": "Build a .zip of the theme",
+	"modo.diff":            "Diff",
+	"modo.diff.desc":       "Compare local files with the theme on Shopify",
+	"modo.editor":          "Editor",
+	"modo.editor.desc":     "Open in VS Code",
+	"modo.terminal":        "Terminal",
+	"modo.terminal.desc":   "Open a terminal here",
+	"modo.red":             "LAN mode",
+	"modo.red.desc":        "Server only on this machine",
+	"modo.red.desc_activo": "Server visible on the local network",
+	"modo.servidor_activo": "(server running)",
+
+	"popup.titulo":        "Actions",
+	"popup.detenido":      "Stopped",
+	"popup.pull.desc":     "Download changes",
+	"popup.push.desc":     "Upload changes",
+	"popup.editor.desc":   "Open VS Code",
+	"popup.terminal.desc": "Open terminal",
+
+	"popup.copiar_pagina":        "Copy visible page",
+	"popup.copiar_todo":          "Copy all logs",
+	"popup.copiar_coincidencias": "Copy matches",
+	"popup.exportar_texto":       "Export as text",
+	"popup.exportar_json":        "Export as JSON",
+
+	"estado.iniciando":  "starting",
+	"estado.listo":      "ready",
+	"estado.deteniendo": "stopping",
+	"estado.detenido":   "stopped",
+	"estado.caido":      "crashed",
+
+	"servidor.activo":                    "Server running",
+	"servidor.detenido":                  "Server stopped",
+	"servidor.caido":                     "Server crashed (exit code %d)",
+	"servidor.iniciado":                  "Server started at %s",
+	"servidor.error_stdin_no_disponible": "stdin not available",
+	"servidor.error_ya_activo":           "a server is already running for '%s'",
+	"servidor.error_stdin":               "could not capture stdin: %v",
+	"servidor.error_stdout":              "could not capture stdout: %v",
+	"servidor.error_stderr":              "could not capture stderr: %v",
+	"servidor.error_iniciar":             "could not start server: %v",
+	"servidor.error_no_existe":           "no server for '%s'",
+	"servidor.error_ya_detenido":         "the server for '%s' is already stopped",
+	"servidor.error_detener":             "could not stop server: %v",
+	"servidor.codigo_salida":             "exit code %d at %s",
+	"servidor.reiniciado":                "Server restarted",
+
+	"servidor.error_terminal": "pseudo-terminals are not available on this system",
+
+	"actividad.ultima":       "last %s %s",
+	"actividad.pull":         "pull",
+	"actividad.push":         "push",
+	"actividad.iniciar":      "dev",
+	"actividad.shopify_pull": "pull",
+	"actividad.git_clone":    "clone",
+	"actividad.check":        "check",
+	"actividad.package":      "package",
+	"actividad.diff":         "diff",
+
+	"tiempo.ahora":   "just now",
+	"tiempo.minutos": "%dm ago",
+	"tiempo.horas":   "%dh ago",
+	"tiempo.dias":    "%dd ago",
+
+	"servidores.titulo":          "Active Servers",
+	"servidores.vacio":           "No servers running.",
+	"servidores.vacio.ayuda":     "Start one from '%s Start server'",
+	"servidores.detalle":         "📍 Port: %d | ⏱️ Uptime: %s",
+	"servidores.detenido":        "Server for '%s' stopped",
+	"servidores.todos_detenidos": "All servers stopped",
+	"servidores.reiniciando":     "Restarting '%s'...",
+	"servidores.reiniciado":      "Server for '%s' restarted",
+	"servidores.caido":           "Server for '%s' stopped with an error",
+
+	"panel.titulo":    "Log dashboard",
+	"panel.combinado": "merged timeline",
+	"panel.errores":   "%d new errors",
+
+	"logs.esperando":        "Waiting for server logs...",
+	"logs.sin_servidor":     "No server running",
+	"logs.posicion":         "Lines %d-%d of %d (%d%%)",
+	"logs.seleccion_on":     "Selection mode ON - Use Ctrl+Shift+C to copy, '%s' to leave",
+	"logs.seleccion_activa": "SELECTION MODE ON - Select text with the mouse",
+	"logs.interactivo":      "INTERACTIVE MODE - Every key is sent to Shopify CLI ('%s' to release)",
+	"logs.descartar":        "'%s' to dismiss it",
+	"logs.descartado":       "Server dismissed",
+	"logs.escribir":         "Press '%s' to type into Shopify CLI",
+	"logs.error_input":      "Could not send input",
+
+	"logs.busqueda":          "Search '%s': %d matches (%s/%s to navigate)",
+	"logs.sin_busqueda":      "No active search (use '%s')",
+	"logs.sin_coincidencias": "No matches for '%s'",
+	"logs.nada_que_copiar":   "No lines to copy",
+	"logs.copiadas":          "%d lines copied to the clipboard",
+	"logs.error_copiar":      "Could not copy: %v",
+	"logs.exportados":        "Logs exported to %s",
+	"logs.error_exportar":    "Could not export logs: %v",
+
+	"cmd.login_ok":           "Logged in",
+	"cmd.tema_descargado":    "Theme downloaded",
+	"cmd.dev_cerrado":        "Development server closed",
+	"cmd.repo_clonado":       "Repository cloned",
+	"cmd.tienda_configurada": "Store set up",
+	"cmd.editor_abierto":     "Editor opened",
+	"cmd.terminal_cerrada":   "Terminal closed",
+	"cmd.terminal_abierta":   "Terminal opened in: %s",
+	"cmd.terminal_salir":     "Type 'exit' or press Ctrl+D to return",
+
+	"terminal.en_ejecucion": "running",
+	"terminal.finalizada":   "exited (code %d)",
+	"terminal.mostrar":      "%s: show",
+	"terminal.ocultar":      "%s: hide",
+	"terminal.cerrar":       "%s: close · %s: hide",
+	"terminal.ocupada":      "A command is already running in the terminal: %s",
+
+	"red.sin_ip":      "could not find this machine's local network IP",
+	"red.activado":    "LAN mode enabled for %s.",
+	"red.desactivado": "LAN mode disabled for %s.",
+	"red.reiniciando": "Restarting the server...",
+	"red.escanear":    "scan · %s: hide",
+	"red.mostrar_qr":  "%s: show QR",
+
+	"cli.uso":                 "Usage: sho [command] [options]\n\nCommands:\n  (none)       open the interface\n  list         list stores\n  pull         run shopify theme pull on the filtered stores\n  push         run shopify theme push on the filtered stores\n  self-update  install the latest version of sho\n\nOptions:\n  --tag <tag>      filter by tag (repeatable)\n  --group <group>  filter by group\n  --favorites      favorites only\n  --all            pull/push every store\n  --version <v>    version to install with self-update",
+	"cli.comando_desconocido": "Unknown command: %s",
+	"cli.flag.tag":            "filter by tag (repeatable)",
+	"cli.flag.grupo":          "filter by group",
+	"cli.flag.favoritas":      "favorite stores only",
+	"cli.flag.todas":          "apply to every store",
+	"cli.flag.version":        "version to install (defaults to the latest)",
+	"cli.sin_filtro":          "Pass --tag, --group, --favorites or --all to run %s",
+	"cli.sin_tiendas":         "No store matches the filter",
+	"cli.ejecutando":          "%s: %s (%s)",
+	"cli.resumen_ok":          "%d stores completed",
+	"cli.resumen_fallos":      "%d of %d stores completed. Failed: %s",
+}
@@ -0,0 +1,468 @@
+package main
+
+var mensajesES = map[string]string{
+	"main.error_idioma":   "Error en el idioma: %v",
+	"main.error_ajustes":  "Error al leer settings.json: %v",
+	"main.error_atajos":   "Error en los atajos de teclado: %v",
+	"main.error_tema":     "Error en el tema: %v",
+	"main.error_ganchos":  "Error en los ganchos: %v",
+	"main.error_salud":    "Error en la configuración de salud: %v",
+	"main.error_proxy":    "Error en la configuración del proxy: %v",
+	"main.error_ejecutar": "Error al ejecutar Shopify TUI: %v",
+
+	"main.error_actualizaciones": "Error en la configuración de actualizaciones: %v",
+	"main.error_reiniciar":       "No se pudo reiniciar sho: %v",
+	"main.error_lotes":           "Error en la configuración de lotes: %v",
+
+	"idioma.desconocido": "idioma desconocido '%s' (disponibles: %s)",
+
+	"atajos.accion_desconocida": "acción desconocida '%s'",
+	"atajos.sin_teclas":         "la acción '%s' no tiene teclas asignadas",
+	"atajos.conflicto":          "'%s' asignada a '%s' y '%s' (%s)",
+	"atajos.conflictos":         "conflictos de atajos:\n  %s",
+
+	"tema.base_circular":    "la paleta '%s' no puede usarse como su propia base",
+	"tema.base_desconocida": "la paleta '%s' usa una base desconocida '%s'",
+	"tema.desconocido":      "tema desconocido '%s' (disponibles: %s)",
+
+	"ganchos.evento_desconocido": "evento de gancho desconocido '%s' (disponibles: %s)",
+	"ganchos.ejecutando":         "gancho %s: %s",
+	"ganchos.fallo":              "el gancho %s falló (%s): %v",
+	"ganchos.prefijo":            "gancho",
+	"ganchos.abortado":           "Inicio cancelado por un gancho",
+	"ganchos.cancelado":          "servidor detenido antes de iniciar",
+
+	"acompanante.iniciado":        "iniciado: %s",
+	"acompanante.detenido":        "detenido",
+	"acompanante.fallo":           "terminó con código %d",
+	"acompanante.error_iniciar":   "no se pudo iniciar: %v",
+	"acompanante.estado_activo":   "activo",
+	"acompanante.estado_detenido": "detenido",
+	"acompanante.estado_fallo":    "falló (código %d)",
+
+	"salud.iniciando":      "esperando respuesta",
+	"salud.sana":           "responde (%v)",
+	"salud.lenta":          "lenta (%v)",
+	"salud.sin_respuesta":  "sin respuesta: %[2]s (fallo %[1]d)",
+	"salud.caida":          "no responde tras %d intentos: %s",
+	"salud.recuperada":     "El servidor vuelve a responder",
+	"salud.reiniciando":    "Reiniciando servidor por falta de respuesta",
+	"salud.ultimo_exito":   "último OK hace %s",
+	"salud.valor_invalido": "'%s' no puede ser negativo (%d)",
+
+	"recursos.subprocesos":    "subprocesos: %d",
+	"recursos.no_disponibles": "recursos no disponibles",
+
+	"proxy.puerto_invalido": "puerto del proxy inválido: %d",
+	"proxy.error_iniciar":   "no se pudo iniciar el proxy en el puerto %d: %v",
+	"proxy.titulo":          "Tiendas locales",
+	"proxy.desconocida":     "No hay ninguna tienda llamada '%s'",
+	"proxy.sin_servidor":    "El servidor de '%s' está detenido",
+	"proxy.sin_respuesta":   "El servidor de '%s' no responde: %s",
+	"proxy.detenido":        "detenido",
+	"proxy.colision":        "%s.localhost apunta a varias tiendas (%s); renombra una para usar el proxy",
+	"proxy.pie":             "Esta página se recarga sola; inicia el servidor desde sho.",
+
+	"tecla.salir":            "salir",
+	"tecla.volver":           "volver",
+	"tecla.arriba":           "arriba",
+	"tecla.abajo":            "abajo",
+	"tecla.seleccionar":      "seleccionar",
+	"tecla.aceptar":          "aceptar",
+	"tecla.login":            "iniciar sesión",
+	"tecla.agregar_tienda":   "agregar tienda",
+	"tecla.desarrollo":       "desarrollo local",
+	"tecla.servidores":       "servidores activos",
+	"tecla.trabajos":         "historial de trabajos",
+	"tecla.campo_siguiente":  "siguiente campo",
+	"tecla.campo_anterior":   "campo anterior",
+	"tecla.shopify_pull":     "shopify pull",
+	"tecla.git_clone":        "git clone",
+	"tecla.seleccion_rapida": "selección rápida",
+	"tecla.eliminar":         "eliminar",
+	"tecla.confirmar":        "confirmar",
+	"tecla.filtrar":          "filtrar",
+	"tecla.ordenar":          "ordenar",
+	"tecla.favorita":         "favorita",
+	"tecla.etiquetar":        "etiquetas",
+	"tecla.iniciar":          "iniciar",
+	"tecla.logs":             "logs",
+	"tecla.detener":          "detener",
+	"tecla.pull":             "pull",
+	"tecla.push":             "push",
+	"tecla.editor":           "editor",
+	"tecla.terminal":         "terminal",
+	"tecla.check":            "revisar tema",
+	"tecla.empaquetar":       "empaquetar tema",
+	"tecla.diff":             "comparar con Shopify",
+	"tecla.detener_todos":    "detener todos",
+	"tecla.reiniciar":        "reiniciar",
+	"tecla.panel":            "panel de logs",
+	"tecla.foco_siguiente":   "panel siguiente",
+	"tecla.foco_anterior":    "panel anterior",
+	"tecla.combinar":         "línea de tiempo combinada",
+	"tecla.menu":             "menú",
+	"tecla.modo_seleccion":   "seleccionar",
+	"tecla.escribir":         "escribir en Shopify CLI",
+	"tecla.soltar_teclado":   "soltar el teclado",
+	"tecla.descartar":        "descartar servidor terminado",
+	"tecla.detener_rapido":   "detener",
+	"tecla.inicio":           "inicio",
+	"tecla.final":            "final",
+	"tecla.pagina_arriba":    "página arriba",
+	"tecla.pagina_abajo":     "página abajo",
+
+	"tecla.buscar":                 "buscar en logs",
+	"tecla.siguiente_coincidencia": "siguiente coincidencia",
+	"tecla.anterior_coincidencia":  "coincidencia anterior",
+	"tecla.copiar_pagina":          "copiar página",
+	"tecla.copiar_todo":            "copiar todo",
+	"tecla.copiar_coincidencias":   "copiar coincidencias",
+	"tecla.exportar_texto":         "exportar a texto",
+	"tecla.exportar_json":          "exportar a JSON",
+	"tecla.adjuntar_terminal":      "mostrar/ocultar terminal",
+	"tecla.modo_red":               "activar/desactivar modo red",
+	"tecla.codigo_qr":              "mostrar/ocultar código QR",
+	"tecla.actualizar":             "buscar e instalar actualización",
+	"tecla.novedades":              "ver novedades de la versión",
+	"tecla.marcar":                 "marcar tienda",
+	"tecla.marcar_todas":           "marcar/desmarcar todas",
+	"tecla.reintentar":             "reintentar fallidas",
+	"tecla.repetir":                "repetir trabajo",
+	"tecla.salida_trabajo":         "ver salida del último trabajo",
+
+	"ayuda.menu":       "[%s] %s/%s %s: seleccionar | %s: salir",
+	"ayuda.agregar":    "%s: cambiar campo • %s: continuar • %s: cancelar",
+	"ayuda.editar":     "%s: cambiar campo • %s: guardar • %s: cancelar",
+	"ayuda.metodo":     "[%s] Shopify Pull | [%s] Git Clone | %s | %s: volver",
+	"ayuda.git":        "%s: clonar | %s: volver",
+	"ayuda.tiendas":    "[%s] %s: iniciar servidor | %s: filtrar | %s: ordenar | %s: favorita | %s: etiquetas | %s: eliminar | %s: volver",
+	"ayuda.logs":       "%s: menú | %s/%s: scroll | %s: buscar | %s/%s: copiar página/todo | %s: seleccionar | %s: volver",
+	"ayuda.servidores": "%s: detener | %s: detener todos | %s: reiniciar | %s: panel | %s: volver",
+	"ayuda.panel":      "%s/%s: cambiar panel | %s: combinar | %s: ver logs | %s: volver",
+	"ayuda.popup":      "%s/%s navegar | %s ejecutar | %s cerrar",
+	"ayuda.volver":     "%s: volver",
+
+	"error.generico": "Error: %s",
+
+	"etiqueta.tienda": "Tienda:",
+	"etiqueta.url":    "URL:",
+
+	"menu.login":                 "Iniciar sesión",
+	"menu.login.desc":            "Autenticarte en Shopify",
+	"menu.agregar":               "Agregar tienda",
+	"menu.agregar.desc":          "Registrar tienda y descargar tema",
+	"menu.desarrollo":            "Desarrollo local",
+	"menu.desarrollo.desc":       "Iniciar servidor",
+	"menu.servidores":            "Servidores activos",
+	"menu.servidores.desc":       "Ver y administrar procesos",
+	"menu.trabajos":              "Trabajos",
+	"menu.trabajos.desc":         "Historial de pull, push, clonados y revisiones",
+	"menu.resumen":               "Tiendas: %d | Servidores: %d",
+	"menu.sin_tiendas":           "No hay tiendas. Agrega una primero.",
+	"menu.sin_tiendas_guardadas": "No hay tiendas guardadas. Agrega una primero.",
+
+	"version.disponible": "Nueva versión disponible:",
+	"version.actual":     "(actual: %s)",
+	"version.actualiza":  "Actualiza:",
+
+	"version.horas_invalidas":   "'horas' no puede ser negativo (%d)",
+	"version.tecla":             "o pulsa %s",
+	"version.descargando":       "Descargando sho %s...",
+	"version.actualizada":       "sho %s instalado. Pulsa %s para reiniciar (se detendrán los servidores).",
+	"version.instalada":         "sho %s instalado en %s",
+	"version.al_dia":            "Ya tienes la última versión (%s)",
+	"version.error_consulta":    "No se pudo consultar la última versión: %v",
+	"version.plataforma":        "no hay binarios publicados para %s/%s",
+	"version.error_descarga":    "no se pudo descargar %s: %s",
+	"version.sin_checksum":      "%s no aparece en checksums.txt",
+	"version.checksum_invalido": "el checksum de %s no coincide; no se instaló nada",
+	"version.error_reemplazar":  "no se pudo reemplazar %s: %v",
+	"version.error_npm":         "npm install falló: %s",
+
+	"version.novedades":       "Pulsa %s para ver las novedades",
+	"novedades.titulo":        "Novedades %s → %s",
+	"novedades.cargando":      "Cargando notas de versión...",
+	"novedades.error":         "No se pudieron cargar las notas de versión: %s",
+	"novedades.vacio":         "No hay notas publicadas para estas versiones.",
+	"novedades.resumen":       "%d versiones nuevas",
+	"novedades.rupturas":      "%d con cambios incompatibles",
+	"novedades.version_mayor": "versión mayor",
+	"novedades.incompletas":   "lista incompleta: solo se consultaron las publicaciones más recientes",
+	"novedades.posicion":      "líneas %d-%d de %d",
+	"novedades.ayuda":         "%s/%s/%s: desplazar • %s: actualizar • %s: volver",
+
+	"lote.paralelismo_invalido": "paralelismo inválido: %d (debe ser mayor que 0)",
+	"lote.titulo":               "%s en lote · %d tiendas",
+	"lote.resumen":              "%d/%d terminadas · %d en curso (máx. %d en paralelo) · %s",
+	"lote.columna.tienda":       "Tienda",
+	"lote.columna.estado":       "Estado",
+	"lote.columna.duracion":     "Duración",
+	"lote.columna.detalle":      "Detalle",
+	"lote.posicion":             "tiendas %d-%d de %d",
+	"lote.terminado":            "%s terminado: %d/%d tiendas correctas",
+	"lote.terminado_fallos":     "%s terminado: %d/%d tiendas correctas, %d sin completar",
+	"lote.en_curso":             "Ya hay un lote en curso",
+	"lote.cancelando":           "Cancelando el lote...",
+	"lote.reintentando":         "Reintentando %d tiendas...",
+	"lote.ayuda_en_curso":       "%s/%s: desplazar • %s: ver salida • %s: cancelar • %s: volver (sigue en segundo plano)",
+	"lote.ayuda":                "%s/%s: desplazar • %s: ver salida • %s: reintentar fallidas • %s: volver",
+
+	"trabajos.estado.pendiente":  "pendiente",
+	"trabajos.estado.ejecutando": "ejecutando",
+	"trabajos.estado.correcto":   "correcto",
+	"trabajos.estado.fallido":    "fallido",
+	"trabajos.estado.cancelado":  "cancelado",
+	"trabajos.ya_en_cola":        "%s de %s ya está en cola",
+	"trabajos.en_cola":           "%s de %s en cola: la tienda tiene otro trabajo en curso",
+	"trabajos.lanzado":           "%s de %s en segundo plano (%s: ver salida)",
+	"trabajos.terminado":         "%s de %s terminado en %s",
+	"trabajos.fallido":           "%s de %s falló: %s",
+	"trabajos.cancelado":         "%s de %s cancelado",
+	"trabajos.progreso":          "%d%% · ~%s restantes",
+	"trabajos.mas":               "+%d más",
+	"trabajos.ver_salida":        "%s: ver salida",
+	"trabajos.ninguno":           "No se ha ejecutado ningún trabajo todavía",
+	"trabajos.cancelando":        "Cancelando el trabajo...",
+	"trabajos.titulo":            "%s · %s",
+	"trabajos.codigo":            "código %d",
+	"trabajos.sin_salida":        "(sin salida todavía)",
+	"trabajos.posicion":          "líneas %d-%d de %d",
+	"trabajos.ayuda":             "%s/%s: desplazar • %s: seguir • %s: cancelar • %s: repetir • %s: volver",
+	"trabajos.no_repetible":      "%s no se puede repetir desde aquí",
+	"trabajos.tienda_eliminada":  "La tienda %s ya no existe",
+	"trabajos.lista.titulo":      "Trabajos",
+	"trabajos.lista.vacia":       "Todavía no se ha ejecutado ningún trabajo",
+	"trabajos.lista.posicion":    "trabajos %d-%d de %d",
+	"trabajos.lista.ayuda":       "%s/%s: mover • %s: ver salida • %s: cancelar • %s: repetir • %s: volver",
+	"trabajos.columna.accion":    "Acción",
+	"trabajos.columna.tienda":    "Tienda",
+	"trabajos.columna.estado":    "Estado",
+	"trabajos.columna.inicio":    "Inicio",
+	"trabajos.columna.duracion":  "Duración",
+	"trabajos.columna.detalle":   "Detalle",
+
+	"diff.titulo":              "Diferencias · %s",
+	"diff.leyenda":             "− remoto (Shopify, %s) · + local (%s)",
+	"diff.tema_publicado":      "tema publicado",
+	"diff.tema_desarrollo":     "tema de desarrollo",
+	"diff.tema":                "tema %s",
+	"diff.descargando":         "Descargando el tema remoto para comparar…",
+	"diff.en_cola":             "Esperando a que termine otro trabajo de la tienda…",
+	"diff.error":               "No se pudo comparar: %s",
+	"diff.cancelado":           "Comparación cancelada",
+	"diff.sin_cambios":         "Los archivos locales coinciden con el tema en Shopify",
+	"diff.resumen":             "%d archivos distintos · +%d −%d líneas",
+	"diff.binario":             "binario",
+	"diff.binario_sin_detalle": "Es un archivo binario: solo se sabe que cambió",
+	"diff.estado.modificado":   "modificado",
+	"diff.estado.solo_local":   "solo en local",
+	"diff.estado.solo_remoto":  "solo en Shopify",
+	"diff.lista.posicion":      "archivos %d-%d de %d",
+	"diff.posicion":            "líneas %d-%d de %d",
+	"diff.archivo.posicion":    "archivo %d de %d",
+	"diff.ayuda":               "%s/%s: mover • %s: ver diff • %s: volver a comparar • %s: volver",
+	"diff.archivo.ayuda":       "%s/%s: desplazar • %s/%s: archivo siguiente/anterior • %s: volver",
+
+	"tiendas.marcadas":   "%d tiendas marcadas · %s: pull · %s: push",
+	"ayuda.tiendas_lote": "%s: marcar | %s: marcar todas | %s: pull | %s: push (marcadas o actual)",
+
+	"agregar.titulo":             "Agregar Nueva Tienda",
+	"agregar.paso":               "Paso 1 de 2: Información básica",
+	"agregar.nombre":             "Nombre de la tienda:",
+	"agregar.nombre.ayuda":       "Un nombre para identificar la tienda",
+	"agregar.nombre.placeholder": "Mi Tienda Principal",
+	"agregar.url":                "URL de Shopify:",
+	"agregar.url.placeholder":    "mi-tienda",
+	"agregar.campos_vacios":      "Por favor completa ambos campos",
+	"agregar.error_directorio":   "Error al crear directorio: %s",
+
+	"metodo.titulo":       "Método de descarga",
+	"metodo.elige":        "Elige método",
+	"metodo.shopify":      "Shopify Pull",
+	"metodo.shopify.desc": "Desde Shopify directo",
+	"metodo.git":          "Git Clone",
+	"metodo.git.desc":     "Desde repositorio Git",
+
+	"git.titulo":          "Clonar desde Git",
+	"git.url":             "URL del repositorio:",
+	"git.url.placeholder": "git@github.com:usuario/tema.git o https://...",
+	"git.url_vacia":       "Por favor ingresa la URL del repositorio",
+	"git.ejemplos":        "Ejemplos:",
+	"git.ejemplo.ssh":     "git@github.com:usuario/tema.git",
+	"git.ejemplo.https":   "https://github.com/usuario/tema.git",
+
+	"tienda.metodo.pull": "pull",
+	"tienda.metodo.git":  "git",
+
+	"tiendas.titulo":                 "Selecciona una tienda",
+	"tiendas.vacio.titulo":           "Ejecutar Theme Dev",
+	"tiendas.vacio":                  "No hay tiendas guardadas.",
+	"tiendas.vacio.ayuda":            "Primero agrega una tienda desde el menú principal.",
+	"tiendas.agregada":               "Tienda '%s' agregada correctamente",
+	"tiendas.eliminada":              "Tienda '%s' eliminada",
+	"tiendas.error_eliminar":         "Error al eliminar: %s",
+	"tiendas.confirmar_eliminar":     "¿Eliminar '%s'? %s: confirmar | otra tecla: cancelar",
+	"tiendas.sin_coincidencias":      "Ninguna tienda coincide con '%s'",
+	"tiendas.filtro_ayuda":           "%s: aplicar | %s: cancelar",
+	"tiendas.directorio_inexistente": "El directorio no existe: %s",
+	"tiendas.orden_reciente":         "recientes primero",
+
+	"editar.titulo":                "Etiquetas de %s",
+	"editar.etiquetas":             "Etiquetas:",
+	"editar.etiquetas.placeholder": "cliente-x, agencia, eu",
+	"editar.etiquetas.ayuda":       "Separadas por comas. Filtra con /#etiqueta",
+	"editar.grupo":                 "Grupo:",
+	"editar.grupo.placeholder":     "Clientes",
+	"editar.grupo.ayuda":           "Vacío para dejarla fuera de los grupos",
+	"editar.guardado":              "Tienda '%s' actualizada",
+
+	"modo.iniciar":         "Iniciar",
+	"modo.iniciar.desc":    "Ejecutar theme dev",
+	"modo.logs":            "Ver logs",
+	"modo.logs.desc":       "Logs en tiempo real",
+	"modo.logs.desc_final": "Logs de la última ejecución",
+	"modo.detener":         "Detener",
+	"modo.detener.desc":    "Parar servidor",
+	"modo.pull":            "Pull",
+	"modo.pull.desc":       "Bajar cambios del tema",
+	"modo.push":            "Push",
+	"modo.push.desc":       "Subir cambios al tema",
+	"modo.check":           "Check",
+	"modo.check.desc":      "Revisar el tema con theme check",
+	"modo.empaquetar":      "Empaquetar",
+	"modo.empaquetar.desc": "Generar un .zip del tema",
+	"modo.diff":            "Diferencias",
+	"modo.diff.desc":       "Comparar los archivos locales con el tema en Shopify",
+	"modo.editor":          "Editor",
+	"modo.editor.desc":     "Abrir en VS Code",
+	"modo.terminal":        "Terminal",
+	"modo.terminal.desc":   "Abrir terminal aquí",
+	"modo.red":             "Modo red",
+	"modo.red.desc":        "Servidor solo en este equipo",
+	"modo.red.desc_activo": "Servidor visible en la red local",
+	"modo.servidor_activo": "(servidor activo)",
+
+	"popup.titulo":        "Acciones",
+	"popup.detenido":      "Detenido",
+	"popup.pull.desc":     "Bajar cambios",
+	"popup.push.desc":     "Subir cambios",
+	"popup.editor.desc":   "Abrir VS Code",
+	"popup.terminal.desc": "Abrir terminal",
+
+	"popup.copiar_pagina":        "Copiar página visible",
+	"popup.copiar_todo":          "Copiar todos los logs",
+	"popup.copiar_coincidencias": "Copiar coincidencias",
+	"popup.exportar_texto":       "Exportar a texto",
+	"popup.exportar_json":        "Exportar a JSON",
+
+	"estado.iniciando":  "iniciando",
+	"estado.listo":      "listo",
+	"estado.deteniendo": "deteniendo",
+	"estado.detenido":   "detenido",
+	"estado.caido":      "caído",
+
+	"servidor.activo":                    "Servidor activo",
+	"servidor.detenido":                  "Servidor detenido",
+	"servidor.caido":                     "Servidor caído (código %d)",
+	"servidor.iniciado":                  "Servidor iniciado en %s",
+	"servidor.error_stdin_no_disponible": "stdin no disponible",
+	"servidor.error_ya_activo":           "ya hay un servidor activo para '%s'",
+	"servidor.error_stdin":               "error al capturar stdin: %v",
+	"servidor.error_stdout":              "error al capturar stdout: %v",
+	"servidor.error_stderr":              "error al capturar stderr: %v",
+	"servidor.error_iniciar":             "error al iniciar servidor: %v",
+	"servidor.error_no_existe":           "no hay servidor para '%s'",
+	"servidor.error_ya_detenido":         "el servidor de '%s' ya está detenido",
+	"servidor.error_detener":             "error al detener servidor: %v",
+	"servidor.reiniciado":                "Servidor reiniciado",
+	"servidor.codigo_salida":             "código de salida %d a las %s",
+
+	"servidor.error_terminal": "la terminal virtual no está disponible en este sistema",
+
+	"actividad.ultima":       "último %s %s",
+	"actividad.pull":         "pull",
+	"actividad.push":         "push",
+	"actividad.iniciar":      "dev",
+	"actividad.shopify_pull": "pull",
+	"actividad.git_clone":    "clone",
+	"actividad.check":        "check",
+	"actividad.package":      "package",
+	"actividad.diff":         "diff",
+
+	"tiempo.ahora":   "ahora",
+	"tiempo.minutos": "hace %d min",
+	"tiempo.horas":   "hace %d h",
+	"tiempo.dias":    "hace %d d",
+
+	"servidores.titulo":          "Servidores Activos",
+	"servidores.vacio":           "No hay servidores corriendo.",
+	"servidores.vacio.ayuda":     "Inicia uno desde '%s Iniciar servidor'",
+	"servidores.detalle":         "📍 Puerto: %d | ⏱️ Activo: %s",
+	"servidores.detenido":        "Servidor de '%s' detenido",
+	"servidores.todos_detenidos": "Todos los servidores detenidos",
+	"servidores.reiniciando":     "Reiniciando '%s'...",
+	"servidores.reiniciado":      "Servidor de '%s' reiniciado",
+	"servidores.caido":           "El servidor de '%s' se detuvo con un error",
+
+	"panel.titulo":    "Panel de logs",
+	"panel.combinado": "línea de tiempo combinada",
+	"panel.errores":   "%d errores nuevos",
+
+	"logs.esperando":        "Esperando logs del servidor...",
+	"logs.sin_servidor":     "No hay servidor activo",
+	"logs.posicion":         "Líneas %d-%d de %d (%d%%)",
+	"logs.seleccion_on":     "Modo selección ON - Usa Ctrl+Shift+C para copiar, '%s' para salir",
+	"logs.seleccion_activa": "MODO SELECCIÓN ACTIVO - Selecciona texto con el mouse",
+	"logs.interactivo":      "MODO INTERACTIVO - Todas las teclas se envían a Shopify CLI ('%s' para soltar)",
+	"logs.descartar":        "'%s' para descartarlo",
+	"logs.descartado":       "Servidor descartado",
+	"logs.escribir":         "Pulsa '%s' para escribir en Shopify CLI",
+	"logs.error_input":      "Error enviando input",
+
+	"logs.busqueda":          "Búsqueda '%s': %d coincidencias (%s/%s para navegar)",
+	"logs.sin_busqueda":      "No hay búsqueda activa (usa '%s')",
+	"logs.sin_coincidencias": "Sin coincidencias para '%s'",
+	"logs.nada_que_copiar":   "No hay líneas para copiar",
+	"logs.copiadas":          "%d líneas copiadas al portapapeles",
+	"logs.error_copiar":      "No se pudo copiar: %v",
+	"logs.exportados":        "Logs exportados a %s",
+	"logs.error_exportar":    "No se pudieron exportar los logs: %v",
+
+	"cmd.login_ok":           "Sesión iniciada correctamente",
+	"cmd.tema_descargado":    "Tema descargado correctamente",
+	"cmd.dev_cerrado":        "Servidor de desarrollo cerrado",
+	"cmd.repo_clonado":       "Repositorio clonado correctamente",
+	"cmd.tienda_configurada": "Tienda configurada correctamente",
+	"cmd.editor_abierto":     "Editor abierto",
+	"cmd.terminal_cerrada":   "Terminal cerrada",
+	"cmd.terminal_abierta":   "Terminal abierta en: %s",
+	"cmd.terminal_salir":     "Escribe 'exit' o presiona Ctrl+D para volver",
+
+	"terminal.en_ejecucion": "en ejecución",
+	"terminal.finalizada":   "terminó (código %d)",
+	"terminal.mostrar":      "%s: mostrar",
+	"terminal.ocultar":      "%s: ocultar",
+	"terminal.cerrar":       "%s: cerrar · %s: ocultar",
+	"terminal.ocupada":      "Ya hay un comando en la terminal: %s",
+
+	"red.sin_ip":      "no se encontró la IP de este equipo en la red local",
+	"red.activado":    "Modo red activado para %s.",
+	"red.desactivado": "Modo red desactivado para %s.",
+	"red.reiniciando": "Reiniciando el servidor...",
+	"red.escanear":    "escanea · %s: ocultar",
+	"red.mostrar_qr":  "%s: mostrar QR",
+
+	"cli.uso":                 "Uso: sho [comando] [opciones]\n\nComandos:\n  (ninguno)    abre la interfaz\n  list         lista las tiendas\n  pull         ejecuta shopify theme pull en las tiendas filtradas\n  push         ejecuta shopify theme push en las tiendas filtradas\n  self-update  instala la última versión de sho\n\nOpciones:\n  --tag <etiqueta>  filtra por etiqueta (repetible)\n  --group <grupo>   filtra por grupo\n  --favorites       solo favoritas\n  --all             pull/push sobre todas las tiendas\n  --version <v>     versión a instalar con self-update",
+	"cli.comando_desconocido": "Comando desconocido: %s",
+	"cli.flag.tag":            "filtra por etiqueta (repetible)",
+	"cli.flag.grupo":          "filtra por grupo",
+	"cli.flag.favoritas":      "solo tiendas favoritas",
+	"cli.flag.todas":          "aplica a todas las tiendas",
+	"cli.flag.version":        "versión a instalar (por defecto la última)",
+	"cli.sin_filtro":          "Indica --tag, --group, --favorites o --all para ejecutar %s",
+	"cli.sin_tiendas":         "Ninguna tienda coincide con el filtro",
+	"cli.ejecutando":          "%s: %s (%s)",
+	"cli.resumen_ok":          "%d tiendas completadas",
+	"cli.resumen_fallos":      "%d de %d tiendas completadas. Fallaron: %s",
+}
@@ -1,7 +1,11 @@
 package main
 
 import (
+	"sort"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/textinput"
 )
 
@@ -17,6 +21,14 @@ const (
 	VistaLogs
 	VistaServidores
 	VistaPopup
+	VistaEditarTienda
+	VistaPanel
+	VistaNovedades
+	VistaLote
+	VistaTrabajo
+	VistaTrabajos
+	VistaDiff
+	VistaDiffArchivo
 )
 
 type MetodoDescarga int
@@ -27,11 +39,18 @@ const (
 )
 
 type Tienda struct {
-	Nombre string         `json:"nombre"`
-	URL    string         `json:"url"`
-	Ruta   string         `json:"ruta"`
-	Metodo MetodoDescarga `json:"metodo"`
-	GitURL string         `json:"git_url,omitempty"`
+	Nombre       string         `json:"nombre"`
+	URL          string         `json:"url"`
+	Ruta         string         `json:"ruta"`
+	Metodo       MetodoDescarga `json:"metodo"`
+	GitURL       string         `json:"git_url,omitempty"`
+	Etiquetas    []string       `json:"etiquetas,omitempty"`
+	Grupo        string         `json:"grupo,omitempty"`
+	Favorita     bool           `json:"favorita,omitempty"`
+	Ganchos      Ganchos        `json:"ganchos,omitempty"`
+	Acompanantes []Acompanante  `json:"acompanantes,omitempty"`
+	ModoRed      bool           `json:"modo_red,omitempty"`
+	TemaRemoto   string         `json:"tema_remoto,omitempty"`
 }
 
 type Model struct {
@@ -55,17 +74,99 @@ type Model struct {
 
 	logsScroll    int
 	modoSeleccion bool
+	escribiendo   bool
 	popupIndex    int
 	vistaAnterior Vista
 
+	confirmarEliminar bool
+	gruposColapsados  map[string]bool
+	inputEtiquetas    textinput.Model
+	inputGrupo        textinput.Model
+	indiceEdicion     int
+	ordenReciente     bool
+
+	panelFoco      int
+	panelCombinado bool
+	logsDesdePanel bool
+	erroresVistos  map[string]int
+
+	inputBusqueda textinput.Model
+	buscandoLogs  bool
+	busquedaLogs  string
+	ocultarQR     bool
+
+	terminal        *TerminalEmbebida
+	terminalAdjunta bool
+	eventos         <-chan EventoServidor
+	servidores      map[string]InstantaneaServidor
+
 	hayActualizacion bool
 	versionNueva     string
+	actualizando     bool
+	actualizado      string
+	reiniciar        bool
+
+	notasVersion     []NotaVersion
+	notasIncompletas bool
+	notasPara        string
+	cargandoNotas    bool
+	errorNotas       string
+	novedadesScroll  int
+
+	marcadas    map[string]bool
+	lote        *Lote
+	loteEnCurso bool
+	loteCursor  int
+	loteScroll  int
+
+	spinner           spinner.Model
+	girando           bool
+	seguidos          map[int]bool
+	trabajo           *Trabajo
+	trabajoScroll     int
+	trabajoSeguir     bool
+	vistaAntesTrabajo Vista
+	trabajosCursor    int
+	trabajosScroll    int
+
+	diffTrabajo     *Trabajo
+	diffCursor      int
+	diffScroll      int
+	diffLineaScroll int
 }
 
+const (
+	accionLogin         = "login"
+	accionAgregarTienda = "agregar_tienda"
+	accionDesarrollo    = "desarrollo"
+	accionServidores    = "servidores"
+	accionTrabajos      = "trabajos"
+	accionShopifyPull   = "shopify_pull"
+	accionGitClone      = "git_clone"
+	accionIniciar       = "iniciar"
+	accionLogs          = "logs"
+	accionDetener       = "detener"
+	accionPull          = "pull"
+	accionPush          = "push"
+	accionEditor        = "editor"
+	accionTerminal      = "terminal"
+	accionModoRed       = "modo_red"
+	accionCheck         = "check"
+	accionEmpaquetar    = "package"
+	accionDiff          = "diff"
+
+	accionCopiarPagina        = "copiar_pagina"
+	accionCopiarTodo          = "copiar_todo"
+	accionCopiarCoincidencias = "copiar_coincidencias"
+	accionExportarTexto       = "exportar_texto"
+	accionExportarJSON        = "exportar_json"
+)
+
 type itemMenu struct {
 	titulo string
 	desc   string
 	atajo  string
+	accion string
 }
 
 func (i itemMenu) Title() string       { return i.titulo }
@@ -73,102 +174,201 @@ func (i itemMenu) Description() string { return i.desc }
 func (i itemMenu) FilterValue() string { return i.titulo }
 
 type itemTienda struct {
-	tienda Tienda
-	indice int
+	tienda   Tienda
+	indice   int
+	servidor *InstantaneaServidor
 }
 
-func (i itemTienda) Title() string {
+func (i itemTienda) activo() bool {
+	return i.servidor != nil && i.servidor.Activo()
+}
 
-	if ObtenerGestor().TieneServidorActivo(i.tienda.Nombre) {
+func (i itemTienda) Title() string {
+	if i.activo() {
 		return Icons.ServerOn + " " + i.tienda.Nombre
 	}
 	return i.tienda.Nombre
 }
 func (i itemTienda) Description() string {
-	metodo := Icons.Download + " pull"
+	metodo := Icons.Download + " " + T("tienda.metodo.pull")
 	if i.tienda.Metodo == MetodoGitClone {
-		metodo = Icons.Git + " git"
+		metodo = Icons.Git + " " + T("tienda.metodo.git")
 	}
 
-	if ObtenerGestor().TieneServidorActivo(i.tienda.Nombre) {
-		servidores := ObtenerGestor().ObtenerServidoresActivos()
-		for _, s := range servidores {
-			if s.Tienda.Nombre == i.tienda.Nombre {
-				return i.tienda.URL + " → " + s.URL
-			}
+	estable := URLEstable(i.tienda)
+	if i.activo() {
+		if estable != "" {
+			return i.tienda.URL + " → " + estable
 		}
+		return i.tienda.URL + " → " + i.servidor.URL
+	}
+	desc := i.tienda.URL + " [" + metodo + "]"
+	if estable != "" {
+		desc += " · " + estable
+	}
+	if resumen := resumenActividad(i.tienda.Nombre); resumen != "" {
+		desc += " · " + resumen
 	}
-	return i.tienda.URL + " [" + metodo + "]"
+	return desc
+}
+func (i itemTienda) FilterValue() string {
+	return strings.Join(camposFiltro(i.tienda), separadorFiltro)
 }
-func (i itemTienda) FilterValue() string { return i.tienda.Nombre }
 
-func modeloInicial() Model {
+type itemGrupo struct {
+	nombre    string
+	cantidad  int
+	colapsado bool
+}
+
+func (i itemGrupo) Title() string       { return i.nombre }
+func (i itemGrupo) Description() string { return "" }
+func (i itemGrupo) FilterValue() string { return "" }
+
+func modeloInicial(ajustes Ajustes) Model {
 
 	inputNombre := textinput.New()
-	inputNombre.Placeholder = "Mi Tienda Principal"
+	inputNombre.Placeholder = T("agregar.nombre.placeholder")
 	inputNombre.CharLimit = 50
 	inputNombre.Width = 40
 
 	inputURL := textinput.New()
-	inputURL.Placeholder = "mi-tienda"
+	inputURL.Placeholder = T("agregar.url.placeholder")
 	inputURL.CharLimit = 50
 	inputURL.Width = 30
 
 	inputGit := textinput.New()
-	inputGit.Placeholder = "git@github.com:usuario/tema.git o https://..."
+	inputGit.Placeholder = T("git.url.placeholder")
 	inputGit.CharLimit = 200
 	inputGit.Width = 50
 
+	inputEtiquetas := textinput.New()
+	inputEtiquetas.Placeholder = T("editar.etiquetas.placeholder")
+	inputEtiquetas.CharLimit = 200
+	inputEtiquetas.Width = 40
+
+	inputBusqueda := textinput.New()
+	inputBusqueda.Prompt = "/"
+	inputBusqueda.CharLimit = 100
+	inputBusqueda.Width = 40
+
+	inputGrupo := textinput.New()
+	inputGrupo.Placeholder = T("editar.grupo.placeholder")
+	inputGrupo.CharLimit = 50
+	inputGrupo.Width = 30
+
 	items := crearMenuPrincipal()
 
 	lista := crearLista(items, Icons.App+" Shopify TUI", 0, 0)
 
 	tiendas, _ := cargarTiendas()
 
-	hayUpdate, versionNew := verificarActualizacion()
-
 	return Model{
 		vista:            VistaMenu,
 		lista:            lista,
 		inputNombre:      inputNombre,
 		inputURL:         inputURL,
 		inputGit:         inputGit,
+		inputEtiquetas:   inputEtiquetas,
+		inputGrupo:       inputGrupo,
+		inputBusqueda:    inputBusqueda,
+		gruposColapsados: make(map[string]bool),
+		marcadas:         make(map[string]bool),
+		seguidos:         make(map[int]bool),
+		spinner:          spinner.New(spinner.WithSpinner(spinner.Dot), spinner.WithStyle(estiloAviso)),
+		erroresVistos:    make(map[string]int),
+		ordenReciente:    ajustes.Orden == ordenReciente,
 		tiendas:          tiendas,
 		cursorInput:      0,
-		hayActualizacion: hayUpdate,
-		versionNueva:     versionNew,
+		eventos:          ObtenerGestor().Suscribir(),
+		servidores:       ObtenerGestor().Instantaneas(),
 	}
 }
 
 func crearMenuPrincipal() []list.Item {
 	return []list.Item{
 		itemMenu{
-			titulo: Icons.Login + " Iniciar sesión",
-			desc:   "Autenticarte en Shopify",
-			atajo:  "a",
+			titulo: Icons.Login + " " + T("menu.login"),
+			desc:   T("menu.login.desc"),
+			atajo:  atajoPrincipal(Teclas.Login),
+			accion: accionLogin,
 		},
 		itemMenu{
-			titulo: Icons.Add + " Agregar tienda",
-			desc:   "Registrar tienda y descargar tema",
-			atajo:  "t",
+			titulo: Icons.Add + " " + T("menu.agregar"),
+			desc:   T("menu.agregar.desc"),
+			atajo:  atajoPrincipal(Teclas.AgregarTienda),
+			accion: accionAgregarTienda,
 		},
 		itemMenu{
-			titulo: Icons.Server + " Desarrollo local",
-			desc:   "Iniciar servidor",
-			atajo:  "d",
+			titulo: Icons.Server + " " + T("menu.desarrollo"),
+			desc:   T("menu.desarrollo.desc"),
+			atajo:  atajoPrincipal(Teclas.Desarrollo),
+			accion: accionDesarrollo,
 		},
 		itemMenu{
-			titulo: Icons.Logs + " Servidores activos",
-			desc:   "Ver y administrar procesos",
-			atajo:  "v",
+			titulo: Icons.Logs + " " + T("menu.servidores"),
+			desc:   T("menu.servidores.desc"),
+			atajo:  atajoPrincipal(Teclas.Servidores),
+			accion: accionServidores,
+		},
+		itemMenu{
+			titulo: "🗂  " + T("menu.trabajos"),
+			desc:   T("menu.trabajos.desc"),
+			atajo:  atajoPrincipal(Teclas.Trabajos),
+			accion: accionTrabajos,
 		},
 	}
 }
 
-func crearListaTiendas(tiendas []Tienda) []list.Item {
-	items := make([]list.Item, len(tiendas))
+func ordenarPorUso(items []list.Item) {
+	historial := ObtenerHistorial()
+	sort.SliceStable(items, func(a, b int) bool {
+		ta := historial.UltimoUso(items[a].(itemTienda).tienda.Nombre)
+		tb := historial.UltimoUso(items[b].(itemTienda).tienda.Nombre)
+		return ta.After(tb)
+	})
+}
+
+func crearListaTiendas(tiendas []Tienda, colapsados map[string]bool, reciente bool, servidores map[string]InstantaneaServidor) []list.Item {
+	var favoritas, sueltas []list.Item
+	grupos := make(map[string][]list.Item)
+
 	for i, t := range tiendas {
-		items[i] = itemTienda{tienda: t, indice: i + 1}
+		item := itemTienda{tienda: t, indice: i + 1}
+		if servidor, existe := servidores[t.Nombre]; existe {
+			item.servidor = &servidor
+		}
+		switch {
+		case t.Favorita:
+			favoritas = append(favoritas, item)
+		case t.Grupo == "":
+			sueltas = append(sueltas, item)
+		default:
+			grupos[t.Grupo] = append(grupos[t.Grupo], item)
+		}
+	}
+
+	nombres := make([]string, 0, len(grupos))
+	for nombre := range grupos {
+		nombres = append(nombres, nombre)
+	}
+	sort.Strings(nombres)
+
+	if reciente {
+		ordenarPorUso(favoritas)
+		ordenarPorUso(sueltas)
+		for _, nombre := range nombres {
+			ordenarPorUso(grupos[nombre])
+		}
+	}
+
+	items := append(favoritas, sueltas...)
+	for _, nombre := range nombres {
+		colapsado := colapsados[nombre]
+		items = append(items, itemGrupo{nombre: nombre, cantidad: len(grupos[nombre]), colapsado: colapsado})
+		if !colapsado {
+			items = append(items, grupos[nombre]...)
+		}
 	}
 	return items
 }
@@ -176,14 +376,16 @@ func crearListaTiendas(tiendas []Tienda) []list.Item {
 func crearListaMetodos() []list.Item {
 	return []list.Item{
 		itemMenu{
-			titulo: Icons.Download + " Shopify Pull",
-			desc:   "Desde Shopify directo",
-			atajo:  "s",
+			titulo: Icons.Download + " " + T("metodo.shopify"),
+			desc:   T("metodo.shopify.desc"),
+			atajo:  atajoPrincipal(Teclas.ShopifyPull),
+			accion: accionShopifyPull,
 		},
 		itemMenu{
-			titulo: Icons.Git + " Git Clone",
-			desc:   "Desde repositorio Git",
-			atajo:  "g",
+			titulo: Icons.Git + " " + T("metodo.git"),
+			desc:   T("metodo.git.desc"),
+			atajo:  atajoPrincipal(Teclas.GitClone),
+			accion: accionGitClone,
 		},
 	}
 }
@@ -192,24 +394,52 @@ func crearListaModos(tienda Tienda, tieneServidor bool) []list.Item {
 
 	opcionesComunes := []list.Item{
 		itemMenu{
-			titulo: Icons.Download + " Pull",
-			desc:   "Bajar cambios del tema",
-			atajo:  "p",
+			titulo: Icons.Download + " " + T("modo.pull"),
+			desc:   T("modo.pull.desc"),
+			atajo:  atajoPrincipal(Teclas.Pull),
+			accion: accionPull,
+		},
+		itemMenu{
+			titulo: Icons.Upload + " " + T("modo.push"),
+			desc:   T("modo.push.desc"),
+			atajo:  atajoPrincipal(Teclas.Push),
+			accion: accionPush,
+		},
+		itemMenu{
+			titulo: Icons.Search + " " + T("modo.check"),
+			desc:   T("modo.check.desc"),
+			atajo:  atajoPrincipal(Teclas.Check),
+			accion: accionCheck,
+		},
+		itemMenu{
+			titulo: "📦 " + T("modo.empaquetar"),
+			desc:   T("modo.empaquetar.desc"),
+			atajo:  atajoPrincipal(Teclas.Empaquetar),
+			accion: accionEmpaquetar,
 		},
 		itemMenu{
-			titulo: Icons.Upload + " Push",
-			desc:   "Subir cambios al tema",
-			atajo:  "u",
+			titulo: "± " + T("modo.diff"),
+			desc:   T("modo.diff.desc"),
+			atajo:  atajoPrincipal(Teclas.Diff),
+			accion: accionDiff,
 		},
 		itemMenu{
-			titulo: Icons.Editor + " Editor",
-			desc:   "Abrir en VS Code",
-			atajo:  "e",
+			titulo: Icons.Editor + " " + T("modo.editor"),
+			desc:   T("modo.editor.desc"),
+			atajo:  atajoPrincipal(Teclas.Editor),
+			accion: accionEditor,
 		},
 		itemMenu{
-			titulo: Icons.Terminal + " Terminal",
-			desc:   "Abrir terminal aquí",
-			atajo:  "t",
+			titulo: Icons.Terminal + " " + T("modo.terminal"),
+			desc:   T("modo.terminal.desc"),
+			atajo:  atajoPrincipal(Teclas.Terminal),
+			accion: accionTerminal,
+		},
+		itemMenu{
+			titulo: "📶 " + T("modo.red"),
+			desc:   descripcionModoRed(tienda),
+			atajo:  atajoPrincipal(Teclas.ModoRed),
+			accion: accionModoRed,
 		},
 	}
 
@@ -217,14 +447,16 @@ func crearListaModos(tienda Tienda, tieneServidor bool) []list.Item {
 
 		items := []list.Item{
 			itemMenu{
-				titulo: Icons.Logs + " Ver logs",
-				desc:   "Logs en tiempo real",
-				atajo:  "l",
+				titulo: Icons.Logs + " " + T("modo.logs"),
+				desc:   T("modo.logs.desc"),
+				atajo:  atajoPrincipal(Teclas.Logs),
+				accion: accionLogs,
 			},
 			itemMenu{
-				titulo: Icons.Stop + " Detener",
-				desc:   "Parar servidor",
-				atajo:  "s",
+				titulo: Icons.Stop + " " + T("modo.detener"),
+				desc:   T("modo.detener.desc"),
+				atajo:  atajoPrincipal(Teclas.Detener),
+				accion: accionDetener,
 			},
 		}
 		return append(items, opcionesComunes...)
@@ -232,14 +464,30 @@ func crearListaModos(tienda Tienda, tieneServidor bool) []list.Item {
 
 	items := []list.Item{
 		itemMenu{
-			titulo: Icons.Rocket + " Iniciar",
-			desc:   "Ejecutar theme dev",
-			atajo:  "i",
+			titulo: Icons.Rocket + " " + T("modo.iniciar"),
+			desc:   T("modo.iniciar.desc"),
+			atajo:  atajoPrincipal(Teclas.Iniciar),
+			accion: accionIniciar,
 		},
 	}
+	if ObtenerGestor().ObtenerServidor(tienda.Nombre) != nil {
+		items = append(items, itemMenu{
+			titulo: Icons.Logs + " " + T("modo.logs"),
+			desc:   T("modo.logs.desc_final"),
+			atajo:  atajoPrincipal(Teclas.Logs),
+			accion: accionLogs,
+		})
+	}
 	return append(items, opcionesComunes...)
 }
 
+func descripcionModoRed(tienda Tienda) string {
+	if tienda.ModoRed {
+		return T("modo.red.desc_activo")
+	}
+	return T("modo.red.desc")
+}
+
 func crearLista(items []list.Item, titulo string, ancho, alto int) list.Model {
 
 	alturaItems := len(items)*2 + 4
@@ -255,11 +503,16 @@ func crearLista(items []list.Item, titulo string, ancho, alto int) list.Model {
 		anchoLista = ancho - 4
 	}
 
-	lista := list.New(items, list.NewDefaultDelegate(), anchoLista, alturaItems)
+	lista := list.New(items, delegadoTema(), anchoLista, alturaItems)
+	aplicarTemaLista(&lista)
 	lista.Title = titulo
 	lista.SetShowStatusBar(false)
 	lista.SetFilteringEnabled(false)
 	lista.SetShowPagination(false)
+	lista.DisableQuitKeybindings()
+	lista.KeyMap.CursorUp = Teclas.Arriba
+	lista.KeyMap.CursorDown = Teclas.Abajo
+	lista.KeyMap.Filter = Teclas.Filtrar
 	return lista
 }
 
@@ -267,3 +520,79 @@ func (m *Model) recrearMenuPrincipal() {
 	items := crearMenuPrincipal()
 	m.lista = crearLista(items, Icons.App+" Shopify TUI", m.ancho, m.alto)
 }
+
+func (m Model) tituloTiendas() string {
+	titulo := Icons.Server + " " + T("tiendas.titulo")
+	if m.ordenReciente {
+		titulo += " · " + T("tiendas.orden_reciente")
+	}
+	return titulo
+}
+
+func (m *Model) recrearListaTiendas() {
+	filtro := ""
+	if m.lista.FilteringEnabled() && m.lista.IsFiltered() {
+		filtro = m.lista.FilterValue()
+	}
+
+	delegado := delegadoTema()
+	delegado.SetSpacing(0)
+
+	colapsados := m.gruposColapsados
+	if filtro != "" {
+		colapsados = nil
+	}
+
+	items := crearListaTiendas(m.tiendas, colapsados, m.ordenReciente, m.servidores)
+	m.lista = crearLista(items, m.tituloTiendas(), m.ancho, m.alto)
+	if m.alto > 0 {
+		m.lista.SetHeight(m.alto - 8)
+	}
+	m.lista.SetDelegate(delegado)
+	m.lista.SetShowTitle(false)
+	m.lista.SetShowHelp(false)
+	m.lista.SetFilteringEnabled(true)
+	m.lista.Filter = filtrarTiendas
+	m.lista.FilterInput.Prompt = Icons.Search + " "
+
+	if filtro != "" {
+		m.lista.SetFilterText(filtro)
+	}
+}
+
+func (m *Model) seleccionarTiendaOriginal(indiceOriginal int) {
+	for i, item := range m.lista.VisibleItems() {
+		if t, ok := item.(itemTienda); ok && t.indice-1 == indiceOriginal {
+			m.lista.Select(i)
+			return
+		}
+	}
+}
+
+func (m Model) tiendaRapida(numero int) int {
+	visibles := m.lista.VisibleItems()
+	inicio, fin := m.lista.Paginator.GetSliceBounds(len(visibles))
+	for i := inicio; i < fin; i++ {
+		if _, ok := visibles[i].(itemTienda); !ok {
+			continue
+		}
+		if numero == 0 {
+			return i
+		}
+		numero--
+	}
+	return -1
+}
+
+func (m Model) tiendaVisible(indice int) (Tienda, int, bool) {
+	visibles := m.lista.VisibleItems()
+	if indice < 0 || indice >= len(visibles) {
+		return Tienda{}, -1, false
+	}
+
+	item, ok := visibles[indice].(itemTienda)
+	if !ok {
+		return Tienda{}, -1, false
+	}
+	return item.tienda, item.indice - 1, true
+}
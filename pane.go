@@ -0,0 +1,423 @@
+package main
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/hinshun/vt10x"
+)
+
+const (
+	modoInverso = 1 << iota
+	modoSubrayado
+	modoNegrita
+	modoGrafico
+	modoCursiva
+	modoParpadeo
+)
+
+type TerminalEmbebida struct {
+	Titulo   string
+	Tienda   Tienda
+	Iniciado time.Time
+
+	vt         vt10x.Terminal
+	maestro    *os.File
+	comando    tea.ExecCommand
+	cambios    chan struct{}
+	terminado  chan struct{}
+	copia      io.Writer
+	alTerminar tea.ExecCallback
+
+	mutex  sync.RWMutex
+	activo bool
+	err    error
+}
+
+type terminalAbiertaMsg struct {
+	terminal *TerminalEmbebida
+}
+
+type terminalActualizadaMsg struct {
+	terminal *TerminalEmbebida
+}
+
+type terminalTerminadaMsg struct {
+	terminal  *TerminalEmbebida
+	resultado tea.Msg
+}
+
+type comandoTerminable interface {
+	Terminar() error
+}
+
+type comandoSimple struct {
+	*exec.Cmd
+	mutex sync.Mutex
+}
+
+func (c *comandoSimple) SetStdin(r io.Reader)  { c.Stdin = r }
+func (c *comandoSimple) SetStdout(w io.Writer) { c.Stdout = w }
+func (c *comandoSimple) SetStderr(w io.Writer) { c.Stderr = w }
+
+func (c *comandoSimple) Run() error {
+	c.mutex.Lock()
+	err := c.Start()
+	c.mutex.Unlock()
+	if err != nil {
+		return err
+	}
+	return c.Wait()
+}
+
+func (c *comandoSimple) Terminar() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return terminarGrupo(c.Cmd)
+}
+
+func ejecutarEnPanel(titulo string, tienda Tienda, comando tea.ExecCommand, copia io.Writer, alTerminar tea.ExecCallback) tea.Cmd {
+	if !terminalDisponible {
+		return tea.Exec(comando, alTerminar)
+	}
+
+	return func() tea.Msg {
+		terminal, err := iniciarTerminalEmbebida(titulo, tienda, comando, copia, alTerminar)
+		if err != nil {
+			return errorMsg{err: err}
+		}
+		return terminalAbiertaMsg{terminal: terminal}
+	}
+}
+
+func iniciarTerminalEmbebida(titulo string, tienda Tienda, comando tea.ExecCommand, copia io.Writer, alTerminar tea.ExecCallback) (*TerminalEmbebida, error) {
+	filas, columnas := 24, 80
+	maestro, esclavo, err := abrirTerminal(filas, columnas)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &TerminalEmbebida{
+		Titulo:     titulo,
+		Tienda:     tienda,
+		Iniciado:   time.Now(),
+		vt:         vt10x.New(vt10x.WithSize(columnas, filas), vt10x.WithWriter(maestro)),
+		maestro:    maestro,
+		comando:    comando,
+		cambios:    make(chan struct{}, 1),
+		terminado:  make(chan struct{}),
+		copia:      copia,
+		alTerminar: alTerminar,
+		activo:     true,
+	}
+
+	comando.SetStdin(esclavo)
+	comando.SetStdout(esclavo)
+	comando.SetStderr(esclavo)
+
+	lectura := make(chan struct{})
+	go func() {
+		defer close(lectura)
+		t.leer()
+	}()
+
+	go func() {
+		err := comando.Run()
+		esclavo.Close()
+
+		select {
+		case <-lectura:
+		case <-time.After(time.Second):
+		}
+
+		t.mutex.Lock()
+		maestro.Close()
+		t.activo = false
+		t.err = err
+		t.mutex.Unlock()
+		close(t.terminado)
+	}()
+
+	return t, nil
+}
+
+func (t *TerminalEmbebida) leer() {
+	bufer := make([]byte, 4096)
+	var pendiente []byte
+	for {
+		n, err := t.maestro.Read(bufer)
+		if n > 0 {
+			var completos []byte
+			completos, pendiente = dividirUTF8(append(pendiente, bufer[:n]...))
+			t.vt.Write(completos)
+			if t.copia != nil {
+				t.copia.Write(completos)
+			}
+			t.avisar()
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+func dividirUTF8(datos []byte) ([]byte, []byte) {
+	for i := len(datos) - 1; i >= 0 && i >= len(datos)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(datos[i]) {
+			if !utf8.FullRune(datos[i:]) {
+				return datos[:i], append([]byte{}, datos[i:]...)
+			}
+			break
+		}
+	}
+	return datos, nil
+}
+
+func (t *TerminalEmbebida) avisar() {
+	select {
+	case t.cambios <- struct{}{}:
+	default:
+	}
+}
+
+func (t *TerminalEmbebida) Activo() bool {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.activo
+}
+
+func (t *TerminalEmbebida) Codigo() int {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return codigoSalida(t.err)
+}
+
+func (t *TerminalEmbebida) Enviar(input string) error {
+	if !t.Activo() {
+		return nil
+	}
+	_, err := t.maestro.Write([]byte(input))
+	return err
+}
+
+func (t *TerminalEmbebida) Redimensionar(filas, columnas int) {
+	t.vt.Resize(columnas, filas)
+
+	t.mutex.RLock()
+	if t.activo {
+		redimensionarTerminal(t.maestro, filas, columnas)
+	}
+	t.mutex.RUnlock()
+	t.avisar()
+}
+
+func (t *TerminalEmbebida) Cerrar() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if !t.activo {
+		return
+	}
+	if comando, ok := t.comando.(comandoTerminable); ok {
+		comando.Terminar()
+	}
+	t.maestro.Close()
+}
+
+func (t *TerminalEmbebida) Render(conCursor bool) string {
+	conCursor = conCursor && t.Activo()
+
+	t.vt.Lock()
+	defer t.vt.Unlock()
+
+	columnas, filas := t.vt.Size()
+	cursor := t.vt.Cursor()
+	conCursor = conCursor && t.vt.CursorVisible()
+
+	var b strings.Builder
+	for y := 0; y < filas; y++ {
+		if y > 0 {
+			b.WriteString("\n")
+		}
+		actual := ""
+		for x := 0; x < columnas; x++ {
+			celda := t.vt.Cell(x, y)
+			if conCursor && x == cursor.X && y == cursor.Y {
+				celda.Mode ^= modoInverso
+			}
+			if sgr := sgrCelda(celda); sgr != actual {
+				b.WriteString("\x1b[0m")
+				if sgr != "" {
+					b.WriteString("\x1b[" + sgr + "m")
+				}
+				actual = sgr
+			}
+			if celda.Char == 0 {
+				b.WriteString(" ")
+			} else {
+				b.WriteRune(celda.Char)
+			}
+		}
+		if actual != "" {
+			b.WriteString("\x1b[0m")
+		}
+	}
+	return b.String()
+}
+
+func sgrCelda(celda vt10x.Glyph) string {
+	var partes []string
+	for _, atributo := range []struct {
+		modo   int16
+		codigo string
+	}{
+		{modoNegrita, "1"},
+		{modoCursiva, "3"},
+		{modoSubrayado, "4"},
+		{modoParpadeo, "5"},
+		{modoInverso, "7"},
+	} {
+		if celda.Mode&atributo.modo != 0 {
+			partes = append(partes, atributo.codigo)
+		}
+	}
+	if color := sgrColor(celda.FG, 30); color != "" {
+		partes = append(partes, color)
+	}
+	if color := sgrColor(celda.BG, 40); color != "" {
+		partes = append(partes, color)
+	}
+	return strings.Join(partes, ";")
+}
+
+func sgrColor(color vt10x.Color, base int) string {
+	switch {
+	case color >= vt10x.DefaultFG:
+		return ""
+	case color < 8:
+		return strconv.Itoa(base + int(color))
+	case color < 16:
+		return strconv.Itoa(base + 60 + int(color) - 8)
+	case color < 256:
+		return strconv.Itoa(base+8) + ";5;" + strconv.Itoa(int(color))
+	}
+	return strconv.Itoa(base+8) + ";2;" + strconv.Itoa(int(color>>16&0xff)) + ";" + strconv.Itoa(int(color>>8&0xff)) + ";" + strconv.Itoa(int(color&0xff))
+}
+
+func esperarTerminal(t *TerminalEmbebida) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case <-t.cambios:
+			return terminalActualizadaMsg{terminal: t}
+		case <-t.terminado:
+			t.mutex.RLock()
+			err := t.err
+			t.mutex.RUnlock()
+			return terminalTerminadaMsg{terminal: t, resultado: t.alTerminar(err)}
+		}
+	}
+}
+
+func (m Model) altoTerminal() int {
+	if m.alto == 0 {
+		return 12
+	}
+	return max(m.alto/2, 8)
+}
+
+func (m Model) tamanoTerminal() (int, int) {
+	ancho := m.ancho
+	if ancho == 0 {
+		ancho = 80
+	}
+	return max(m.altoTerminal()-3, 1), max(ancho-2, 20)
+}
+
+func (m Model) abrirEnTerminal(cmd tea.Cmd) (tea.Model, tea.Cmd) {
+	if m.terminal != nil && m.terminal.Activo() {
+		m.terminalAdjunta = true
+		m.mensaje = IconWarning(T("terminal.ocupada", m.terminal.Titulo))
+		return m, nil
+	}
+	return m, cmd
+}
+
+func (m Model) updateTerminal(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if !m.terminal.Activo() {
+		if key.Matches(msg, Teclas.Volver, Teclas.Aceptar) {
+			m.terminal = nil
+			m.terminalAdjunta = false
+		}
+		return m, nil
+	}
+
+	if input := secuenciaTecla(msg, true); input != "" {
+		if err := m.terminal.Enviar(input); err != nil {
+			m.mensaje = IconWarning(T("logs.error_input"))
+		}
+	}
+	return m, nil
+}
+
+func (m Model) conTerminal(contenido string) string {
+	if m.terminal == nil {
+		return contenido
+	}
+
+	if !m.terminalAdjunta {
+		return recortarAlto(contenido, m.alto-1) + "\n" + m.barraTerminal()
+	}
+	return recortarAlto(contenido, m.alto-m.altoTerminal()) + "\n" + m.vistaTerminal()
+}
+
+func recortarAlto(contenido string, alto int) string {
+	lineas := strings.Split(contenido, "\n")
+	if alto <= 0 || len(lineas) <= alto {
+		return contenido
+	}
+	return strings.Join(lineas[:alto], "\n")
+}
+
+func (m Model) estadoTerminal() string {
+	if m.terminal.Activo() {
+		return estiloExito.Render(T("terminal.en_ejecucion"))
+	}
+	if codigo := m.terminal.Codigo(); codigo != 0 {
+		return estiloError.Render(T("terminal.finalizada", codigo))
+	}
+	return estiloDesc.Render(T("terminal.finalizada", 0))
+}
+
+func (m Model) barraTerminal() string {
+	texto := Icons.Terminal + " " + estiloLabel.Render(m.terminal.Tienda.Nombre) + " " + estiloDesc.Render(m.terminal.Titulo) +
+		" · " + m.estadoTerminal() + " · " + estiloDesc.Render(T("terminal.mostrar", ayudaTecla(Teclas.AdjuntarTerminal)))
+	return ansi.Truncate(texto, max(m.ancho, 20), "…")
+}
+
+func (m Model) vistaTerminal() string {
+	filas, columnas := m.tamanoTerminal()
+
+	ayuda := T("terminal.ocultar", ayudaTecla(Teclas.AdjuntarTerminal))
+	if !m.terminal.Activo() {
+		ayuda = T("terminal.cerrar", ayudaTecla(Teclas.Volver), ayudaTecla(Teclas.AdjuntarTerminal))
+	}
+	encabezado := Icons.Terminal + " " + estiloLabel.Render(m.terminal.Tienda.Nombre) + " " + estiloDesc.Render(m.terminal.Titulo) +
+		" · " + m.estadoTerminal() + " · " + estiloDesc.Render(ayuda)
+
+	estilo := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorTema(Tema.Primario)).
+		Width(columnas).
+		Height(filas + 1)
+
+	return estilo.Render(ansi.Truncate(encabezado, columnas, "…") + "\n" + m.terminal.Render(true))
+}
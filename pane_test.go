@@ -0,0 +1,34 @@
+package main
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestCerrarTerminalTerminaGrupo(t *testing.T) {
+	if !terminalDisponible {
+		t.Skip("sin PTY en esta plataforma")
+	}
+
+	cmd := exec.Command("sh", "-c", "trap '' HUP; sleep 30 & wait")
+	configurarTerminalControl(cmd)
+	terminal, err := iniciarTerminalEmbebida("prueba", Tienda{Nombre: "panel"}, &comandoSimple{Cmd: cmd}, nil, func(err error) tea.Msg { return nil })
+	if err != nil {
+		t.Fatalf("iniciarTerminalEmbebida: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+	terminal.Cerrar()
+
+	select {
+	case <-terminal.terminado:
+	case <-time.After(5 * time.Second):
+		t.Fatal("el proceso del panel sigue vivo tras Cerrar")
+	}
+	if terminal.Activo() {
+		t.Fatal("la terminal sigue activa tras terminar")
+	}
+}
@@ -0,0 +1,71 @@
+//go:build !windows
+
+package main
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+
+	"github.com/creack/pty"
+)
+
+const terminalDisponible = true
+
+func configurarGrupoProcesos(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+}
+
+func terminarGrupo(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	grupo := -cmd.Process.Pid
+	if err := syscall.Kill(grupo, syscall.SIGTERM); err != nil {
+		return cmd.Process.Kill()
+	}
+	time.AfterFunc(esperaTerminacion, func() { syscall.Kill(grupo, syscall.SIGKILL) })
+	return nil
+}
+
+func iniciarEnTerminal(cmd *exec.Cmd, filas, columnas int) (*os.File, error) {
+	return pty.StartWithAttrs(cmd, tamanoTerminal(filas, columnas), &syscall.SysProcAttr{Setsid: true, Setctty: true})
+}
+
+func redimensionarTerminal(terminal *os.File, filas, columnas int) error {
+	return pty.Setsize(terminal, tamanoTerminal(filas, columnas))
+}
+
+func tamanoTerminal(filas, columnas int) *pty.Winsize {
+	return &pty.Winsize{Rows: uint16(max(filas, 1)), Cols: uint16(max(columnas, 1))}
+}
+
+func abrirTerminal(filas, columnas int) (*os.File, *os.File, error) {
+	maestro, esclavo, err := pty.Open()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := pty.Setsize(maestro, tamanoTerminal(filas, columnas)); err != nil {
+		maestro.Close()
+		esclavo.Close()
+		return nil, nil, err
+	}
+	return maestro, esclavo, nil
+}
+
+func configurarTerminalControl(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+}
+
+func instalarEjecutable(nuevo, ruta string) error {
+	return os.Rename(nuevo, ruta)
+}
+
+func reiniciarEjecutable() error {
+	ruta, err := rutaEjecutable()
+	if err != nil {
+		return err
+	}
+	return syscall.Exec(ruta, os.Args, os.Environ())
+}
@@ -0,0 +1,65 @@
+//go:build windows
+
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+)
+
+const terminalDisponible = false
+
+func configurarGrupoProcesos(cmd *exec.Cmd) {}
+
+func terminarGrupo(cmd *exec.Cmd) error {
+	if cmd.Process == nil {
+		return nil
+	}
+	return cmd.Process.Kill()
+}
+
+func iniciarEnTerminal(cmd *exec.Cmd, filas, columnas int) (*os.File, error) {
+	return nil, errors.New(T("servidor.error_terminal"))
+}
+
+func redimensionarTerminal(terminal *os.File, filas, columnas int) error {
+	return nil
+}
+
+func abrirTerminal(filas, columnas int) (*os.File, *os.File, error) {
+	return nil, nil, errors.New(T("servidor.error_terminal"))
+}
+
+func configurarTerminalControl(cmd *exec.Cmd) {}
+
+func instalarEjecutable(nuevo, ruta string) error {
+	anterior := ruta + ".old"
+	os.Remove(anterior)
+	if err := os.Rename(ruta, anterior); err != nil {
+		return err
+	}
+	if err := os.Rename(nuevo, ruta); err != nil {
+		os.Rename(anterior, ruta)
+		return err
+	}
+	return nil
+}
+
+func reiniciarEjecutable() error {
+	ruta, err := rutaEjecutable()
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command(ruta, os.Args[1:]...)
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err = cmd.Run()
+
+	var errSalida *exec.ExitError
+	if err != nil && !errors.As(err, &errSalida) {
+		return err
+	}
+	os.Exit(codigoSalida(err))
+	return nil
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const puertoProxyPorDefecto = 9290
+
+type ConfigProxy struct {
+	Activo bool `json:"activo,omitempty"`
+	Puerto int  `json:"puerto,omitempty"`
+}
+
+var ProxyGlobal = ConfigProxy{Puerto: puertoProxyPorDefecto}
+
+func InitProxy(config ConfigProxy) error {
+	if config.Puerto == 0 {
+		config.Puerto = puertoProxyPorDefecto
+	}
+	if config.Puerto < 1 || config.Puerto > 65535 {
+		return errors.New(T("proxy.puerto_invalido", config.Puerto))
+	}
+	ProxyGlobal = config
+	return nil
+}
+
+type ProxyTiendas struct {
+	mutex      sync.RWMutex
+	servidor   *http.Server
+	escuchando bool
+	tiendas    []Tienda
+	hosts      map[string]int
+	colisiones map[string][]string
+}
+
+var proxyGlobal = &ProxyTiendas{}
+
+type proxyIniciadoMsg struct {
+	err        error
+	colisiones []string
+}
+
+func ObtenerProxy() *ProxyTiendas {
+	return proxyGlobal
+}
+
+func (p *ProxyTiendas) Iniciar() error {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.escuchando {
+		return nil
+	}
+
+	oyente, err := net.Listen("tcp", fmt.Sprintf("127.0.0.1:%d", ProxyGlobal.Puerto))
+	if err != nil {
+		return errors.New(T("proxy.error_iniciar", ProxyGlobal.Puerto, err))
+	}
+
+	if p.hosts == nil {
+		tiendas, _ := cargarTiendas()
+		p.indexar(tiendas)
+	}
+
+	p.servidor = &http.Server{
+		Handler:           http.HandlerFunc(p.atender),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	p.escuchando = true
+
+	go func() {
+		p.servidor.Serve(oyente)
+		p.mutex.Lock()
+		p.escuchando = false
+		p.mutex.Unlock()
+	}()
+	return nil
+}
+
+func (p *ProxyTiendas) Detener() {
+	p.mutex.RLock()
+	servidor := p.servidor
+	p.mutex.RUnlock()
+
+	if servidor != nil {
+		servidor.Close()
+	}
+}
+
+func iniciarProxy() tea.Cmd {
+	if !ProxyGlobal.Activo {
+		return nil
+	}
+	return func() tea.Msg {
+		proxy := ObtenerProxy()
+		if err := proxy.Iniciar(); err != nil {
+			return proxyIniciadoMsg{err: err}
+		}
+		return proxyIniciadoMsg{colisiones: proxy.Colisiones()}
+	}
+}
+
+func (p *ProxyTiendas) ActualizarTiendas(tiendas []Tienda) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.indexar(tiendas)
+}
+
+func (p *ProxyTiendas) indexar(tiendas []Tienda) {
+	p.tiendas = append([]Tienda(nil), tiendas...)
+	p.hosts = make(map[string]int, len(tiendas))
+	p.colisiones = make(map[string][]string)
+
+	for i, tienda := range p.tiendas {
+		host := hostTienda(tienda.Nombre)
+		if host == "" {
+			continue
+		}
+		primera, repetido := p.hosts[host]
+		if !repetido {
+			p.hosts[host] = i
+			continue
+		}
+		if len(p.colisiones[host]) == 0 {
+			p.colisiones[host] = []string{p.tiendas[primera].Nombre}
+		}
+		p.colisiones[host] = append(p.colisiones[host], tienda.Nombre)
+	}
+}
+
+func (p *ProxyTiendas) buscarTienda(host string) (Tienda, []string, bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	if nombres := p.colisiones[host]; len(nombres) > 0 {
+		return Tienda{}, nombres, false
+	}
+	i, ok := p.hosts[host]
+	if !ok {
+		return Tienda{}, nil, false
+	}
+	return p.tiendas[i], nil, true
+}
+
+func (p *ProxyTiendas) Colisiones() []string {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	avisos := make([]string, 0, len(p.colisiones))
+	for host, nombres := range p.colisiones {
+		avisos = append(avisos, T("proxy.colision", host, strings.Join(nombres, ", ")))
+	}
+	sort.Strings(avisos)
+	return avisos
+}
+
+func hostTienda(nombre string) string {
+	nombre = strings.NewReplacer(
+		"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	).Replace(sanitizarNombre(nombre))
+
+	var b strings.Builder
+	for _, r := range nombre {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '-' {
+			b.WriteRune(r)
+		}
+	}
+	return strings.Trim(b.String(), "-")
+}
+
+func URLEstable(tienda Tienda) string {
+	if !ProxyGlobal.Activo {
+		return ""
+	}
+	return fmt.Sprintf("http://%s.localhost:%d", hostTienda(tienda.Nombre), ProxyGlobal.Puerto)
+}
+
+func hostSolicitado(r *http.Request) string {
+	host := r.Host
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "localhost" || net.ParseIP(host) != nil {
+		return ""
+	}
+	return strings.TrimSuffix(host, ".localhost")
+}
+
+type estadoProxyTienda struct {
+	Nombre string
+	URL    string
+	Estado string
+	Activa bool
+}
+
+func (p *ProxyTiendas) estados() []estadoProxyTienda {
+	p.mutex.RLock()
+	tiendas := p.tiendas
+	colisiones := p.colisiones
+	p.mutex.RUnlock()
+
+	estados := make([]estadoProxyTienda, 0, len(tiendas))
+	for _, tienda := range tiendas {
+		estado := estadoProxyTienda{Nombre: tienda.Nombre, URL: URLEstable(tienda), Estado: T("proxy.detenido")}
+		if servidor := ObtenerGestor().ObtenerServidor(tienda.Nombre); servidor != nil && servidor.Activo() {
+			estado.Activa = true
+			estado.Estado = servidor.Salud().Texto()
+		}
+		if host := hostTienda(tienda.Nombre); len(colisiones[host]) > 0 {
+			estado.Estado = T("proxy.colision", host, strings.Join(colisiones[host], ", "))
+		}
+		estados = append(estados, estado)
+	}
+	return estados
+}
+
+func (p *ProxyTiendas) atender(w http.ResponseWriter, r *http.Request) {
+	host := hostSolicitado(r)
+	if host == "" {
+		p.paginaEstado(w, http.StatusOK, "", T("proxy.titulo"))
+		return
+	}
+
+	tienda, colisiones, ok := p.buscarTienda(host)
+	if len(colisiones) > 0 {
+		p.paginaEstado(w, http.StatusConflict, "", T("proxy.colision", host, strings.Join(colisiones, ", ")))
+		return
+	}
+	if !ok {
+		p.paginaEstado(w, http.StatusNotFound, "", T("proxy.desconocida", host))
+		return
+	}
+
+	servidor := ObtenerGestor().ObtenerServidor(tienda.Nombre)
+	if servidor == nil || !servidor.Activo() {
+		p.paginaEstado(w, http.StatusServiceUnavailable, tienda.Nombre, T("proxy.sin_servidor", tienda.Nombre))
+		return
+	}
+
+	destino, err := url.Parse(servidor.URL)
+	if err != nil {
+		p.paginaEstado(w, http.StatusBadGateway, tienda.Nombre, err.Error())
+		return
+	}
+
+	proxy := httputil.NewSingleHostReverseProxy(destino)
+	dirigir := proxy.Director
+	proxy.Director = func(solicitud *http.Request) {
+		dirigir(solicitud)
+		solicitud.Host = destino.Host
+	}
+	proxy.ErrorHandler = func(w http.ResponseWriter, _ *http.Request, err error) {
+		p.paginaEstado(w, http.StatusBadGateway, tienda.Nombre, T("proxy.sin_respuesta", tienda.Nombre, resumirError(err)))
+	}
+	proxy.ServeHTTP(w, r)
+}
+
+var plantillaEstadoProxy = template.Must(template.New("estado").Parse(`<!doctype html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="5">
+<title>{{.Titulo}}</title>
+<style>
+body { font-family: system-ui, sans-serif; background: #1e1e2e; color: #cdd6f4; max-width: 40rem; margin: 3rem auto; padding: 0 1rem; }
+a { color: #89b4fa; }
+li { margin: .4rem 0; }
+.activa { color: #a6e3a1; }
+.detenida { color: #6c7086; }
+.actual { font-weight: bold; }
+</style>
+</head>
+<body>
+<h1>{{.Titulo}}</h1>
+<ul>
+{{range .Tiendas}}<li class="{{if .Activa}}activa{{else}}detenida{{end}}{{if eq .Nombre $.Actual}} actual{{end}}"><a href="{{.URL}}">{{.Nombre}}</a> · {{.Estado}}</li>
+{{end}}</ul>
+<p><small>{{.Pie}}</small></p>
+</body>
+</html>
+`))
+
+func (p *ProxyTiendas) paginaEstado(w http.ResponseWriter, codigo int, actual, titulo string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(codigo)
+	plantillaEstadoProxy.Execute(w, struct {
+		Titulo  string
+		Actual  string
+		Tiendas []estadoProxyTienda
+		Pie     string
+	}{
+		Titulo:  titulo,
+		Actual:  actual,
+		Tiendas: p.estados(),
+		Pie:     T("proxy.pie"),
+	})
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestHostTienda(t *testing.T) {
+	casos := []struct {
+		nombre, host string
+	}{
+		{"alpha", "alpha"},
+		{"Mi Tienda", "mi-tienda"},
+		{"Tienda Española", "tienda-espanola"},
+		{"  Café & Té  ", "cafe--te"},
+		{"store_2024", "store-2024"},
+		{"--raro--", "raro"},
+		{"!!!", ""},
+	}
+
+	for _, caso := range casos {
+		if got := hostTienda(caso.nombre); got != caso.host {
+			t.Errorf("hostTienda(%q) = %q, se esperaba %q", caso.nombre, got, caso.host)
+		}
+	}
+}
+
+func TestHostSolicitado(t *testing.T) {
+	casos := []struct {
+		host, esperado string
+	}{
+		{"alpha.localhost:9290", "alpha"},
+		{"Alpha.LOCALHOST:9290", "alpha"},
+		{"alpha.localhost.:9290", "alpha"},
+		{"alpha.localhost", "alpha"},
+		{"localhost:9290", ""},
+		{"127.0.0.1:9290", ""},
+		{"[::1]:9290", ""},
+	}
+
+	for _, caso := range casos {
+		solicitud := httptest.NewRequest(http.MethodGet, "/", nil)
+		solicitud.Host = caso.host
+		if got := hostSolicitado(solicitud); got != caso.esperado {
+			t.Errorf("hostSolicitado(%q) = %q, se esperaba %q", caso.host, got, caso.esperado)
+		}
+	}
+}
+
+func TestColisionesProxy(t *testing.T) {
+	proxy := &ProxyTiendas{}
+	proxy.ActualizarTiendas([]Tienda{{Nombre: "Mi Tienda"}, {Nombre: "beta"}, {Nombre: "mi-tienda"}, {Nombre: "MI TIENDA"}, {Nombre: "!!!"}})
+
+	if _, nombres, _ := proxy.buscarTienda("mi-tienda"); !reflect.DeepEqual(nombres, []string{"Mi Tienda", "mi-tienda", "MI TIENDA"}) {
+		t.Fatalf("colisión de mi-tienda = %v", nombres)
+	}
+	if tienda, nombres, ok := proxy.buscarTienda("beta"); !ok || len(nombres) > 0 || tienda.Nombre != "beta" {
+		t.Fatalf("buscarTienda(beta) = %v, %v, %v", tienda, nombres, ok)
+	}
+	if len(proxy.Colisiones()) != 1 {
+		t.Fatalf("Colisiones() = %v", proxy.Colisiones())
+	}
+
+	proxy.ActualizarTiendas([]Tienda{{Nombre: "Mi Tienda"}, {Nombre: "beta"}})
+	if len(proxy.Colisiones()) != 0 {
+		t.Fatalf("tras renombrar siguen las colisiones: %v", proxy.Colisiones())
+	}
+	if _, _, ok := proxy.buscarTienda("mi-tienda"); !ok {
+		t.Fatal("mi-tienda debería resolverse tras actualizar las tiendas")
+	}
+}
+
+func TestAtenderProxy(t *testing.T) {
+	var hostRecibido string
+	destino := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hostRecibido = r.Host
+		io.WriteString(w, "tema de alpha "+r.URL.Path)
+	}))
+	defer destino.Close()
+
+	gestor := ObtenerGestor()
+	gestor.mutex.Lock()
+	gestor.servidores["proxy-alpha"] = &ServidorActivo{Tienda: Tienda{Nombre: "proxy-alpha"}, URL: destino.URL, ciclo: CicloVida{Estado: EstadoListo}}
+	gestor.servidores["proxy-caida"] = &ServidorActivo{Tienda: Tienda{Nombre: "proxy-caida"}, URL: destino.URL, ciclo: CicloVida{Estado: EstadoCaido}}
+	gestor.mutex.Unlock()
+	t.Cleanup(func() {
+		gestor.mutex.Lock()
+		delete(gestor.servidores, "proxy-alpha")
+		delete(gestor.servidores, "proxy-caida")
+		gestor.mutex.Unlock()
+	})
+
+	proxy := &ProxyTiendas{}
+	proxy.ActualizarTiendas([]Tienda{
+		{Nombre: "proxy-alpha"}, {Nombre: "proxy-beta"}, {Nombre: "proxy-caida"},
+		{Nombre: "Doble"}, {Nombre: "doble"},
+	})
+
+	casos := []struct {
+		host      string
+		codigo    int
+		contenido string
+	}{
+		{"proxy-alpha.localhost:9290", http.StatusOK, "tema de alpha /products/x"},
+		{"proxy-beta.localhost:9290", http.StatusServiceUnavailable, "proxy-beta"},
+		{"proxy-caida.localhost:9290", http.StatusServiceUnavailable, "proxy-caida"},
+		{"otra.localhost:9290", http.StatusNotFound, "otra"},
+		{"doble.localhost:9290", http.StatusConflict, "Doble, doble"},
+		{"localhost:9290", http.StatusOK, "proxy-alpha"},
+	}
+
+	for _, caso := range casos {
+		solicitud := httptest.NewRequest(http.MethodGet, "/products/x", nil)
+		solicitud.Host = caso.host
+		respuesta := httptest.NewRecorder()
+		proxy.atender(respuesta, solicitud)
+
+		if respuesta.Code != caso.codigo || !strings.Contains(respuesta.Body.String(), caso.contenido) {
+			t.Errorf("%s: %d %q, se esperaba %d con %q", caso.host, respuesta.Code, respuesta.Body.String(), caso.codigo, caso.contenido)
+		}
+	}
+
+	if hostRecibido != strings.TrimPrefix(destino.URL, "http://") {
+		t.Errorf("el servidor de la tienda recibió Host %q", hostRecibido)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// USER_HZ vale 100 en todos los kernels Linux habituales; solo se usa si
+	// el sistema no informa AT_CLKTCK.
+	ticksPorDefecto   = 100
+	intervaloRecursos = 2 * time.Second
+	historialRecursos = 30
+	cpuAlta           = 80.0
+	memoriaAlta       = 2 << 30
+)
+
+var ticksPorSegundo = sync.OnceValue(leerTicksPorSegundo)
+
+type muestraRecursos struct {
+	ticks    uint64
+	memoria  uint64
+	procesos int
+}
+
+type Recursos struct {
+	CPU              float64
+	Memoria          uint64
+	Procesos         int
+	Medido           time.Time
+	HistorialCPU     []float64
+	HistorialMemoria []float64
+}
+
+func (s *ServidorActivo) Recursos() Recursos {
+	s.LogsMutex.RLock()
+	defer s.LogsMutex.RUnlock()
+
+	recursos := s.recursos
+	recursos.HistorialCPU = append([]float64(nil), s.recursos.HistorialCPU...)
+	recursos.HistorialMemoria = append([]float64(nil), s.recursos.HistorialMemoria...)
+	return recursos
+}
+
+func (s *ServidorActivo) registrarRecursos(cpu float64, muestra muestraRecursos) {
+	s.LogsMutex.Lock()
+	defer s.LogsMutex.Unlock()
+
+	s.recursos.CPU = cpu
+	s.recursos.Memoria = muestra.memoria
+	s.recursos.Procesos = muestra.procesos
+	s.recursos.Medido = time.Now()
+	s.recursos.HistorialCPU = agregarHistorial(s.recursos.HistorialCPU, cpu)
+	s.recursos.HistorialMemoria = agregarHistorial(s.recursos.HistorialMemoria, float64(muestra.memoria))
+}
+
+func agregarHistorial(historial []float64, valor float64) []float64 {
+	historial = append(historial, valor)
+	if exceso := len(historial) - historialRecursos; exceso > 0 {
+		historial = append(historial[:0], historial[exceso:]...)
+	}
+	return historial
+}
+
+func (g *GestorServidores) vigilarRecursos(servidor *ServidorActivo) {
+	if !recursosDisponibles || servidor.Proceso.Process == nil {
+		return
+	}
+	raiz := servidor.Proceso.Process.Pid
+
+	anterior, err := medirArbolProcesos(raiz)
+	medido := err == nil
+	momento := time.Now()
+
+	intervalo := time.NewTicker(intervaloRecursos)
+	defer intervalo.Stop()
+
+	for {
+		select {
+		case <-servidor.terminado:
+			return
+		case <-intervalo.C:
+		}
+
+		muestra, err := medirArbolProcesos(raiz)
+		if err != nil {
+			continue
+		}
+
+		ahora := time.Now()
+		if medido && muestra.ticks >= anterior.ticks {
+			cpu := float64(muestra.ticks-anterior.ticks) / ticksPorSegundo() / ahora.Sub(momento).Seconds() * 100
+			servidor.registrarRecursos(cpu, muestra)
+			g.publicar(EventoRecursos, servidor.Tienda.Nombre, "")
+		}
+		anterior, momento, medido = muestra, ahora, true
+	}
+}
+
+func sparkline(valores []float64, maximo float64) string {
+	niveles := []rune("▁▂▃▄▅▆▇█")
+	for _, valor := range valores {
+		maximo = max(maximo, valor)
+	}
+
+	var b strings.Builder
+	for _, valor := range valores {
+		nivel := 0
+		if maximo > 0 {
+			nivel = int(valor / maximo * float64(len(niveles)-1))
+		}
+		b.WriteRune(niveles[min(max(nivel, 0), len(niveles)-1)])
+	}
+	return b.String()
+}
+
+func formatearMemoria(bytes uint64) string {
+	switch {
+	case bytes >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(bytes)/(1<<30))
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%d MB", bytes>>20)
+	}
+	return fmt.Sprintf("%d KB", bytes>>10)
+}
+
+func renderRecursos(recursos Recursos) string {
+	cpu := fmt.Sprintf("CPU %5.1f%% ", recursos.CPU)
+	estiloCPU := estiloDesc
+	if recursos.CPU >= cpuAlta {
+		estiloCPU = estiloAviso
+	}
+
+	memoria := "RAM " + formatearMemoria(recursos.Memoria) + " "
+	estiloMemoria := estiloDesc
+	if recursos.Memoria >= memoriaAlta {
+		estiloMemoria = estiloAviso
+	}
+
+	return estiloCPU.Render(cpu) + estiloEnlace.Render(sparkline(recursos.HistorialCPU, 100)) +
+		estiloDesc.Render(" · ") +
+		estiloMemoria.Render(memoria) + estiloEnlace.Render(sparkline(recursos.HistorialMemoria, 0)) +
+		estiloDesc.Render(" · "+T("recursos.subprocesos", recursos.Procesos-1))
+}
@@ -0,0 +1,119 @@
+//go:build linux
+
+package main
+
+import (
+	"encoding/binary"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+)
+
+type estadoProceso struct {
+	padre int
+	grupo int
+	ticks uint64
+	rss   uint64
+}
+
+const (
+	recursosDisponibles = true
+	auxvClkTck          = 17
+)
+
+func leerTicksPorSegundo() float64 {
+	datos, err := os.ReadFile("/proc/self/auxv")
+	if err != nil {
+		return ticksPorDefecto
+	}
+
+	palabra := strconv.IntSize / 8
+	for i := 0; i+2*palabra <= len(datos); i += 2 * palabra {
+		tipo, valor := leerPalabraAuxv(datos[i:], palabra), leerPalabraAuxv(datos[i+palabra:], palabra)
+		if tipo == auxvClkTck && valor > 0 {
+			return float64(valor)
+		}
+	}
+	return ticksPorDefecto
+}
+
+func leerPalabraAuxv(datos []byte, palabra int) uint64 {
+	if palabra == 4 {
+		return uint64(binary.NativeEndian.Uint32(datos))
+	}
+	return binary.NativeEndian.Uint64(datos)
+}
+
+func leerEstadoProceso(pid int) (estadoProceso, bool) {
+	datos, err := os.ReadFile("/proc/" + strconv.Itoa(pid) + "/stat")
+	if err != nil {
+		return estadoProceso{}, false
+	}
+
+	texto := string(datos)
+	cierre := strings.LastIndexByte(texto, ')')
+	if cierre < 0 {
+		return estadoProceso{}, false
+	}
+	campos := strings.Fields(texto[cierre+1:])
+	if len(campos) < 22 {
+		return estadoProceso{}, false
+	}
+
+	padre, _ := strconv.Atoi(campos[1])
+	grupo, _ := strconv.Atoi(campos[2])
+	usuario, _ := strconv.ParseUint(campos[11], 10, 64)
+	sistema, _ := strconv.ParseUint(campos[12], 10, 64)
+	paginas, _ := strconv.ParseUint(campos[21], 10, 64)
+
+	return estadoProceso{
+		padre: padre,
+		grupo: grupo,
+		ticks: usuario + sistema,
+		rss:   paginas * uint64(os.Getpagesize()),
+	}, true
+}
+
+func medirArbolProcesos(raiz int) (muestraRecursos, error) {
+	entradas, err := os.ReadDir("/proc")
+	if err != nil {
+		return muestraRecursos{}, err
+	}
+
+	procesos := make(map[int]estadoProceso)
+	for _, entrada := range entradas {
+		pid, err := strconv.Atoi(entrada.Name())
+		if err != nil {
+			continue
+		}
+		if estado, ok := leerEstadoProceso(pid); ok {
+			procesos[pid] = estado
+		}
+	}
+
+	pertenece := map[int]bool{raiz: true}
+	var enArbol func(pid int, profundidad int) bool
+	enArbol = func(pid int, profundidad int) bool {
+		if valor, visto := pertenece[pid]; visto {
+			return valor
+		}
+		estado, existe := procesos[pid]
+		resultado := existe && profundidad < 64 && (estado.grupo == raiz || enArbol(estado.padre, profundidad+1))
+		pertenece[pid] = resultado
+		return resultado
+	}
+
+	var muestra muestraRecursos
+	for pid, estado := range procesos {
+		if enArbol(pid, 0) {
+			muestra.ticks += estado.ticks
+			muestra.memoria += estado.rss
+			muestra.procesos++
+		}
+	}
+	if muestra.procesos == 0 {
+		return muestraRecursos{}, errors.New(T("recursos.no_disponibles"))
+	}
+	return muestra, nil
+}
@@ -0,0 +1,15 @@
+//go:build !linux
+
+package main
+
+import "errors"
+
+const recursosDisponibles = false
+
+func leerTicksPorSegundo() float64 {
+	return ticksPorDefecto
+}
+
+func medirArbolProcesos(raiz int) (muestraRecursos, error) {
+	return muestraRecursos{}, errors.New(T("recursos.no_disponibles"))
+}
@@ -0,0 +1,238 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	archivoChecksums = "checksums.txt"
+	maxTamanoBinario = 200 << 20
+)
+
+var urlReleases = "https://github.com/JacuXx/shopify-tui/releases/download"
+
+var (
+	ejecutableActual = rutaEjecutable
+	comandoNpm       = func(argumentos ...string) *exec.Cmd {
+		return exec.Command("npm", argumentos...)
+	}
+)
+
+type autoActualizacionMsg struct {
+	version string
+	err     error
+}
+
+func nombreBinarioRelease() (string, error) {
+	sistemas := map[string]string{"linux": "linux", "darwin": "darwin", "windows": "win32"}
+	arquitecturas := map[string]string{"amd64": "x64", "arm64": "arm64"}
+
+	sistema, okSistema := sistemas[runtime.GOOS]
+	arquitectura, okArquitectura := arquitecturas[runtime.GOARCH]
+	if !okSistema || !okArquitectura {
+		return "", errors.New(T("version.plataforma", runtime.GOOS, runtime.GOARCH))
+	}
+
+	nombre := "shopify-tui-" + sistema + "-" + arquitectura
+	if runtime.GOOS == "windows" {
+		nombre += ".exe"
+	}
+	return nombre, nil
+}
+
+func urlRelease(version, archivo string) string {
+	return urlReleases + "/v" + strings.TrimPrefix(version, "v") + "/" + archivo
+}
+
+func rutaEjecutable() (string, error) {
+	ruta, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(ruta)
+}
+
+func instaladoConNpm(ruta string) bool {
+	return strings.Contains(filepath.ToSlash(ruta), "/node_modules/"+NpmPackageName+"/")
+}
+
+func descargarArchivo(url string) ([]byte, error) {
+	client := &http.Client{
+		Timeout: 2 * time.Minute,
+	}
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != 200 {
+		return nil, errors.New(T("version.error_descarga", url, resp.Status))
+	}
+
+	datos, err := io.ReadAll(io.LimitReader(resp.Body, maxTamanoBinario+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(datos) > maxTamanoBinario {
+		return nil, errors.New(T("version.error_descarga", url, fmt.Sprintf("> %d MB", maxTamanoBinario>>20)))
+	}
+	return datos, nil
+}
+
+func checksumEsperado(checksums []byte, archivo string) (string, error) {
+	lector := bufio.NewScanner(bytes.NewReader(checksums))
+	for lector.Scan() {
+		campos := strings.Fields(lector.Text())
+		if len(campos) == 2 && strings.TrimPrefix(campos[1], "*") == archivo {
+			return strings.ToLower(campos[0]), nil
+		}
+	}
+	return "", errors.New(T("version.sin_checksum", archivo))
+}
+
+func autoActualizar(version string) (string, error) {
+	ruta, err := ejecutableActual()
+	if err != nil {
+		return "", err
+	}
+
+	if instaladoConNpm(ruta) {
+		cmd := comandoNpm("install", "-g", NpmPackageName+"@"+strings.TrimPrefix(version, "v"))
+		if salida, err := cmd.CombinedOutput(); err != nil {
+			return "", errors.New(T("version.error_npm", ultimaLinea(string(salida), err)))
+		}
+		return ruta, nil
+	}
+
+	archivo, err := nombreBinarioRelease()
+	if err != nil {
+		return "", err
+	}
+
+	checksums, err := descargarArchivo(urlRelease(version, archivoChecksums))
+	if err != nil {
+		return "", err
+	}
+	esperado, err := checksumEsperado(checksums, archivo)
+	if err != nil {
+		return "", err
+	}
+
+	binario, err := descargarArchivo(urlRelease(version, archivo))
+	if err != nil {
+		return "", err
+	}
+	suma := sha256.Sum256(binario)
+	if hex.EncodeToString(suma[:]) != esperado {
+		return "", errors.New(T("version.checksum_invalido", archivo))
+	}
+
+	if err := reemplazarEjecutable(ruta, binario); err != nil {
+		return "", errors.New(T("version.error_reemplazar", ruta, err))
+	}
+	return ruta, nil
+}
+
+func ultimaLinea(salida string, err error) string {
+	lineas := strings.Split(strings.TrimSpace(salida), "\n")
+	if ultima := strings.TrimSpace(lineas[len(lineas)-1]); ultima != "" {
+		return ultima
+	}
+	return err.Error()
+}
+
+func reemplazarEjecutable(ruta string, binario []byte) error {
+	info, err := os.Stat(ruta)
+	if err != nil {
+		return err
+	}
+
+	temporal, err := os.CreateTemp(filepath.Dir(ruta), ".sho-update-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(temporal.Name())
+
+	if _, err := temporal.Write(binario); err != nil {
+		temporal.Close()
+		return err
+	}
+	if err := temporal.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(temporal.Name(), info.Mode().Perm()|0111); err != nil {
+		return err
+	}
+
+	return instalarEjecutable(temporal.Name(), ruta)
+}
+
+func ejecutarAutoActualizacion(version string) tea.Cmd {
+	return func() tea.Msg {
+		_, err := autoActualizar(version)
+		return autoActualizacionMsg{version: version, err: err}
+	}
+}
+
+func (m Model) accionActualizar() (tea.Model, tea.Cmd) {
+	switch {
+	case m.actualizado != "":
+		m.reiniciar = true
+		return m.salir()
+	case m.hayActualizacion && !m.actualizando:
+		m.actualizando = true
+		m.mensaje = IconInfo(T("version.descargando", m.versionNueva))
+		return m, ejecutarAutoActualizacion(m.versionNueva)
+	}
+	return m, nil
+}
+
+func cliAutoActualizar(args []string) int {
+	var version string
+	banderas := flag.NewFlagSet("self-update", flag.ContinueOnError)
+	banderas.StringVar(&version, "version", "", T("cli.flag.version"))
+	if err := banderas.Parse(args); err != nil {
+		return 2
+	}
+
+	if version == "" {
+		ultima, err := consultarUltimaVersion()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, IconError(T("version.error_consulta", err)))
+			return 1
+		}
+		if !compararVersiones(Version, ultima) {
+			fmt.Println(IconSuccess(T("version.al_dia", Version)))
+			return 0
+		}
+		version = ultima
+	}
+
+	fmt.Println(Icons.Download + " " + T("version.descargando", version))
+	ruta, err := autoActualizar(version)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, IconError(err.Error()))
+		return 1
+	}
+
+	fmt.Println(IconSuccess(T("version.instalada", version, ruta)))
+	return 0
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const variableNpmFalso = "SHO_NPM_FALSO"
+
+func TestNpmFalso(t *testing.T) {
+	codigo := os.Getenv(variableNpmFalso)
+	if codigo == "" {
+		return
+	}
+	fmt.Println("npm ERR! código", codigo)
+	if codigo != "0" {
+		os.Exit(1)
+	}
+	os.Exit(0)
+}
+
+func sumaHex(datos []byte) string {
+	suma := sha256.Sum256(datos)
+	return hex.EncodeToString(suma[:])
+}
+
+func TestChecksumEsperado(t *testing.T) {
+	casos := []struct {
+		nombre    string
+		checksums string
+		esperado  string
+		ok        bool
+	}{
+		{"coincide", "abc123  shopify-tui-linux-x64\n", "abc123", true},
+		{"modo binario", "abc123 *shopify-tui-linux-x64\n", "abc123", true},
+		{"mayúsculas", "ABC123  shopify-tui-linux-x64\n", "abc123", true},
+		{"entre otros", "111  shopify-tui-darwin-arm64\nabc123  shopify-tui-linux-x64\n222  shopify-tui-win32-x64.exe\n", "abc123", true},
+		{"falta el archivo", "111  shopify-tui-darwin-arm64\n", "", false},
+		{"prefijo de otro", "111  shopify-tui-linux-x64.exe\n", "", false},
+		{"sin suma", "shopify-tui-linux-x64\n", "", false},
+		{"campos de más", "abc123  shopify-tui-linux-x64  extra\n", "", false},
+		{"vacío", "", "", false},
+		{"basura", "<html>404 Not Found</html>", "", false},
+	}
+
+	for _, caso := range casos {
+		esperado, err := checksumEsperado([]byte(caso.checksums), "shopify-tui-linux-x64")
+		if (err == nil) != caso.ok || esperado != caso.esperado {
+			t.Errorf("%s: checksumEsperado = %q, %v; se esperaba %q, ok=%v", caso.nombre, esperado, err, caso.esperado, caso.ok)
+		}
+	}
+}
+
+type releaseFalsa struct {
+	archivos map[string][]byte
+	mutex    sync.Mutex
+	pedidos  []string
+}
+
+func usarReleaseFalsa(t *testing.T, ruta string, archivos map[string][]byte) *releaseFalsa {
+	t.Helper()
+
+	release := &releaseFalsa{archivos: archivos}
+	servidor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		release.mutex.Lock()
+		release.pedidos = append(release.pedidos, r.URL.Path)
+		release.mutex.Unlock()
+
+		datos, existe := release.archivos[filepath.Base(r.URL.Path)]
+		if !existe || !strings.HasPrefix(r.URL.Path, "/v1.2.3/") {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(datos)
+	}))
+
+	urlAnterior, ejecutableAnterior := urlReleases, ejecutableActual
+	urlReleases = servidor.URL
+	ejecutableActual = func() (string, error) { return ruta, nil }
+	t.Cleanup(func() {
+		servidor.Close()
+		urlReleases, ejecutableActual = urlAnterior, ejecutableAnterior
+	})
+	return release
+}
+
+func ejecutableFalso(t *testing.T) string {
+	t.Helper()
+
+	ruta := filepath.Join(t.TempDir(), "sho")
+	if err := os.WriteFile(ruta, []byte("viejo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	return ruta
+}
+
+func comprobarIntacto(t *testing.T, ruta string) {
+	t.Helper()
+
+	datos, err := os.ReadFile(ruta)
+	if err != nil || string(datos) != "viejo" {
+		t.Fatalf("el ejecutable cambió: %q, %v", datos, err)
+	}
+	entradas, _ := os.ReadDir(filepath.Dir(ruta))
+	if len(entradas) != 1 {
+		t.Fatalf("quedaron %d archivos junto al ejecutable, se esperaba solo el original", len(entradas))
+	}
+}
+
+func TestAutoActualizar(t *testing.T) {
+	archivo, err := nombreBinarioRelease()
+	if err != nil {
+		t.Skip(err)
+	}
+	nuevo := []byte("binario nuevo")
+
+	casos := []struct {
+		nombre    string
+		checksums string
+		binario   []byte
+		error     string
+	}{
+		{"suma correcta", sumaHex(nuevo) + "  " + archivo + "\n", nuevo, ""},
+		{"suma distinta", sumaHex([]byte("otro")) + "  " + archivo + "\n", nuevo, T("version.checksum_invalido", archivo)},
+		{"binario manipulado", sumaHex(nuevo) + "  " + archivo + "\n", []byte("binario manipulado"), T("version.checksum_invalido", archivo)},
+		{"falta en checksums.txt", sumaHex(nuevo) + "  shopify-tui-plan9-mips\n", nuevo, T("version.sin_checksum", archivo)},
+		{"checksums.txt malformado", "{\"sha256\": \"" + sumaHex(nuevo) + "\"}", nuevo, T("version.sin_checksum", archivo)},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			ruta := ejecutableFalso(t)
+			release := usarReleaseFalsa(t, ruta, map[string][]byte{
+				archivoChecksums: []byte(caso.checksums),
+				archivo:          caso.binario,
+			})
+
+			instalado, err := autoActualizar("v1.2.3")
+			if caso.error != "" {
+				if err == nil || err.Error() != caso.error {
+					t.Fatalf("error = %v, se esperaba %q", err, caso.error)
+				}
+				comprobarIntacto(t, ruta)
+				if caso.error == T("version.sin_checksum", archivo) && len(release.pedidos) != 1 {
+					t.Fatalf("pedidos = %v, no se debe descargar el binario sin su suma", release.pedidos)
+				}
+				return
+			}
+
+			if err != nil || instalado != ruta {
+				t.Fatalf("autoActualizar = %q, %v", instalado, err)
+			}
+			datos, _ := os.ReadFile(ruta)
+			if string(datos) != string(nuevo) {
+				t.Fatalf("contenido = %q, se esperaba el binario nuevo", datos)
+			}
+			if info, _ := os.Stat(ruta); info.Mode().Perm()&0100 == 0 {
+				t.Fatalf("permisos = %v, el ejecutable debe conservar el bit de ejecución", info.Mode())
+			}
+		})
+	}
+}
+
+func TestAutoActualizarDescargaFallida(t *testing.T) {
+	ruta := ejecutableFalso(t)
+	usarReleaseFalsa(t, ruta, map[string][]byte{})
+
+	if _, err := autoActualizar("v1.2.3"); err == nil {
+		t.Fatal("se esperaba un error sin checksums.txt")
+	}
+	comprobarIntacto(t, ruta)
+}
+
+func TestAutoActualizarNpm(t *testing.T) {
+	ruta := filepath.Join(t.TempDir(), "node_modules", NpmPackageName, "bin", "sho")
+	casos := []struct {
+		nombre string
+		codigo string
+		error  bool
+	}{
+		{"npm correcto", "0", false},
+		{"npm falla", "1", true},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			release := usarReleaseFalsa(t, ruta, map[string][]byte{})
+			t.Setenv(variableNpmFalso, caso.codigo)
+
+			var argumentos []string
+			comandoAnterior := comandoNpm
+			comandoNpm = func(a ...string) *exec.Cmd {
+				argumentos = a
+				return exec.Command(os.Args[0], "-test.run=^TestNpmFalso$")
+			}
+			t.Cleanup(func() { comandoNpm = comandoAnterior })
+
+			instalado, err := autoActualizar("v1.2.3")
+			if esperados := []string{"install", "-g", NpmPackageName + "@1.2.3"}; !reflect.DeepEqual(argumentos, esperados) {
+				t.Fatalf("argumentos de npm = %q, se esperaba %q", argumentos, esperados)
+			}
+			if len(release.pedidos) != 0 {
+				t.Fatalf("pedidos = %v, una instalación con npm no debe descargar binarios", release.pedidos)
+			}
+			if caso.error {
+				if err == nil || !strings.Contains(err.Error(), "npm ERR! código 1") {
+					t.Fatalf("error = %v, se esperaba la última línea de npm", err)
+				}
+				return
+			}
+			if err != nil || instalado != ruta {
+				t.Fatalf("autoActualizar = %q, %v", instalado, err)
+			}
+		})
+	}
+}
@@ -2,34 +2,142 @@ package main
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"io"
+	"os"
 	"os/exec"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 )
 
+const maxLogsServidor = 100
+
+type LineaLog struct {
+	Momento time.Time
+	Texto   string
+	contada bool
+}
+
 type ServidorActivo struct {
 	Tienda    Tienda
 	Proceso   *exec.Cmd
 	Puerto    int
 	Iniciado  time.Time
 	URL       string
-	Activo    bool
-	Logs      []string
+	URLRed    string
+	Logs      []LineaLog
 	LogsMutex sync.RWMutex
 	Stdin     io.WriteCloser
+
+	Acompanantes []*ProcesoAcompanante
+	urlCompartir string
+	terminado    chan struct{}
+	terminal     *os.File
+	errores      int
+	descartadas  int
+	salud        Salud
+	recursos     Recursos
+	ciclo        CicloVida
+	configSalud  ConfigSalud
 }
 
 func (s *ServidorActivo) AgregarLog(linea string) {
+	s.LogsMutex.Lock()
+	s.Logs = append(s.Logs, LineaLog{Momento: time.Now(), Texto: linea, contada: true})
+	if esLineaError(linea) {
+		s.errores++
+	}
+	s.recortarLogs()
+	s.LogsMutex.Unlock()
+
+	s.detectarURL(linea)
+	ObtenerGestor().publicar(EventoLog, s.Tienda.Nombre, linea)
+}
+
+func (s *ServidorActivo) recortarLogs() {
+	if exceso := len(s.Logs) - maxLogsServidor; exceso > 0 {
+		s.Logs = s.Logs[exceso:]
+		s.descartadas += exceso
+	}
+}
+
+func (s *ServidorActivo) agregarLineaTerminal() int {
 	s.LogsMutex.Lock()
 	defer s.LogsMutex.Unlock()
 
-	s.Logs = append(s.Logs, linea)
+	s.Logs = append(s.Logs, LineaLog{Momento: time.Now()})
+	indice := s.descartadas + len(s.Logs) - 1
+	s.recortarLogs()
+	return indice
+}
+
+func (s *ServidorActivo) lineaTerminal(indice int) *LineaLog {
+	i := indice - s.descartadas
+	if i < 0 || i >= len(s.Logs) {
+		return nil
+	}
+	return &s.Logs[i]
+}
 
-	if len(s.Logs) > 100 {
-		s.Logs = s.Logs[len(s.Logs)-100:]
+func (s *ServidorActivo) escribirLineaTerminal(indice int, texto string) {
+	s.LogsMutex.Lock()
+	if linea := s.lineaTerminal(indice); linea != nil {
+		linea.Texto = texto
+		linea.Momento = time.Now()
 	}
+	s.LogsMutex.Unlock()
+
+	ObtenerGestor().publicar(EventoLog, s.Tienda.Nombre, texto)
+}
+
+func (s *ServidorActivo) textoLineaTerminal(indice int) string {
+	s.LogsMutex.RLock()
+	defer s.LogsMutex.RUnlock()
+
+	if linea := s.lineaTerminal(indice); linea != nil {
+		return linea.Texto
+	}
+	return ""
+}
+
+func (s *ServidorActivo) completarLineaTerminal(indice int) {
+	s.LogsMutex.Lock()
+	texto := ""
+	if linea := s.lineaTerminal(indice); linea != nil && !linea.contada {
+		linea.contada = true
+		texto = linea.Texto
+		if esLineaError(linea.Texto) {
+			s.errores++
+		}
+	}
+	s.LogsMutex.Unlock()
+
+	s.detectarURL(texto)
+}
+
+func (s *ServidorActivo) eliminarLineasTerminal(indices []int) {
+	s.LogsMutex.Lock()
+	defer s.LogsMutex.Unlock()
+
+	for j := len(indices) - 1; j >= 0; j-- {
+		if indices[j]-s.descartadas != len(s.Logs)-1 {
+			if linea := s.lineaTerminal(indices[j]); linea != nil {
+				linea.Texto = ""
+			}
+			continue
+		}
+		s.Logs = s.Logs[:len(s.Logs)-1]
+	}
+}
+
+func (s *ServidorActivo) EnTerminal() bool {
+	s.LogsMutex.RLock()
+	defer s.LogsMutex.RUnlock()
+
+	return s.terminal != nil
 }
 
 func (s *ServidorActivo) ObtenerLogs() []string {
@@ -37,15 +145,41 @@ func (s *ServidorActivo) ObtenerLogs() []string {
 	defer s.LogsMutex.RUnlock()
 
 	copia := make([]string, len(s.Logs))
+	for i, linea := range s.Logs {
+		copia[i] = linea.Texto
+	}
+	return copia
+}
+
+func (s *ServidorActivo) ObtenerLineas() []LineaLog {
+	s.LogsMutex.RLock()
+	defer s.LogsMutex.RUnlock()
+
+	copia := make([]LineaLog, len(s.Logs))
 	copy(copia, s.Logs)
 	return copia
 }
 
+func (s *ServidorActivo) ContarErrores() int {
+	s.LogsMutex.RLock()
+	defer s.LogsMutex.RUnlock()
+
+	return s.errores
+}
+
+func esLineaError(linea string) bool {
+	return strings.Contains(linea, "error") || strings.Contains(linea, "Error")
+}
+
 func (s *ServidorActivo) EnviarInput(input string) error {
-	if s.Stdin == nil {
-		return fmt.Errorf("stdin no disponible")
+	s.LogsMutex.RLock()
+	stdin := s.Stdin
+	s.LogsMutex.RUnlock()
+
+	if stdin == nil {
+		return errors.New(T("servidor.error_stdin_no_disponible"))
 	}
-	_, err := s.Stdin.Write([]byte(input))
+	_, err := stdin.Write([]byte(input))
 	return err
 }
 
@@ -53,11 +187,18 @@ type GestorServidores struct {
 	servidores map[string]*ServidorActivo
 	mutex      sync.RWMutex
 	puertos    map[int]bool
+	filas      int
+	columnas   int
+
+	suscriptores []*suscripcion
+	eventosMutex sync.Mutex
 }
 
 var gestorGlobal = &GestorServidores{
 	servidores: make(map[string]*ServidorActivo),
 	puertos:    make(map[int]bool),
+	filas:      24,
+	columnas:   80,
 }
 
 func ObtenerGestor() *GestorServidores {
@@ -76,11 +217,15 @@ func (g *GestorServidores) ObtenerPuertoDisponible() int {
 }
 
 func (g *GestorServidores) IniciarServidor(tienda Tienda) (*ServidorActivo, error) {
+	return g.iniciarServidor(tienda, make([]LineaLog, 0), 0)
+}
+
+func (g *GestorServidores) iniciarServidor(tienda Tienda, previos []LineaLog, errores int) (*ServidorActivo, error) {
 	g.mutex.Lock()
 	defer g.mutex.Unlock()
 
-	if servidor, existe := g.servidores[tienda.Nombre]; existe && servidor.Activo {
-		return nil, fmt.Errorf("ya hay un servidor activo para '%s'", tienda.Nombre)
+	if servidor, existe := g.servidores[tienda.Nombre]; existe && servidor.Activo() {
+		return nil, errors.New(T("servidor.error_ya_activo", tienda.Nombre))
 	}
 
 	puerto := 9292
@@ -88,71 +233,221 @@ func (g *GestorServidores) IniciarServidor(tienda Tienda) (*ServidorActivo, erro
 		puerto++
 	}
 
-	cmd := exec.Command("shopify", "theme", "dev",
+	argumentos := []string{"theme", "dev",
 		"--store", tienda.URL,
 		"--port", fmt.Sprintf("%d", puerto),
-	)
+	}
+
+	urlRed := ""
+	if tienda.ModoRed {
+		ip, err := detectarIPLocal()
+		if err != nil {
+			return nil, err
+		}
+		argumentos = append(argumentos, "--host", "0.0.0.0")
+		urlRed = fmt.Sprintf("http://%s:%d", ip, puerto)
+	}
+
+	cmd := comandoServidor(argumentos...)
 	cmd.Dir = tienda.Ruta
+	configurarGrupoProcesos(cmd)
 
 	servidor := &ServidorActivo{
-		Tienda:   tienda,
-		Proceso:  cmd,
-		Puerto:   puerto,
-		Iniciado: time.Now(),
-		URL:      fmt.Sprintf("http://127.0.0.1:%d", puerto),
-		Activo:   true,
-		Logs:     make([]string, 0),
+		Tienda:    tienda,
+		Proceso:   cmd,
+		Puerto:    puerto,
+		Iniciado:  time.Now(),
+		URL:       fmt.Sprintf("http://127.0.0.1:%d", puerto),
+		URLRed:    urlRed,
+		Logs:      previos,
+		errores:   errores,
+		terminado: make(chan struct{}),
+		salud:     Salud{Estado: saludIniciando},
+		ciclo:     CicloVida{Estado: EstadoIniciando},
+
+		configSalud: SaludGlobal,
+	}
+	servidor.recortarLogs()
+
+	var salidas []io.Reader
+	if len(comandosGancho(ganchoAntesIniciar, tienda)) == 0 {
+		var err error
+		if salidas, err = g.iniciarProceso(servidor); err != nil {
+			return nil, err
+		}
+	}
+
+	g.servidores[tienda.Nombre] = servidor
+	g.puertos[puerto] = true
+
+	go g.arrancar(servidor, salidas)
+
+	g.publicar(EventoIniciado, tienda.Nombre, servidor.URL)
+	return servidor, nil
+}
+
+func (g *GestorServidores) iniciarProceso(servidor *ServidorActivo) ([]io.Reader, error) {
+	cmd := servidor.Proceso
+
+	if terminalDisponible {
+		terminal, err := iniciarEnTerminal(cmd, g.filas, g.columnas)
+		if err != nil {
+			return nil, errors.New(T("servidor.error_iniciar", err))
+		}
+
+		servidor.LogsMutex.Lock()
+		servidor.terminal = terminal
+		servidor.Stdin = terminal
+		servidor.LogsMutex.Unlock()
+		return []io.Reader{terminal}, nil
 	}
 
 	stdin, err := cmd.StdinPipe()
 	if err != nil {
-		return nil, fmt.Errorf("error al capturar stdin: %v", err)
+		return nil, errors.New(T("servidor.error_stdin", err))
 	}
-	servidor.Stdin = stdin
 
 	stdout, err := cmd.StdoutPipe()
 	if err != nil {
-		return nil, fmt.Errorf("error al capturar stdout: %v", err)
+		return nil, errors.New(T("servidor.error_stdout", err))
 	}
 
 	stderr, err := cmd.StderrPipe()
 	if err != nil {
-		return nil, fmt.Errorf("error al capturar stderr: %v", err)
+		return nil, errors.New(T("servidor.error_stderr", err))
 	}
 
 	if err := cmd.Start(); err != nil {
-		return nil, fmt.Errorf("error al iniciar servidor: %v", err)
+		return nil, errors.New(T("servidor.error_iniciar", err))
 	}
 
-	g.servidores[tienda.Nombre] = servidor
-	g.puertos[puerto] = true
+	servidor.LogsMutex.Lock()
+	servidor.Stdin = stdin
+	servidor.LogsMutex.Unlock()
+	return []io.Reader{stdout, stderr}, nil
+}
 
-	go func() {
-		leerLogs(stdout, servidor)
-	}()
-
-	go func() {
-		leerLogs(stderr, servidor)
-	}()
-
-	go func() {
-		cmd.Wait()
-		g.mutex.Lock()
-		defer g.mutex.Unlock()
-		if s, ok := g.servidores[tienda.Nombre]; ok {
-			s.Activo = false
-			s.AgregarLog("--- Servidor detenido ---")
-			delete(g.puertos, s.Puerto)
+func (g *GestorServidores) arrancar(servidor *ServidorActivo, salidas []io.Reader) {
+	tienda := servidor.Tienda
+	cmd := servidor.Proceso
+
+	if salidas == nil {
+		var err error
+		if salidas, err = g.iniciarTrasGanchos(servidor); err != nil {
+			servidor.AgregarLog(IconError(err.Error()))
+			servidor.AgregarLog("--- " + T("ganchos.abortado") + " ---")
+			estado, _ := g.finalizar(servidor, err)
+			ObtenerHistorial().Registrar(tienda.Nombre, accionIniciar, servidor.Iniciado, err)
+			g.publicar(eventosEstado[estado], tienda.Nombre, err.Error())
+			close(servidor.terminado)
+			return
 		}
-	}()
+	}
 
-	return servidor, nil
+	enTerminal := servidor.EnTerminal()
+	var lectores sync.WaitGroup
+	for _, salida := range salidas {
+		lectores.Add(1)
+		go func(salida io.Reader) {
+			defer lectores.Done()
+			if enTerminal {
+				leerTerminal(salida, servidor)
+			} else {
+				leerLogs(salida, servidor, "")
+			}
+		}(salida)
+	}
+
+	if servidor.configSalud.Desactivada {
+		g.cambiarEstado(servidor, EstadoListo)
+	}
+	g.iniciarAcompanantes(servidor)
+	go g.vigilarSalud(servidor)
+	go g.vigilarRecursos(servidor)
+
+	if err := ejecutarGanchosServidor(ganchoDespuesIniciar, servidor, nil); err != nil {
+		servidor.AgregarLog(IconWarning(err.Error()))
+	}
+
+	lectores.Wait()
+	err := cmd.Wait()
+	if enTerminal {
+		servidor.LogsMutex.Lock()
+		servidor.terminal.Close()
+		servidor.LogsMutex.Unlock()
+	}
+
+	g.mutex.Lock()
+	g.detenerAcompanantes(servidor)
+	g.mutex.Unlock()
+
+	estado, err := g.finalizar(servidor, err)
+	defer close(servidor.terminado)
+
+	evento := ganchoAlDetener
+	codigo := servidor.CicloVida().Codigo
+	if estado == EstadoCaido {
+		evento = ganchoAlFallar
+		servidor.AgregarLog("--- " + T("servidor.caido", codigo) + " ---")
+	} else {
+		servidor.AgregarLog("--- " + T("servidor.detenido") + " ---")
+	}
+	if errGancho := ejecutarGanchosServidor(evento, servidor, &codigo); errGancho != nil {
+		servidor.AgregarLog(IconWarning(errGancho.Error()))
+	}
+
+	ObtenerHistorial().Registrar(tienda.Nombre, accionIniciar, servidor.Iniciado, err)
+	if err != nil {
+		g.publicar(eventosEstado[estado], tienda.Nombre, err.Error())
+		return
+	}
+	g.publicar(eventosEstado[estado], tienda.Nombre, string(estado))
+}
+
+func (g *GestorServidores) iniciarTrasGanchos(servidor *ServidorActivo) ([]io.Reader, error) {
+	if err := ejecutarGanchosServidor(ganchoAntesIniciar, servidor, nil); err != nil {
+		return nil, err
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if !servidor.Activo() {
+		return nil, errors.New(T("ganchos.cancelado"))
+	}
+	return g.iniciarProceso(servidor)
+}
+
+func (g *GestorServidores) Redimensionar(filas, columnas int) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.filas, g.columnas = filas, columnas
+	for _, servidor := range g.servidores {
+		if !servidor.Activo() {
+			continue
+		}
+		servidor.LogsMutex.RLock()
+		if servidor.terminal != nil {
+			redimensionarTerminal(servidor.terminal, filas, columnas)
+		}
+		servidor.LogsMutex.RUnlock()
+	}
+}
+
+func (g *GestorServidores) finalizar(servidor *ServidorActivo, err error) (EstadoServidor, error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	estado, err := servidor.registrarSalida(err)
+	delete(g.puertos, servidor.Puerto)
+	return estado, err
 }
 
-func leerLogs(pipe io.Reader, servidor *ServidorActivo) {
+func leerLogs(pipe io.Reader, servidor *ServidorActivo, prefijo string) {
 	scanner := bufio.NewScanner(pipe)
 	for scanner.Scan() {
-		servidor.AgregarLog(scanner.Text())
+		servidor.AgregarLog(prefijo + scanner.Text())
 	}
 }
 
@@ -162,36 +457,77 @@ func (g *GestorServidores) DetenerServidor(nombreTienda string) error {
 
 	servidor, existe := g.servidores[nombreTienda]
 	if !existe {
-		return fmt.Errorf("no hay servidor para '%s'", nombreTienda)
+		return errors.New(T("servidor.error_no_existe", nombreTienda))
 	}
 
-	if !servidor.Activo {
-		return fmt.Errorf("el servidor de '%s' ya está detenido", nombreTienda)
+	if !g.cambiarEstado(servidor, EstadoDeteniendo) {
+		return errors.New(T("servidor.error_ya_detenido", nombreTienda))
 	}
 
 	if servidor.Proceso != nil && servidor.Proceso.Process != nil {
-		if err := servidor.Proceso.Process.Kill(); err != nil {
-			return fmt.Errorf("error al detener servidor: %v", err)
+		if err := terminarGrupo(servidor.Proceso); err != nil {
+			return errors.New(T("servidor.error_detener", err))
 		}
 	}
-
-	servidor.Activo = false
-	delete(g.puertos, servidor.Puerto)
+	g.detenerAcompanantes(servidor)
 
 	return nil
 }
 
+func (g *GestorServidores) ReiniciarServidor(tienda Tienda) (*ServidorActivo, error) {
+	g.mutex.RLock()
+	anterior, existe := g.servidores[tienda.Nombre]
+	activo := existe && anterior.Activo()
+	g.mutex.RUnlock()
+
+	if activo {
+		if err := g.DetenerServidor(tienda.Nombre); err != nil {
+			return nil, err
+		}
+	}
+	if existe {
+		select {
+		case <-anterior.terminado:
+		case <-time.After(10 * time.Second):
+		}
+	}
+
+	if !existe {
+		return g.IniciarServidor(tienda)
+	}
+
+	previos := append(anterior.ObtenerLineas(), LineaLog{Momento: time.Now(), Texto: "--- " + T("servidor.reiniciado") + " ---", contada: true})
+	return g.iniciarServidor(tienda, previos, anterior.ContarErrores())
+}
+
 func (g *GestorServidores) DetenerTodos() {
 	g.mutex.Lock()
 	defer g.mutex.Unlock()
 
 	for _, servidor := range g.servidores {
-		if servidor.Activo && servidor.Proceso != nil && servidor.Proceso.Process != nil {
-			servidor.Proceso.Process.Kill()
-			servidor.Activo = false
+		if servidor.Proceso != nil && servidor.Proceso.Process != nil && g.cambiarEstado(servidor, EstadoDeteniendo) {
+			terminarGrupo(servidor.Proceso)
+			g.detenerAcompanantes(servidor)
+		}
+	}
+}
+
+func (g *GestorServidores) EsperarDetenidos(limite time.Duration) {
+	g.mutex.RLock()
+	pendientes := make([]chan struct{}, 0, len(g.servidores))
+	for _, servidor := range g.servidores {
+		pendientes = append(pendientes, servidor.terminado)
+	}
+	g.mutex.RUnlock()
+
+	expira := time.After(limite)
+	for _, terminado := range pendientes {
+		select {
+		case <-terminado:
+		case <-expira:
+			return
 		}
 	}
-	g.puertos = make(map[int]bool)
 }
 
 func (g *GestorServidores) ObtenerServidoresActivos() []*ServidorActivo {
@@ -200,10 +536,13 @@ func (g *GestorServidores) ObtenerServidoresActivos() []*ServidorActivo {
 
 	var activos []*ServidorActivo
 	for _, servidor := range g.servidores {
-		if servidor.Activo {
+		if servidor.Activo() {
 			activos = append(activos, servidor)
 		}
 	}
+	sort.Slice(activos, func(i, j int) bool {
+		return activos[i].Tienda.Nombre < activos[j].Tienda.Nombre
+	})
 	return activos
 }
 
@@ -213,7 +552,7 @@ func (g *GestorServidores) ContarActivos() int {
 
 	count := 0
 	for _, servidor := range g.servidores {
-		if servidor.Activo {
+		if servidor.Activo() {
 			count++
 		}
 	}
@@ -225,7 +564,7 @@ func (g *GestorServidores) TieneServidorActivo(nombreTienda string) bool {
 	defer g.mutex.RUnlock()
 
 	if servidor, existe := g.servidores[nombreTienda]; existe {
-		return servidor.Activo
+		return servidor.Activo()
 	}
 	return false
 }
@@ -234,8 +573,17 @@ func (g *GestorServidores) ObtenerServidor(nombreTienda string) *ServidorActivo
 	g.mutex.RLock()
 	defer g.mutex.RUnlock()
 
-	if servidor, existe := g.servidores[nombreTienda]; existe && servidor.Activo {
-		return servidor
+	return g.servidores[nombreTienda]
+}
+
+func (g *GestorServidores) Descartar(nombreTienda string) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	servidor, existe := g.servidores[nombreTienda]
+	if !existe || !servidor.Estado().Final() {
+		return false
 	}
-	return nil
+	delete(g.servidores, nombreTienda)
+	return true
 }
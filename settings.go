@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+type Ajustes struct {
+	Idioma  string              `json:"idioma,omitempty"`
+	Atajos  map[string][]string `json:"atajos,omitempty"`
+	Tema    string              `json:"tema,omitempty"`
+	Paletas map[string]Paleta   `json:"paletas,omitempty"`
+	Orden   string              `json:"orden,omitempty"`
+	Ganchos Ganchos             `json:"ganchos,omitempty"`
+	Salud   ConfigSalud         `json:"salud,omitempty"`
+	Proxy   ConfigProxy         `json:"proxy,omitempty"`
+	Lotes   ConfigLotes         `json:"lotes,omitempty"`
+
+	Actualizaciones ConfigActualizaciones `json:"actualizaciones,omitempty"`
+}
+
+const (
+	ordenManual   = "manual"
+	ordenReciente = "reciente"
+)
+
+func obtenerRutaAjustes() (string, error) {
+	dirBase, err := obtenerDirectorioBase()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dirBase, "settings.json"), nil
+}
+
+func cargarAjustes() (Ajustes, error) {
+	rutaArchivo, err := obtenerRutaAjustes()
+	if err != nil {
+		return Ajustes{}, err
+	}
+
+	datos, err := os.ReadFile(rutaArchivo)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return Ajustes{}, nil
+		}
+		return Ajustes{}, err
+	}
+
+	var ajustes Ajustes
+	if err := json.Unmarshal(datos, &ajustes); err != nil {
+		return Ajustes{}, err
+	}
+
+	return ajustes, nil
+}
@@ -143,7 +143,12 @@ func guardarTiendas(tiendas []Tienda) error {
 		return err
 	}
 
-	return os.WriteFile(rutaArchivo, datos, 0644)
+	if err := os.WriteFile(rutaArchivo, datos, 0644); err != nil {
+		return err
+	}
+
+	ObtenerProxy().ActualizarTiendas(tiendas)
+	return nil
 }
 
 func eliminarTienda(tiendas []Tienda, indice int) []Tienda {
@@ -160,3 +165,65 @@ func existeDirectorio(ruta string) bool {
 	}
 	return info.IsDir()
 }
+
+func normalizarEtiquetas(texto string) []string {
+	var etiquetas []string
+	vistas := make(map[string]bool)
+
+	for _, campo := range strings.FieldsFunc(texto, func(r rune) bool {
+		return r == ',' || r == ' '
+	}) {
+		etiqueta := strings.ToLower(strings.TrimPrefix(campo, "#"))
+		if etiqueta == "" || vistas[etiqueta] {
+			continue
+		}
+		vistas[etiqueta] = true
+		etiquetas = append(etiquetas, etiqueta)
+	}
+
+	return etiquetas
+}
+
+func (t Tienda) tieneEtiqueta(etiqueta string) bool {
+	for _, e := range t.Etiquetas {
+		if strings.EqualFold(e, etiqueta) {
+			return true
+		}
+	}
+	return false
+}
+
+type FiltroTiendas struct {
+	Etiquetas []string
+	Grupo     string
+	Favoritas bool
+}
+
+func (f FiltroTiendas) vacio() bool {
+	return len(f.Etiquetas) == 0 && f.Grupo == "" && !f.Favoritas
+}
+
+func (f FiltroTiendas) coincide(t Tienda) bool {
+	for _, etiqueta := range f.Etiquetas {
+		if !t.tieneEtiqueta(etiqueta) {
+			return false
+		}
+	}
+	if f.Grupo != "" && !strings.EqualFold(t.Grupo, f.Grupo) {
+		return false
+	}
+	if f.Favoritas && !t.Favorita {
+		return false
+	}
+	return true
+}
+
+func filtrarTiendasPor(tiendas []Tienda, filtro FiltroTiendas) []Tienda {
+	var resultado []Tienda
+	for _, t := range tiendas {
+		if filtro.coincide(t) {
+			resultado = append(resultado, t)
+		}
+	}
+	return resultado
+}
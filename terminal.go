@@ -0,0 +1,299 @@
+package main
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const maxLineasTerminal = 200
+
+// Los servidores se leen como líneas de log y no con vt10x (pane.go): vt10x solo
+// conserva la pantalla visible, y los logs necesitan historial, búsqueda,
+// conteo de errores y detección de URLs sobre cada línea completa.
+type procesadorTerminal struct {
+	servidor  *ServidorActivo
+	propias   []int
+	pos       int
+	linea     string
+	retorno   bool
+	sucio     bool
+	pendiente []byte
+}
+
+func nuevoProcesadorTerminal(servidor *ServidorActivo) *procesadorTerminal {
+	return &procesadorTerminal{servidor: servidor, pos: -1}
+}
+
+func leerTerminal(lector io.Reader, servidor *ServidorActivo) {
+	procesador := nuevoProcesadorTerminal(servidor)
+	bufer := make([]byte, 4096)
+	for {
+		n, err := lector.Read(bufer)
+		if n > 0 {
+			procesador.procesar(bufer[:n])
+		}
+		if err != nil {
+			break
+		}
+	}
+	procesador.cerrar()
+}
+
+func (p *procesadorTerminal) procesar(bloque []byte) {
+	datos := append(p.pendiente, bloque...)
+	p.pendiente = nil
+
+	for i := 0; i < len(datos); {
+		c := datos[i]
+		switch {
+		case c == 0x1b:
+			n, completa := p.escape(datos[i:])
+			if !completa {
+				p.pendiente = append([]byte{}, datos[i:]...)
+				p.volcar()
+				return
+			}
+			i += n
+		case c == '\r':
+			p.retorno = true
+			i++
+		case c == '\n':
+			p.saltoLinea()
+			i++
+		case c == '\b':
+			if p.linea != "" {
+				_, tam := utf8.DecodeLastRuneInString(p.linea)
+				p.linea = p.linea[:len(p.linea)-tam]
+				p.sucio = true
+			}
+			i++
+		case c == '\t':
+			p.escribir("\t")
+			i++
+		case c < 0x20 || c == 0x7f:
+			i++
+		default:
+			if !utf8.FullRune(datos[i:]) {
+				p.pendiente = append([]byte{}, datos[i:]...)
+				p.volcar()
+				return
+			}
+			r, tam := utf8.DecodeRune(datos[i:])
+			p.escribir(string(r))
+			i += tam
+		}
+	}
+	p.volcar()
+}
+
+func (p *procesadorTerminal) escape(seq []byte) (int, bool) {
+	if len(seq) < 2 {
+		return 0, false
+	}
+
+	switch seq[1] {
+	case '[':
+		for j := 2; j < len(seq); j++ {
+			if seq[j] >= 0x40 && seq[j] <= 0x7e {
+				p.csi(string(seq[2:j]), seq[j], string(seq[:j+1]))
+				return j + 1, true
+			}
+		}
+		if len(seq) > 64 {
+			return len(seq), true
+		}
+		return 0, false
+	case ']':
+		for j := 2; j < len(seq); j++ {
+			if seq[j] == 0x07 {
+				return j + 1, true
+			}
+			if seq[j] == 0x1b && j+1 < len(seq) && seq[j+1] == '\\' {
+				return j + 2, true
+			}
+		}
+		if len(seq) > 1024 {
+			return len(seq), true
+		}
+		return 0, false
+	case '(', ')':
+		if len(seq) < 3 {
+			return 0, false
+		}
+		return 3, true
+	}
+	return 2, true
+}
+
+func (p *procesadorTerminal) csi(parametros string, final byte, secuencia string) {
+	cantidad := 1
+	if n, err := strconv.Atoi(parametros); err == nil && n > 0 {
+		cantidad = n
+	}
+
+	switch final {
+	case 'm':
+		p.escribir(secuencia)
+	case 'A':
+		p.mover(-cantidad)
+	case 'B':
+		p.mover(cantidad)
+	case 'G':
+		if cantidad <= 1 {
+			p.retorno = true
+		}
+	case 'K':
+		if parametros == "1" || parametros == "2" || p.retorno {
+			p.linea = ""
+			p.sucio = true
+		}
+	case 'J':
+		if parametros == "" || parametros == "0" {
+			p.borrarAbajo()
+		}
+	}
+}
+
+func (p *procesadorTerminal) asegurarLinea() {
+	if p.pos >= 0 {
+		return
+	}
+	p.propias = append(p.propias, p.servidor.agregarLineaTerminal())
+	if len(p.propias) > maxLineasTerminal {
+		p.propias = p.propias[len(p.propias)-maxLineasTerminal:]
+	}
+	p.pos = len(p.propias) - 1
+	p.linea = ""
+}
+
+func (p *procesadorTerminal) escribir(texto string) {
+	p.asegurarLinea()
+	if p.retorno {
+		p.linea = ""
+		p.retorno = false
+	}
+	p.linea += texto
+	p.sucio = true
+}
+
+func (p *procesadorTerminal) saltoLinea() {
+	p.asegurarLinea()
+	p.volcar()
+	p.servidor.completarLineaTerminal(p.propias[p.pos])
+	p.retorno = true
+
+	if p.pos < len(p.propias)-1 {
+		p.pos++
+		p.linea = p.servidor.textoLineaTerminal(p.propias[p.pos])
+		return
+	}
+	p.pos = -1
+	p.linea = ""
+}
+
+func (p *procesadorTerminal) mover(desplazamiento int) {
+	p.volcar()
+	if len(p.propias) == 0 {
+		return
+	}
+
+	base := p.pos
+	if base < 0 {
+		base = len(p.propias)
+	}
+	destino := base + desplazamiento
+	if destino < 0 {
+		destino = 0
+	}
+	if destino >= len(p.propias) {
+		p.pos = -1
+		p.linea = ""
+		return
+	}
+	p.pos = destino
+	p.linea = p.servidor.textoLineaTerminal(p.propias[p.pos])
+}
+
+func (p *procesadorTerminal) borrarAbajo() {
+	p.volcar()
+	if p.pos < 0 {
+		return
+	}
+	p.servidor.eliminarLineasTerminal(p.propias[p.pos+1:])
+	p.propias = p.propias[:p.pos+1]
+}
+
+func (p *procesadorTerminal) volcar() {
+	if !p.sucio || p.pos < 0 {
+		return
+	}
+	p.servidor.escribirLineaTerminal(p.propias[p.pos], p.linea)
+	p.sucio = false
+}
+
+func (p *procesadorTerminal) cerrar() {
+	p.volcar()
+	if p.pos >= 0 {
+		p.servidor.completarLineaTerminal(p.propias[p.pos])
+	}
+}
+
+func secuenciaTecla(msg tea.KeyMsg, enTerminal bool) string {
+	if !enTerminal {
+		switch msg.String() {
+		case "enter":
+			return "\n"
+		case "backspace":
+			return "\b"
+		case "tab":
+			return "\t"
+		case " ", "space":
+			return " "
+		}
+		if msg.Type == tea.KeyRunes && !msg.Alt {
+			return string(msg.Runes)
+		}
+		return ""
+	}
+
+	switch msg.Type {
+	case tea.KeyRunes:
+		if msg.Alt {
+			return "\x1b" + string(msg.Runes)
+		}
+		return string(msg.Runes)
+	case tea.KeySpace:
+		return " "
+	case tea.KeyUp:
+		return "\x1b[A"
+	case tea.KeyDown:
+		return "\x1b[B"
+	case tea.KeyRight:
+		return "\x1b[C"
+	case tea.KeyLeft:
+		return "\x1b[D"
+	case tea.KeyHome:
+		return "\x1b[H"
+	case tea.KeyEnd:
+		return "\x1b[F"
+	case tea.KeyDelete:
+		return "\x1b[3~"
+	case tea.KeyShiftTab:
+		return "\x1b[Z"
+	}
+	if msg.Type >= 0 && msg.Type < 0x80 {
+		return string(rune(msg.Type))
+	}
+	return ""
+}
+
+func cerrarEstilos(linea string) string {
+	if strings.Contains(linea, "\x1b[") {
+		return linea + "\x1b[0m"
+	}
+	return linea
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestSecuenciaTecla(t *testing.T) {
+	casos := []struct {
+		nombre     string
+		tecla      tea.KeyMsg
+		enTerminal bool
+		esperada   string
+	}{
+		{"texto por pipe", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")}, false, "y"},
+		{"enter por pipe", tea.KeyMsg{Type: tea.KeyEnter}, false, "\n"},
+		{"tab por pipe", tea.KeyMsg{Type: tea.KeyTab}, false, "\t"},
+		{"espacio por pipe", tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}, false, " "},
+		{"flecha por pipe", tea.KeyMsg{Type: tea.KeyUp}, false, ""},
+		{"alt por pipe", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x"), Alt: true}, false, ""},
+		{"ctrl+c por pipe", tea.KeyMsg{Type: tea.KeyCtrlC}, false, ""},
+
+		{"texto", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")}, true, "q"},
+		{"alt+texto", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x"), Alt: true}, true, "\x1bx"},
+		{"espacio", tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}, true, " "},
+		{"enter", tea.KeyMsg{Type: tea.KeyEnter}, true, "\r"},
+		{"escape", tea.KeyMsg{Type: tea.KeyEscape}, true, "\x1b"},
+		{"borrar", tea.KeyMsg{Type: tea.KeyBackspace}, true, "\x7f"},
+		{"ctrl+c", tea.KeyMsg{Type: tea.KeyCtrlC}, true, "\x03"},
+		{"ctrl+t", tea.KeyMsg{Type: tea.KeyCtrlT}, true, "\x14"},
+		{"arriba", tea.KeyMsg{Type: tea.KeyUp}, true, "\x1b[A"},
+		{"abajo", tea.KeyMsg{Type: tea.KeyDown}, true, "\x1b[B"},
+		{"derecha", tea.KeyMsg{Type: tea.KeyRight}, true, "\x1b[C"},
+		{"izquierda", tea.KeyMsg{Type: tea.KeyLeft}, true, "\x1b[D"},
+		{"inicio", tea.KeyMsg{Type: tea.KeyHome}, true, "\x1b[H"},
+		{"fin", tea.KeyMsg{Type: tea.KeyEnd}, true, "\x1b[F"},
+		{"suprimir", tea.KeyMsg{Type: tea.KeyDelete}, true, "\x1b[3~"},
+		{"shift+tab", tea.KeyMsg{Type: tea.KeyShiftTab}, true, "\x1b[Z"},
+		{"tecla de función", tea.KeyMsg{Type: tea.KeyF1}, true, ""},
+	}
+
+	for _, caso := range casos {
+		if got := secuenciaTecla(caso.tecla, caso.enTerminal); got != caso.esperada {
+			t.Errorf("%s: secuenciaTecla(%q, %v) = %q, se esperaba %q", caso.nombre, caso.tecla.String(), caso.enTerminal, got, caso.esperada)
+		}
+	}
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+type Paleta struct {
+	Base       string `json:"base,omitempty"`
+	Primario   string `json:"primario,omitempty"`
+	Exito      string `json:"exito,omitempty"`
+	Error      string `json:"error,omitempty"`
+	Atajo      string `json:"atajo,omitempty"`
+	Texto      string `json:"texto,omitempty"`
+	Tenue      string `json:"tenue,omitempty"`
+	Aviso      string `json:"aviso,omitempty"`
+	Enlace     string `json:"enlace,omitempty"`
+	Comando    string `json:"comando,omitempty"`
+	FondoPopup string `json:"fondo_popup,omitempty"`
+
+	FondoAgregado  string `json:"fondo_agregado,omitempty"`
+	FondoEliminado string `json:"fondo_eliminado,omitempty"`
+}
+
+var PaletaOscura = Paleta{
+	Primario:   "#7D56F4",
+	Exito:      "#04B575",
+	Error:      "#FF6B6B",
+	Atajo:      "#FFB800",
+	Texto:      "#FFFFFF",
+	Tenue:      "#626262",
+	Aviso:      "#FFD700",
+	Enlace:     "#00BFFF",
+	Comando:    "#00FF00",
+	FondoPopup: "#1a1a2e",
+
+	FondoAgregado:  "#12361F",
+	FondoEliminado: "#3D1518",
+}
+
+var PaletaClara = Paleta{
+	Primario:   "#5A3FC0",
+	Exito:      "#027A48",
+	Error:      "#C62828",
+	Atajo:      "#A35F00",
+	Texto:      "#1A1A1A",
+	Tenue:      "#6B6B6B",
+	Aviso:      "#8A6D00",
+	Enlace:     "#0062A3",
+	Comando:    "#1B7F1B",
+	FondoPopup: "#F2F0FA",
+
+	FondoAgregado:  "#DAFBE1",
+	FondoEliminado: "#FFEBE9",
+}
+
+var PaletaAltoContraste = Paleta{
+	Primario:   "14",
+	Exito:      "10",
+	Error:      "9",
+	Atajo:      "11",
+	Texto:      "15",
+	Tenue:      "7",
+	Aviso:      "11",
+	Enlace:     "14",
+	Comando:    "10",
+	FondoPopup: "0",
+
+	FondoAgregado:  "22",
+	FondoEliminado: "52",
+}
+
+var PaletaSinColor = Paleta{}
+
+var Temas = map[string]Paleta{
+	"dark":          PaletaOscura,
+	"light":         PaletaClara,
+	"high-contrast": PaletaAltoContraste,
+}
+
+var Tema = PaletaOscura
+
+func (p Paleta) completar(base Paleta) Paleta {
+	campos := []struct {
+		destino *string
+		origen  string
+	}{
+		{&p.Primario, base.Primario},
+		{&p.Exito, base.Exito},
+		{&p.Error, base.Error},
+		{&p.Atajo, base.Atajo},
+		{&p.Texto, base.Texto},
+		{&p.Tenue, base.Tenue},
+		{&p.Aviso, base.Aviso},
+		{&p.Enlace, base.Enlace},
+		{&p.Comando, base.Comando},
+		{&p.FondoPopup, base.FondoPopup},
+		{&p.FondoAgregado, base.FondoAgregado},
+		{&p.FondoEliminado, base.FondoEliminado},
+	}
+
+	for _, c := range campos {
+		if *c.destino == "" {
+			*c.destino = c.origen
+		}
+	}
+	p.Base = ""
+	return p
+}
+
+func resolverTema(nombre string, paletas map[string]Paleta) (Paleta, error) {
+	if nombre == "" {
+		nombre = "dark"
+	}
+
+	if paleta, existe := paletas[nombre]; existe {
+		base := paleta.Base
+		if base == "" {
+			base = "dark"
+		}
+		if base == nombre {
+			return Paleta{}, errors.New(T("tema.base_circular", nombre))
+		}
+		paletaBase, existe := Temas[base]
+		if !existe {
+			return Paleta{}, errors.New(T("tema.base_desconocida", nombre, base))
+		}
+		return paleta.completar(paletaBase), nil
+	}
+
+	if paleta, existe := Temas[nombre]; existe {
+		return paleta, nil
+	}
+
+	disponibles := make([]string, 0, len(Temas)+len(paletas))
+	for n := range Temas {
+		disponibles = append(disponibles, n)
+	}
+	for n := range paletas {
+		disponibles = append(disponibles, n)
+	}
+	sort.Strings(disponibles)
+
+	return Paleta{}, errors.New(T("tema.desconocido", nombre, strings.Join(disponibles, ", ")))
+}
+
+func colorTema(c string) lipgloss.TerminalColor {
+	if c == "" {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(c)
+}
+
+func InitTema(nombre string, paletas map[string]Paleta) error {
+	if env := os.Getenv("SHOPIFY_TUI_THEME"); env != "" {
+		nombre = env
+	}
+
+	paleta, err := resolverTema(nombre, paletas)
+	if err != nil {
+		return err
+	}
+
+	if os.Getenv("NO_COLOR") != "" {
+		paleta = PaletaSinColor
+	}
+
+	Tema = paleta
+	aplicarTema(Tema)
+	return nil
+}
+
+func aplicarTema(p Paleta) {
+	estiloTitulo = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colorTema(p.Primario)).
+		MarginBottom(1)
+
+	estiloExito = lipgloss.NewStyle().
+		Foreground(colorTema(p.Exito)).
+		Bold(true)
+
+	estiloError = lipgloss.NewStyle().
+		Foreground(colorTema(p.Error)).
+		Bold(true)
+
+	estiloContenedor = lipgloss.NewStyle().
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorTema(p.Primario))
+
+	estiloInputActivo = lipgloss.NewStyle().
+		Foreground(colorTema(p.Primario)).
+		Bold(true)
+
+	estiloLabel = lipgloss.NewStyle().
+		Bold(true).
+		MarginBottom(0)
+
+	estiloAyuda = lipgloss.NewStyle().
+		Foreground(colorTema(p.Tenue)).
+		MarginTop(1)
+
+	estiloInfo = lipgloss.NewStyle().
+		Foreground(colorTema(p.Primario)).
+		Italic(true)
+
+	estiloAtajo = lipgloss.NewStyle().
+		Foreground(colorTema(p.Atajo)).
+		Bold(true)
+
+	estiloItemNormal = lipgloss.NewStyle().
+		Foreground(colorTema(p.Texto))
+
+	estiloItemSeleccionado = lipgloss.NewStyle().
+		Foreground(colorTema(p.Primario)).
+		Bold(true)
+
+	estiloDesc = lipgloss.NewStyle().
+		Foreground(colorTema(p.Tenue))
+
+	estiloAviso = lipgloss.NewStyle().
+		Foreground(colorTema(p.Aviso)).
+		Bold(true)
+
+	estiloEnlace = lipgloss.NewStyle().
+		Foreground(colorTema(p.Enlace))
+
+	estiloComando = lipgloss.NewStyle().
+		Foreground(colorTema(p.Comando))
+
+	estiloPopup = lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorTema(p.Primario)).
+		Padding(1, 2).
+		Background(colorTema(p.FondoPopup))
+
+	estiloPopupTitulo = lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colorTema(p.Primario)).
+		MarginBottom(1)
+
+	estiloCoincidencia = lipgloss.NewStyle().
+		Foreground(colorTema(p.Aviso)).
+		Underline(true)
+
+	estiloQR = lipgloss.NewStyle().
+		Foreground(colorTema(p.Texto)).
+		Background(colorTema(p.FondoPopup))
+
+	estiloDiffAgregado = lipgloss.NewStyle().
+		Background(colorTema(p.FondoAgregado))
+
+	estiloDiffEliminado = lipgloss.NewStyle().
+		Background(colorTema(p.FondoEliminado))
+
+	estiloSintaxisPalabra = lipgloss.NewStyle().
+		Foreground(colorTema(p.Primario)).
+		Bold(true)
+
+	estiloSintaxisCadena = lipgloss.NewStyle().
+		Foreground(colorTema(p.Exito))
+
+	estiloSintaxisNumero = lipgloss.NewStyle().
+		Foreground(colorTema(p.Atajo))
+
+	estiloSintaxisClave = lipgloss.NewStyle().
+		Foreground(colorTema(p.Enlace))
+
+	estiloSintaxisComentario = lipgloss.NewStyle().
+		Foreground(colorTema(p.Tenue)).
+		Italic(true)
+
+	estiloSintaxisEtiqueta = lipgloss.NewStyle().
+		Foreground(colorTema(p.Aviso))
+
+	estiloSintaxisFuncion = lipgloss.NewStyle().
+		Foreground(colorTema(p.Comando))
+}
+
+func colorClaro(c string) bool {
+	rgb := termenv.ConvertToRGB(termenv.TrueColor.Color(c))
+	return 0.299*rgb.R+0.587*rgb.G+0.114*rgb.B > 0.5
+}
+
+func frenteQRClaro() bool {
+	if Tema.Texto == "" {
+		return lipgloss.HasDarkBackground()
+	}
+	return colorClaro(Tema.Texto)
+}
+
+func delegadoTema() list.DefaultDelegate {
+	delegado := list.NewDefaultDelegate()
+	estilos := &delegado.Styles
+
+	estilos.NormalTitle = estilos.NormalTitle.Foreground(colorTema(Tema.Texto))
+	estilos.NormalDesc = estilos.NormalDesc.Foreground(colorTema(Tema.Tenue))
+	estilos.SelectedTitle = estilos.SelectedTitle.
+		Foreground(colorTema(Tema.Primario)).
+		BorderForeground(colorTema(Tema.Primario)).
+		Bold(true)
+	estilos.SelectedDesc = estilos.SelectedDesc.
+		Foreground(colorTema(Tema.Tenue)).
+		BorderForeground(colorTema(Tema.Primario))
+	estilos.DimmedTitle = estilos.DimmedTitle.Foreground(colorTema(Tema.Tenue))
+	estilos.DimmedDesc = estilos.DimmedDesc.Foreground(colorTema(Tema.Tenue))
+	estilos.FilterMatch = estiloCoincidencia
+
+	return delegado
+}
+
+func estilosLista() list.Styles {
+	estilos := list.DefaultStyles()
+
+	estilos.Title = estilos.Title.
+		Foreground(colorTema(Tema.Texto)).
+		Background(colorTema(Tema.Primario))
+	estilos.Spinner = estilos.Spinner.Foreground(colorTema(Tema.Tenue))
+	estilos.FilterPrompt = estilos.FilterPrompt.Foreground(colorTema(Tema.Primario))
+	estilos.FilterCursor = estilos.FilterCursor.Foreground(colorTema(Tema.Atajo))
+	estilos.DefaultFilterCharacterMatch = estiloCoincidencia
+	estilos.StatusBar = estilos.StatusBar.Foreground(colorTema(Tema.Tenue))
+	estilos.StatusEmpty = estilos.StatusEmpty.Foreground(colorTema(Tema.Tenue))
+	estilos.StatusBarActiveFilter = estilos.StatusBarActiveFilter.Foreground(colorTema(Tema.Texto))
+	estilos.StatusBarFilterCount = estilos.StatusBarFilterCount.Foreground(colorTema(Tema.Tenue))
+	estilos.NoItems = estilos.NoItems.Foreground(colorTema(Tema.Tenue))
+	estilos.ActivePaginationDot = estilos.ActivePaginationDot.Foreground(colorTema(Tema.Texto))
+	estilos.InactivePaginationDot = estilos.InactivePaginationDot.Foreground(colorTema(Tema.Tenue))
+	estilos.ArabicPagination = estilos.ArabicPagination.Foreground(colorTema(Tema.Tenue))
+	estilos.DividerDot = estilos.DividerDot.Foreground(colorTema(Tema.Tenue))
+
+	return estilos
+}
+
+func aplicarTemaLista(lista *list.Model) {
+	lista.Styles = estilosLista()
+	lista.FilterInput.PromptStyle = lista.Styles.FilterPrompt
+	lista.FilterInput.Cursor.Style = lista.Styles.FilterCursor
+}
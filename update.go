@@ -4,37 +4,62 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-type tickMsg time.Time
-
-func tickCmd() tea.Cmd {
-	return tea.Tick(500*time.Millisecond, func(t time.Time) tea.Msg {
-		return tickMsg(t)
-	})
+func (m Model) Init() tea.Cmd {
+	return tea.Batch(iniciarProxy(), verificarActualizacion(), esperarEventos(m.eventos), esperarTrabajos())
 }
 
-func (m Model) Init() tea.Cmd {
-	return nil
+func (m Model) salir() (tea.Model, tea.Cmd) {
+	ObtenerTrabajos().CancelarTodos()
+	ObtenerGestor().DetenerTodos()
+	ObtenerGestor().EsperarDetenidos(esperaTerminacion + time.Second)
+	if m.terminal != nil {
+		m.terminal.Cerrar()
+	}
+	ObtenerProxy().Detener()
+	return m, tea.Quit
 }
 
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	m.marcarErroresVistos()
+
 	switch msg := msg.(type) {
 
 	case tea.WindowSizeMsg:
 		m.ancho = msg.Width
 		m.alto = msg.Height
 		m.lista.SetSize(msg.Width-4, msg.Height-6)
+		ObtenerGestor().Redimensionar(m.lineasPorPagina(), max(msg.Width-2, 20))
+		if m.terminal != nil {
+			m.terminal.Redimensionar(m.tamanoTerminal())
+		}
 		return m, nil
 
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "ctrl+q":
+		if m.escribiendo && m.vista == VistaLogs {
+			return m.updateEscritura(msg)
+		}
 
-			ObtenerGestor().DetenerTodos()
-			return m, tea.Quit
-		case "q", "esc":
+		switch {
+		case key.Matches(msg, Teclas.Salir):
+
+			return m.salir()
+		case m.terminal != nil && key.Matches(msg, Teclas.AdjuntarTerminal):
+
+			m.terminalAdjunta = !m.terminalAdjunta
+			return m, nil
+		case m.terminal != nil && m.terminalAdjunta:
+
+			return m.updateTerminal(msg)
+		case key.Matches(msg, Teclas.SalidaTrabajo):
+
+			return m.abrirSalidaTrabajo(ObtenerTrabajos().Reciente())
+		case key.Matches(msg, Teclas.Volver) && !m.lista.SettingFilter() &&
+			!(m.enFormulario() && msg.Type == tea.KeyRunes):
 
 			switch m.vista {
 			case VistaMenu:
@@ -52,25 +77,55 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.mensaje = ""
 
 				items := crearListaMetodos()
-				m.lista = crearLista(items, Icons.Download+" Método de descarga", m.ancho, m.alto)
+				m.lista = crearLista(items, Icons.Download+" "+T("metodo.titulo"), m.ancho, m.alto)
 			case VistaSeleccionarTienda:
+				if m.lista.IsFiltered() {
+					m.lista.ResetFilter()
+					m.recrearListaTiendas()
+					return m, nil
+				}
+				if len(m.marcadas) > 0 {
+					m.marcadas = make(map[string]bool)
+					return m, nil
+				}
 				m.vista = VistaMenu
 				m.mensaje = ""
+				m.confirmarEliminar = false
 				m.recrearMenuPrincipal()
+			case VistaEditarTienda:
+				m.vista = VistaSeleccionarTienda
+				m.mensaje = ""
+				m.recrearListaTiendas()
+				m.seleccionarTiendaOriginal(m.indiceEdicion)
 			case VistaSeleccionarModo:
 				m.vista = VistaSeleccionarTienda
 				m.mensaje = ""
-				items := crearListaTiendas(m.tiendas)
-				m.lista = crearLista(items, Icons.Server+" Selecciona una tienda", m.ancho, m.alto)
+				m.recrearListaTiendas()
 			case VistaLogs:
 
+				if m.buscandoLogs {
+					m.buscandoLogs = false
+					m.inputBusqueda.Blur()
+					return m, nil
+				}
+				if m.busquedaLogs != "" {
+					m.busquedaLogs = ""
+					m.mensaje = ""
+					return m, nil
+				}
+				if m.logsDesdePanel {
+					m.logsDesdePanel = false
+					m.vista = VistaPanel
+					m.mensaje = ""
+					return m, nil
+				}
 				m.vista = VistaSeleccionarModo
 				gestor := ObtenerGestor()
 				tieneServidor := gestor.TieneServidorActivo(m.tiendaParaDev.Nombre)
 				items := crearListaModos(m.tiendaParaDev, tieneServidor)
 				titulo := Icons.Server + " " + m.tiendaParaDev.Nombre
 				if tieneServidor {
-					titulo = Icons.ServerOn + " " + m.tiendaParaDev.Nombre + " (servidor activo)"
+					titulo = Icons.ServerOn + " " + m.tiendaParaDev.Nombre + " " + T("modo.servidor_activo")
 				}
 				m.lista = crearLista(items, titulo, m.ancho, m.alto)
 				m.mensaje = ""
@@ -78,6 +133,29 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.vista = VistaMenu
 				m.mensaje = ""
 				m.recrearMenuPrincipal()
+			case VistaPanel:
+				m.vista = VistaServidores
+				m.mensaje = ""
+			case VistaNovedades:
+				m.vista = VistaMenu
+				m.mensaje = ""
+				m.recrearMenuPrincipal()
+			case VistaLote:
+				m.vista = VistaSeleccionarTienda
+				m.mensaje = ""
+				m.recrearListaTiendas()
+			case VistaTrabajo:
+				m.vista = m.vistaAntesTrabajo
+				m.mensaje = ""
+			case VistaTrabajos:
+				m.vista = VistaMenu
+				m.mensaje = ""
+				m.recrearMenuPrincipal()
+			case VistaDiff:
+				m = m.cerrarDiff()
+			case VistaDiffArchivo:
+				m.vista = VistaDiff
+				m.mensaje = ""
 			}
 			return m, nil
 		}
@@ -88,7 +166,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.tienda != nil {
 			m.tiendas = append(m.tiendas, *msg.tienda)
 			guardarTiendas(m.tiendas)
-			m.mensaje = IconSuccess("Tienda '" + msg.tienda.Nombre + "' agregada correctamente")
+			m.mensaje = IconSuccess(T("tiendas.agregada", msg.tienda.Nombre))
 			m.vista = VistaMenu
 			m.recrearMenuPrincipal()
 			return m, nil
@@ -97,7 +175,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if msg.volverAOpciones && m.tiendaParaDev.Nombre != "" {
 			m.vista = VistaLogs
 			m.logsScroll = 0
-			return m, tickCmd()
+			return m, nil
 		}
 
 		m.vista = VistaMenu
@@ -105,15 +183,88 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case errorMsg:
-		m.mensaje = IconError("Error: " + msg.err.Error())
+		m.mensaje = IconError(T("error.generico", msg.err.Error()))
+		return m, nil
+
+	case terminalAbiertaMsg:
+		m.terminal = msg.terminal
+		m.terminalAdjunta = true
+		m.terminal.Redimensionar(m.tamanoTerminal())
+		return m, esperarTerminal(msg.terminal)
+
+	case terminalActualizadaMsg:
+		return m, esperarTerminal(msg.terminal)
+
+	case terminalTerminadaMsg:
+		switch resultado := msg.resultado.(type) {
+		case comandoTerminadoMsg:
+			if resultado.tienda != nil {
+				return m.Update(resultado)
+			}
+			m.mensaje = resultado.resultado
+		case errorMsg:
+			m.mensaje = IconError(T("error.generico", resultado.err.Error()))
+		}
+		return m, nil
+
+	case actualizacionMsg:
+		m.hayActualizacion = msg.version != "" && m.actualizado == ""
+		m.versionNueva = msg.version
+		return m, nil
+
+	case autoActualizacionMsg:
+		m.actualizando = false
+		if msg.err != nil {
+			m.mensaje = IconError(msg.err.Error())
+			return m, nil
+		}
+		m.hayActualizacion = false
+		m.actualizado = msg.version
+		m.mensaje = IconSuccess(T("version.actualizada", msg.version, ayudaTecla(Teclas.Actualizar)))
+		return m, nil
+
+	case notasVersionMsg:
+		m.cargandoNotas = false
+		if msg.err != nil {
+			m.errorNotas = msg.err.Error()
+			return m, nil
+		}
+		m.errorNotas = ""
+		m.notasVersion = msg.notas
+		m.notasIncompletas = msg.incompletas
+		m.notasPara = msg.version
 		return m, nil
 
-	case tickMsg:
+	case proxyIniciadoMsg:
+		if msg.err != nil {
+			m.mensaje = IconWarning(msg.err.Error())
+		} else if len(msg.colisiones) > 0 {
+			m.mensaje = IconWarning(strings.Join(msg.colisiones, " · "))
+		}
+		return m, nil
 
-		if m.vista == VistaLogs {
-			return m, tickCmd()
+	case servidorReiniciadoMsg:
+		if msg.err != nil {
+			m.mensaje = IconError(msg.err.Error())
+		} else {
+			m.mensaje = IconSuccess(T("servidores.reiniciado", msg.nombre))
 		}
 		return m, nil
+
+	case eventosServidorMsg:
+		return m.aplicarEventos(msg)
+
+	case trabajosActualizadosMsg:
+		return m.trabajosActualizados()
+
+	case trabajoLanzadoMsg:
+		return m.trabajoLanzado(msg)
+
+	case diffLanzadoMsg:
+		return m.diffLanzado(msg)
+
+	case spinner.TickMsg:
+		return m.girarSpinner(msg)
 	}
 
 	switch m.vista {
@@ -135,21 +286,40 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.updateServidores(msg)
 	case VistaPopup:
 		return m.updatePopup(msg)
+	case VistaEditarTienda:
+		return m.updateEditarTienda(msg)
+	case VistaPanel:
+		return m.updatePanel(msg)
+	case VistaNovedades:
+		return m.updateNovedades(msg)
+	case VistaLote:
+		return m.updateLote(msg)
+	case VistaTrabajo:
+		return m.updateTrabajo(msg)
+	case VistaTrabajos:
+		return m.updateTrabajos(msg)
+	case VistaDiff:
+		return m.updateDiff(msg)
+	case VistaDiffArchivo:
+		return m.updateDiffArchivo(msg)
 	}
 
 	return m, nil
 }
 
+func (m Model) enFormulario() bool {
+	return m.vista == VistaAgregarTienda || m.vista == VistaInputGit || m.vista == VistaEditarTienda ||
+		(m.vista == VistaLogs && m.buscandoLogs)
+}
+
 func (m Model) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		key := msg.String()
-
-		switch key {
-		case "a":
+		switch {
+		case key.Matches(msg, Teclas.Login):
 			return m, ejecutarShopifyLogin()
 
-		case "t":
+		case key.Matches(msg, Teclas.AgregarTienda):
 			m.vista = VistaAgregarTienda
 			m.inputNombre.SetValue("")
 			m.inputURL.SetValue("")
@@ -159,35 +329,41 @@ func (m Model) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.tiendaTemporal = Tienda{}
 			return m, nil
 
-		case "d":
+		case key.Matches(msg, Teclas.Desarrollo):
 			if len(m.tiendas) == 0 {
-				m.mensaje = IconWarning("No hay tiendas. Agrega una primero.")
+				m.mensaje = IconWarning(T("menu.sin_tiendas"))
 				return m, nil
 			}
 			m.vista = VistaSeleccionarTienda
-			items := crearListaTiendas(m.tiendas)
-			m.lista = crearLista(items, Icons.Server+" Selecciona una tienda", m.ancho, m.alto)
+			m.recrearListaTiendas()
 			m.mensaje = ""
 			return m, nil
 
-		case "v":
+		case key.Matches(msg, Teclas.Servidores):
 			m.vista = VistaServidores
 			m.mensaje = ""
 			return m, nil
 
-		case "enter", "l":
+		case key.Matches(msg, Teclas.Trabajos):
+			return m.abrirTrabajos()
+
+		case key.Matches(msg, Teclas.Actualizar):
+			return m.accionActualizar()
+
+		case key.Matches(msg, Teclas.Novedades) && m.versionNueva != "":
+			return m.abrirNovedades()
+
+		case key.Matches(msg, Teclas.Seleccionar):
 			item, ok := m.lista.SelectedItem().(itemMenu)
 			if !ok {
 				return m, nil
 			}
 
-			titulo := item.titulo
-
-			switch {
-			case strings.Contains(titulo, "Iniciar sesión"):
+			switch item.accion {
+			case accionLogin:
 				return m, ejecutarShopifyLogin()
 
-			case strings.Contains(titulo, "Agregar tienda"):
+			case accionAgregarTienda:
 				m.vista = VistaAgregarTienda
 				m.inputNombre.SetValue("")
 				m.inputURL.SetValue("")
@@ -197,21 +373,23 @@ func (m Model) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.tiendaTemporal = Tienda{}
 				return m, nil
 
-			case strings.Contains(titulo, "Desarrollo local"):
+			case accionDesarrollo:
 				if len(m.tiendas) == 0 {
-					m.mensaje = IconWarning("No hay tiendas guardadas. Agrega una primero.")
+					m.mensaje = IconWarning(T("menu.sin_tiendas_guardadas"))
 					return m, nil
 				}
 				m.vista = VistaSeleccionarTienda
-				items := crearListaTiendas(m.tiendas)
-				m.lista = crearLista(items, Icons.Server+" Selecciona una tienda", m.ancho, m.alto)
+				m.recrearListaTiendas()
 				m.mensaje = ""
 				return m, nil
 
-			case strings.Contains(titulo, "Servidores activos"):
+			case accionServidores:
 				m.vista = VistaServidores
 				m.mensaje = ""
 				return m, nil
+
+			case accionTrabajos:
+				return m.abrirTrabajos()
 			}
 		}
 	}
@@ -224,8 +402,8 @@ func (m Model) updateMenu(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) updateAgregarTienda(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "tab", "down":
+		switch {
+		case key.Matches(msg, Teclas.CampoSiguiente):
 			if m.cursorInput == 0 {
 				m.cursorInput = 1
 				m.inputNombre.Blur()
@@ -237,7 +415,7 @@ func (m Model) updateAgregarTienda(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case "shift+tab", "up":
+		case key.Matches(msg, Teclas.CampoAnterior):
 			if m.cursorInput == 1 {
 				m.cursorInput = 0
 				m.inputURL.Blur()
@@ -249,12 +427,12 @@ func (m Model) updateAgregarTienda(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case "enter":
+		case key.Matches(msg, Teclas.Aceptar):
 			nombre := m.inputNombre.Value()
 			url := m.inputURL.Value()
 
 			if nombre == "" || url == "" {
-				m.mensaje = IconWarning("Por favor completa ambos campos")
+				m.mensaje = IconWarning(T("agregar.campos_vacios"))
 				return m, nil
 			}
 
@@ -265,7 +443,7 @@ func (m Model) updateAgregarTienda(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			m.vista = VistaSeleccionarMetodo
 			items := crearListaMetodos()
-			m.lista = crearLista(items, Icons.Download+" Método de descarga", m.ancho, m.alto)
+			m.lista = crearLista(items, Icons.Download+" "+T("metodo.titulo"), m.ancho, m.alto)
 			m.mensaje = ""
 			return m, nil
 		}
@@ -280,23 +458,70 @@ func (m Model) updateAgregarTienda(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+func (m Model) updateEditarTienda(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, Teclas.CampoSiguiente), key.Matches(msg, Teclas.CampoAnterior):
+			if m.cursorInput == 0 {
+				m.cursorInput = 1
+				m.inputEtiquetas.Blur()
+				m.inputGrupo.Focus()
+			} else {
+				m.cursorInput = 0
+				m.inputGrupo.Blur()
+				m.inputEtiquetas.Focus()
+			}
+			return m, nil
+
+		case key.Matches(msg, Teclas.Aceptar):
+			if m.indiceEdicion < 0 || m.indiceEdicion >= len(m.tiendas) {
+				return m, nil
+			}
+
+			tienda := &m.tiendas[m.indiceEdicion]
+			tienda.Etiquetas = normalizarEtiquetas(m.inputEtiquetas.Value())
+			tienda.Grupo = strings.TrimSpace(m.inputGrupo.Value())
+
+			if err := guardarTiendas(m.tiendas); err != nil {
+				m.mensaje = IconError(T("error.generico", err.Error()))
+				return m, nil
+			}
+
+			m.vista = VistaSeleccionarTienda
+			m.mensaje = IconSuccess(T("editar.guardado", tienda.Nombre))
+			m.recrearListaTiendas()
+			m.seleccionarTiendaOriginal(m.indiceEdicion)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.cursorInput == 0 {
+		m.inputEtiquetas, cmd = m.inputEtiquetas.Update(msg)
+	} else {
+		m.inputGrupo, cmd = m.inputGrupo.Update(msg)
+	}
+	return m, cmd
+}
+
 func (m Model) updateSeleccionarMetodo(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	usarShopifyPull := func() (tea.Model, tea.Cmd) {
 		directorio, err := crearDirectorioTienda(m.tiendaTemporal.Nombre)
 		if err != nil {
-			m.mensaje = IconError("Error al crear directorio: " + err.Error())
+			m.mensaje = IconError(T("agregar.error_directorio", err.Error()))
 			return m, nil
 		}
 		m.tiendaTemporal.Metodo = MetodoShopifyPull
 		m.tiendaTemporal.Ruta = directorio
-		return m, ejecutarDescargaConExec(m.tiendaTemporal, directorio)
+		return m.abrirEnTerminal(ejecutarDescargaEnPanel(m.tiendaTemporal, directorio))
 	}
 
 	usarGitClone := func() (tea.Model, tea.Cmd) {
 		directorio, err := crearDirectorioTienda(m.tiendaTemporal.Nombre)
 		if err != nil {
-			m.mensaje = IconError("Error al crear directorio: " + err.Error())
+			m.mensaje = IconError(T("agregar.error_directorio", err.Error()))
 			return m, nil
 		}
 		m.tiendaTemporal.Metodo = MetodoGitClone
@@ -310,24 +535,22 @@ func (m Model) updateSeleccionarMetodo(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		key := msg.String()
-
-		switch key {
-		case "s":
+		switch {
+		case key.Matches(msg, Teclas.ShopifyPull):
 			return usarShopifyPull()
-		case "g":
+		case key.Matches(msg, Teclas.GitClone):
 			return usarGitClone()
 
-		case "enter":
+		case key.Matches(msg, Teclas.Seleccionar):
 			item, ok := m.lista.SelectedItem().(itemMenu)
 			if !ok {
 				return m, nil
 			}
 
-			titulo := item.titulo
-			if strings.Contains(titulo, "Shopify Pull") {
+			switch item.accion {
+			case accionShopifyPull:
 				return usarShopifyPull()
-			} else if strings.Contains(titulo, "Git Clone") {
+			case accionGitClone:
 				return usarGitClone()
 			}
 		}
@@ -341,16 +564,16 @@ func (m Model) updateSeleccionarMetodo(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) updateInputGit(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "enter", "l":
+		switch {
+		case key.Matches(msg, Teclas.Aceptar):
 			gitURL := m.inputGit.Value()
 			if gitURL == "" {
-				m.mensaje = IconWarning("Por favor ingresa la URL del repositorio")
+				m.mensaje = IconWarning(T("git.url_vacia"))
 				return m, nil
 			}
 
 			m.tiendaTemporal.GitURL = gitURL
-			return m, ejecutarDescargaConExec(m.tiendaTemporal, m.tiendaTemporal.Ruta)
+			return m.abrirEnTerminal(ejecutarDescargaEnPanel(m.tiendaTemporal, m.tiendaTemporal.Ruta))
 		}
 	}
 
@@ -362,24 +585,36 @@ func (m Model) updateInputGit(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) updateSeleccionarTienda(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		key := msg.String()
+		if m.lista.SettingFilter() {
+			var cmd tea.Cmd
+			m.lista, cmd = m.lista.Update(msg)
+			if !m.lista.SettingFilter() && !m.lista.IsFiltered() {
+				m.recrearListaTiendas()
+			}
+			return m, cmd
+		}
+
+		if key.Matches(msg, Teclas.Filtrar) && len(m.gruposColapsados) > 0 {
+			m.lista.SetItems(crearListaTiendas(m.tiendas, nil, m.ordenReciente, m.servidores))
+		}
 
 		var indiceSeleccionado int = -1
 
-		if len(key) == 1 && key >= "1" && key <= "9" {
-			indiceSeleccionado = int(key[0] - '1')
-		} else if key == "enter" || key == "l" {
-			indiceSeleccionado = m.lista.Index()
-		} else if key == "d" {
-			indice := m.lista.Index()
-			if indice >= 0 && indice < len(m.tiendas) {
+		if m.confirmarEliminar {
+			m.confirmarEliminar = false
+			m.mensaje = ""
+
+			_, indice, ok := m.tiendaVisible(m.lista.Index())
+			if key.Matches(msg, Teclas.Confirmar) && ok {
 				nombreEliminada := m.tiendas[indice].Nombre
 				m.tiendas = eliminarTienda(m.tiendas, indice)
+				ObtenerHistorial().EliminarTienda(nombreEliminada)
+				delete(m.marcadas, nombreEliminada)
 
 				if err := guardarTiendas(m.tiendas); err != nil {
-					m.mensaje = IconError("Error al eliminar: " + err.Error())
+					m.mensaje = IconError(T("tiendas.error_eliminar", err.Error()))
 				} else {
-					m.mensaje = Icons.Delete + " Tienda '" + nombreEliminada + "' eliminada"
+					m.mensaje = Icons.Delete + " " + T("tiendas.eliminada", nombreEliminada)
 				}
 
 				if len(m.tiendas) == 0 {
@@ -388,17 +623,85 @@ func (m Model) updateSeleccionarTienda(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 
-				items := crearListaTiendas(m.tiendas)
-				m.lista = crearLista(items, Icons.Server+" Selecciona una tienda", m.ancho, m.alto)
+				m.recrearListaTiendas()
+			}
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, Teclas.SeleccionRapida):
+			indiceSeleccionado = m.tiendaRapida(indiceRapido(Teclas.SeleccionRapida, msg.String()))
+		case key.Matches(msg, Teclas.Seleccionar):
+			if grupo, ok := m.lista.SelectedItem().(itemGrupo); ok {
+				if grupo.colapsado {
+					delete(m.gruposColapsados, grupo.nombre)
+				} else {
+					m.gruposColapsados[grupo.nombre] = true
+				}
+				indice := m.lista.Index()
+				m.recrearListaTiendas()
+				m.lista.Select(indice)
+				return m, nil
+			}
+			indiceSeleccionado = m.lista.Index()
+		case key.Matches(msg, Teclas.Ordenar):
+			m.ordenReciente = !m.ordenReciente
+			_, indice, ok := m.tiendaVisible(m.lista.Index())
+			m.recrearListaTiendas()
+			if ok {
+				m.seleccionarTiendaOriginal(indice)
+			}
+			return m, nil
+		case key.Matches(msg, Teclas.Favorita):
+			if _, indice, ok := m.tiendaVisible(m.lista.Index()); ok {
+				m.tiendas[indice].Favorita = !m.tiendas[indice].Favorita
+				if err := guardarTiendas(m.tiendas); err != nil {
+					m.mensaje = IconError(T("error.generico", err.Error()))
+				}
+				m.recrearListaTiendas()
+				m.seleccionarTiendaOriginal(indice)
+			}
+			return m, nil
+		case key.Matches(msg, Teclas.Etiquetar):
+			if tienda, indice, ok := m.tiendaVisible(m.lista.Index()); ok {
+				m.vista = VistaEditarTienda
+				m.indiceEdicion = indice
+				m.inputEtiquetas.SetValue(strings.Join(tienda.Etiquetas, ", "))
+				m.inputGrupo.SetValue(tienda.Grupo)
+				m.inputEtiquetas.Focus()
+				m.inputGrupo.Blur()
+				m.cursorInput = 0
+				m.mensaje = ""
+			}
+			return m, nil
+		case key.Matches(msg, Teclas.Marcar):
+			m.alternarMarcas(m.tiendasDeItem(m.lista.SelectedItem()))
+			m.lista.CursorDown()
+			return m, nil
+		case key.Matches(msg, Teclas.MarcarTodas):
+			var visibles []Tienda
+			for _, item := range m.lista.VisibleItems() {
+				visibles = append(visibles, m.tiendasDeItem(item)...)
+			}
+			m.alternarMarcas(visibles)
+			return m, nil
+		case key.Matches(msg, Teclas.Pull):
+			return m.abrirLote(accionPull)
+		case key.Matches(msg, Teclas.Push):
+			return m.abrirLote(accionPush)
+		case key.Matches(msg, Teclas.Eliminar):
+			if tienda, _, ok := m.tiendaVisible(m.lista.Index()); ok {
+				m.confirmarEliminar = true
+				m.mensaje = IconWarning(T("tiendas.confirmar_eliminar",
+					tienda.Nombre, ayudaTecla(Teclas.Confirmar)))
 			}
 			return m, nil
 		}
 
-		if indiceSeleccionado >= 0 && indiceSeleccionado < len(m.tiendas) {
-			tienda := m.tiendas[indiceSeleccionado]
+		if tienda, _, ok := m.tiendaVisible(indiceSeleccionado); ok {
 
 			if !existeDirectorio(tienda.Ruta) {
-				m.mensaje = IconError("El directorio no existe: " + tienda.Ruta)
+				m.mensaje = IconError(T("tiendas.directorio_inexistente", tienda.Ruta))
 				return m, nil
 			}
 
@@ -410,7 +713,7 @@ func (m Model) updateSeleccionarTienda(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.vista = VistaLogs
 				m.logsScroll = 0
 				m.mensaje = ""
-				return m, tickCmd()
+				return m, nil
 			}
 
 			servidor, err := gestor.IniciarServidor(tienda)
@@ -418,13 +721,13 @@ func (m Model) updateSeleccionarTienda(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.mensaje = IconError(err.Error())
 				m.vista = VistaLogs
 				m.logsScroll = 0
-				return m, tickCmd()
+				return m, nil
 			}
 
-			m.mensaje = IconSuccess("Servidor iniciado en " + servidor.URL)
+			m.mensaje = IconSuccess(T("servidor.iniciado", servidor.URL))
 			m.vista = VistaLogs
 			m.logsScroll = 0
-			return m, tickCmd()
+			return m, nil
 		}
 	}
 
@@ -443,24 +746,24 @@ func (m Model) updateSeleccionarModo(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.mensaje = IconError(err.Error())
 			return m, nil
 		}
-		m.mensaje = IconSuccess("Servidor iniciado en " + servidor.URL)
+		m.mensaje = IconSuccess(T("servidor.iniciado", servidor.URL))
 		m.vista = VistaLogs
 		m.logsScroll = 0
-		return m, tickCmd()
+		return m, nil
 	}
 
 	verLogs := func() (tea.Model, tea.Cmd) {
 		m.vista = VistaLogs
 		m.logsScroll = 0
 		m.mensaje = ""
-		return m, tickCmd()
+		return m, nil
 	}
 
 	detenerServidor := func() (tea.Model, tea.Cmd) {
 		if err := gestor.DetenerServidor(m.tiendaParaDev.Nombre); err != nil {
 			m.mensaje = IconError(err.Error())
 		} else {
-			m.mensaje = Icons.Stop + " Servidor detenido"
+			m.mensaje = Icons.Stop + " " + T("servidor.detenido")
 		}
 		m.vista = VistaMenu
 		m.recrearMenuPrincipal()
@@ -469,59 +772,75 @@ func (m Model) updateSeleccionarModo(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		key := msg.String()
-
-		switch key {
-		case "i":
+		switch {
+		case key.Matches(msg, Teclas.Iniciar):
 			if !tieneServidor {
 				return iniciarServidor()
 			}
-		case "l":
-			if tieneServidor {
+		case key.Matches(msg, Teclas.Logs):
+			if gestor.ObtenerServidor(m.tiendaParaDev.Nombre) != nil {
 				return verLogs()
 			}
-		case "s":
+		case key.Matches(msg, Teclas.Detener):
 			if tieneServidor {
 				return detenerServidor()
 			}
-		case "p":
-			return m, ejecutarThemePull(m.tiendaParaDev)
-		case "u":
-			return m, ejecutarThemePush(m.tiendaParaDev)
-		case "e":
+		case key.Matches(msg, Teclas.Pull):
+			return m, lanzarTrabajo(accionPull, m.tiendaParaDev)
+		case key.Matches(msg, Teclas.Push):
+			return m, lanzarTrabajo(accionPush, m.tiendaParaDev)
+		case key.Matches(msg, Teclas.Check):
+			return m, lanzarTrabajo(accionCheck, m.tiendaParaDev)
+		case key.Matches(msg, Teclas.Empaquetar):
+			return m, lanzarTrabajo(accionEmpaquetar, m.tiendaParaDev)
+		case key.Matches(msg, Teclas.Diff):
+			return m.abrirDiff()
+		case key.Matches(msg, Teclas.Editor):
 			return m, ejecutarAbrirEditor(m.tiendaParaDev)
-		case "t":
-			return m, ejecutarAbrirTerminal(m.tiendaParaDev)
+		case key.Matches(msg, Teclas.Terminal):
+			return m.abrirEnTerminal(ejecutarAbrirTerminal(m.tiendaParaDev))
+		case key.Matches(msg, Teclas.ModoRed):
+			return m.alternarModoRed()
 
-		case "enter":
+		case key.Matches(msg, Teclas.Aceptar):
 			item, ok := m.lista.SelectedItem().(itemMenu)
 			if !ok {
 				return m, nil
 			}
 
-			titulo := item.titulo
-
-			switch {
-			case strings.Contains(titulo, "Iniciar"):
+			switch item.accion {
+			case accionIniciar:
 				return iniciarServidor()
 
-			case strings.Contains(titulo, "Ver logs"):
+			case accionLogs:
 				return verLogs()
 
-			case strings.Contains(titulo, "Detener"):
+			case accionDetener:
 				return detenerServidor()
 
-			case strings.Contains(titulo, "Pull"):
-				return m, ejecutarThemePull(m.tiendaParaDev)
+			case accionPull:
+				return m, lanzarTrabajo(accionPull, m.tiendaParaDev)
+
+			case accionPush:
+				return m, lanzarTrabajo(accionPush, m.tiendaParaDev)
 
-			case strings.Contains(titulo, "Push"):
-				return m, ejecutarThemePush(m.tiendaParaDev)
+			case accionCheck:
+				return m, lanzarTrabajo(accionCheck, m.tiendaParaDev)
 
-			case strings.Contains(titulo, "Editor"):
+			case accionEmpaquetar:
+				return m, lanzarTrabajo(accionEmpaquetar, m.tiendaParaDev)
+
+			case accionDiff:
+				return m.abrirDiff()
+
+			case accionEditor:
 				return m, ejecutarAbrirEditor(m.tiendaParaDev)
 
-			case strings.Contains(titulo, "Terminal"):
-				return m, ejecutarAbrirTerminal(m.tiendaParaDev)
+			case accionTerminal:
+				return m.abrirEnTerminal(ejecutarAbrirTerminal(m.tiendaParaDev))
+
+			case accionModoRed:
+				return m.alternarModoRed()
 			}
 		}
 	}
@@ -534,10 +853,10 @@ func (m Model) updateSeleccionarModo(msg tea.Msg) (tea.Model, tea.Cmd) {
 func (m Model) updateServidores(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "s":
+		switch {
+		case key.Matches(msg, Teclas.Detener):
 
-			servidores := ObtenerGestor().ObtenerServidoresActivos()
+			servidores := m.servidoresActivos()
 			if len(servidores) == 0 {
 				return m, nil
 			}
@@ -551,28 +870,66 @@ func (m Model) updateServidores(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if err := ObtenerGestor().DetenerServidor(servidor.Tienda.Nombre); err != nil {
 				m.mensaje = IconError(err.Error())
 			} else {
-				m.mensaje = IconSuccess("Servidor de '" + servidor.Tienda.Nombre + "' detenido")
+				m.mensaje = IconSuccess(T("servidores.detenido", servidor.Tienda.Nombre))
 			}
 			return m, nil
 
-		case "S":
+		case key.Matches(msg, Teclas.DetenerTodos):
 
 			ObtenerGestor().DetenerTodos()
-			m.mensaje = "✅ Todos los servidores detenidos"
+			m.mensaje = IconSuccess(T("servidores.todos_detenidos"))
+			return m, nil
+
+		case key.Matches(msg, Teclas.Reiniciar):
+
+			servidores := m.servidoresActivos()
+			if len(servidores) == 0 {
+				return m, nil
+			}
+
+			indice := m.lista.Index()
+			if indice < 0 || indice >= len(servidores) {
+				indice = 0
+			}
+
+			tienda := servidores[indice].Tienda
+			for _, t := range m.tiendas {
+				if t.Nombre == tienda.Nombre {
+					tienda = t
+					break
+				}
+			}
+			m.mensaje = IconInfo(T("servidores.reiniciando", tienda.Nombre))
+			return m, reiniciarServidor(tienda)
+
+		case key.Matches(msg, Teclas.Panel):
+
+			servidores := m.servidoresActivos()
+			if len(servidores) == 0 {
+				return m, nil
+			}
+
+			m.panelFoco = m.lista.Index()
+			if m.panelFoco < 0 || m.panelFoco >= len(servidores) {
+				m.panelFoco = 0
+			}
+			m.vista = VistaPanel
+			m.mensaje = ""
+			m.marcarErroresVistos()
 			return m, nil
 
-		case "j", "down":
+		case key.Matches(msg, Teclas.Abajo):
 
-			servidores := ObtenerGestor().ObtenerServidoresActivos()
+			servidores := m.servidoresActivos()
 			if len(servidores) > 0 {
 
 				m.lista, _ = m.lista.Update(msg)
 			}
 			return m, nil
 
-		case "k", "up":
+		case key.Matches(msg, Teclas.Arriba):
 
-			servidores := ObtenerGestor().ObtenerServidoresActivos()
+			servidores := m.servidoresActivos()
 			if len(servidores) > 0 {
 				m.lista, _ = m.lista.Update(msg)
 			}
@@ -583,6 +940,45 @@ func (m Model) updateServidores(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+func (m Model) updatePanel(msg tea.Msg) (tea.Model, tea.Cmd) {
+	servidores := m.servidoresActivos()
+	if len(servidores) == 0 {
+		return m, nil
+	}
+	if m.panelFoco >= len(servidores) {
+		m.panelFoco = len(servidores) - 1
+	}
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch {
+		case key.Matches(msg, Teclas.FocoSiguiente):
+			m.panelFoco = (m.panelFoco + 1) % len(servidores)
+
+		case key.Matches(msg, Teclas.FocoAnterior):
+			m.panelFoco = (m.panelFoco - 1 + len(servidores)) % len(servidores)
+
+		case key.Matches(msg, Teclas.Combinar):
+			m.panelCombinado = !m.panelCombinado
+
+		case key.Matches(msg, Teclas.Aceptar):
+			if m.panelCombinado {
+				return m, nil
+			}
+			m.tiendaParaDev = servidores[m.panelFoco].Tienda
+			m.vista = VistaLogs
+			m.logsScroll = 0
+			m.logsDesdePanel = true
+			m.mensaje = ""
+			m.marcarErroresVistos()
+			return m, nil
+		}
+		m.marcarErroresVistos()
+	}
+
+	return m, nil
+}
+
 func (m Model) updateLogs(msg tea.Msg) (tea.Model, tea.Cmd) {
 	servidor := ObtenerGestor().ObtenerServidor(m.tiendaParaDev.Nombre)
 
@@ -622,10 +1018,8 @@ func (m Model) updateLogs(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 	case tea.KeyMsg:
-		key := msg.String()
-
 		if m.modoSeleccion {
-			if key == "v" {
+			if key.Matches(msg, Teclas.ModoSeleccion) {
 				m.modoSeleccion = false
 				m.mensaje = ""
 				return m, tea.EnableMouseCellMotion
@@ -634,56 +1028,102 @@ func (m Model) updateLogs(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
-		switch key {
-		case " ", "m", "ctrl+p":
+		if m.buscandoLogs {
+			if key.Matches(msg, Teclas.Aceptar) {
+				m.buscandoLogs = false
+				m.busquedaLogs = strings.TrimSpace(m.inputBusqueda.Value())
+				m.inputBusqueda.Blur()
+				return m.irACoincidencia(m.logsScroll, true), nil
+			}
+
+			var cmd tea.Cmd
+			m.inputBusqueda, cmd = m.inputBusqueda.Update(msg)
+			return m, cmd
+		}
+
+		switch {
+		case key.Matches(msg, Teclas.Buscar):
+
+			m.buscandoLogs = true
+			m.inputBusqueda.SetValue(m.busquedaLogs)
+			m.inputBusqueda.CursorEnd()
+			return m, m.inputBusqueda.Focus()
+
+		case key.Matches(msg, Teclas.SiguienteCoincidencia):
+
+			return m.irACoincidencia(m.logsScroll+1, true), nil
+
+		case key.Matches(msg, Teclas.AnteriorCoincidencia):
+
+			return m.irACoincidencia(m.logsScroll-1, false), nil
+
+		case key.Matches(msg, Teclas.CopiarPagina):
+
+			return m.copiarLogs(alcancePagina), nil
+
+		case key.Matches(msg, Teclas.CopiarTodo):
+
+			return m.copiarLogs(alcanceTodo), nil
+
+		case key.Matches(msg, Teclas.CodigoQR):
+
+			m.ocultarQR = !m.ocultarQR
+			return m, nil
+
+		case key.Matches(msg, Teclas.Menu):
 			m.vistaAnterior = VistaLogs
 			m.vista = VistaPopup
 			m.popupIndex = 0
 			return m, nil
 
-		case "v":
+		case key.Matches(msg, Teclas.Descartar):
 
-			m.modoSeleccion = true
-			m.mensaje = IconInfo("Modo selección ON - Usa Ctrl+Shift+C para copiar, 'v' para salir")
-
-			return m, tea.DisableMouse
+			if !ObtenerGestor().Descartar(m.tiendaParaDev.Nombre) {
+				return m, nil
+			}
+			m.servidores = ObtenerGestor().Instantaneas()
+			m.logsScroll = 0
+			m.busquedaLogs = ""
+			m.mensaje = IconSuccess(T("logs.descartado"))
+			return m, nil
 
-		case "ctrl+q":
+		case key.Matches(msg, Teclas.Escribir):
 
-			m.modoSeleccion = false
-			m.vista = VistaSeleccionarModo
-			gestor := ObtenerGestor()
-			tieneServidor := gestor.TieneServidorActivo(m.tiendaParaDev.Nombre)
-			items := crearListaModos(m.tiendaParaDev, tieneServidor)
-			titulo := Icons.Server + " " + m.tiendaParaDev.Nombre
-			if tieneServidor {
-				titulo = Icons.ServerOn + " " + m.tiendaParaDev.Nombre + " (servidor activo)"
+			if servidor == nil || !servidor.Activo() {
+				m.mensaje = IconWarning(T("logs.sin_servidor"))
+				return m, nil
 			}
-			m.lista = crearLista(items, titulo, m.ancho, m.alto)
+			m.escribiendo = true
 			m.mensaje = ""
+			return m, nil
 
-			return m, tea.EnableMouseCellMotion
+		case key.Matches(msg, Teclas.ModoSeleccion):
 
-		case "ctrl+s":
+			m.modoSeleccion = true
+			m.mensaje = IconInfo(T("logs.seleccion_on", ayudaTecla(Teclas.ModoSeleccion)))
+
+			return m, tea.DisableMouse
+
+		case key.Matches(msg, Teclas.DetenerRapido):
 
 			if err := ObtenerGestor().DetenerServidor(m.tiendaParaDev.Nombre); err != nil {
 				m.mensaje = IconError(err.Error())
 			} else {
-				m.mensaje = Icons.Stop + " Servidor detenido"
+				m.mensaje = Icons.Stop + " " + T("servidor.detenido")
 			}
-			return m, tickCmd()
+			return m, nil
 
-		case "ctrl+g", "G":
+		case key.Matches(msg, Teclas.Final):
 
 			m.logsScroll = getMaxScroll()
 			return m, nil
 
-		case "ctrl+t", "g":
+		case key.Matches(msg, Teclas.Inicio):
 
 			m.logsScroll = 0
 			return m, nil
 
-		case "j", "down":
+		case key.Matches(msg, Teclas.Abajo):
 
 			maxScroll := getMaxScroll()
 			m.logsScroll++
@@ -692,7 +1132,7 @@ func (m Model) updateLogs(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case "k", "up":
+		case key.Matches(msg, Teclas.Arriba):
 
 			m.logsScroll--
 			if m.logsScroll < 0 {
@@ -700,7 +1140,7 @@ func (m Model) updateLogs(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case "pgdown", "ctrl+d":
+		case key.Matches(msg, Teclas.PaginaAbajo):
 
 			maxScroll := getMaxScroll()
 			m.logsScroll += 10
@@ -709,59 +1149,57 @@ func (m Model) updateLogs(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			return m, nil
 
-		case "pgup", "ctrl+u":
+		case key.Matches(msg, Teclas.PaginaArriba):
 
 			m.logsScroll -= 10
 			if m.logsScroll < 0 {
 				m.logsScroll = 0
 			}
 			return m, nil
+		}
+	}
 
-		default:
-
-			if servidor != nil && servidor.Activo {
-
-				var input string
-				switch key {
-				case "enter":
-					input = "\n"
-				case "space":
-					input = " "
-				case "tab":
-					input = "\t"
-				case "backspace":
-					input = "\b"
-				default:
+	return m, nil
+}
 
-					if len(key) == 1 {
-						input = key
-					}
-				}
+func (m Model) updateEscritura(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	servidor := ObtenerGestor().ObtenerServidor(m.tiendaParaDev.Nombre)
+	if key.Matches(msg, Teclas.SoltarTeclado) || servidor == nil || !servidor.Activo() {
+		m.escribiendo = false
+		return m, nil
+	}
 
-				if input != "" {
-					if err := servidor.EnviarInput(input); err != nil {
-						m.mensaje = IconWarning("Error enviando input")
-					}
-				}
-			}
-			return m, nil
+	if input := secuenciaTecla(msg, servidor.EnTerminal()); input != "" {
+		if err := servidor.EnviarInput(input); err != nil {
+			m.mensaje = IconWarning(T("logs.error_input"))
 		}
 	}
-
 	return m, nil
 }
 
-func crearOpcionesPopup(tieneServidor bool) []itemMenu {
+func crearOpcionesPopup(tienda Tienda, tieneServidor, hayBusqueda bool) []itemMenu {
 	opciones := []itemMenu{
-		{titulo: Icons.Download + " Pull", desc: "Bajar cambios", atajo: "p"},
-		{titulo: Icons.Upload + " Push", desc: "Subir cambios", atajo: "u"},
-		{titulo: Icons.Editor + " Editor", desc: "Abrir VS Code", atajo: "e"},
-		{titulo: Icons.Terminal + " Terminal", desc: "Abrir terminal", atajo: "t"},
+		{titulo: Icons.Download + " " + T("modo.pull"), desc: T("popup.pull.desc"), atajo: atajoPrincipal(Teclas.Pull), accion: accionPull},
+		{titulo: Icons.Upload + " " + T("modo.push"), desc: T("popup.push.desc"), atajo: atajoPrincipal(Teclas.Push), accion: accionPush},
+		{titulo: Icons.Editor + " " + T("modo.editor"), desc: T("popup.editor.desc"), atajo: atajoPrincipal(Teclas.Editor), accion: accionEditor},
+		{titulo: Icons.Terminal + " " + T("modo.terminal"), desc: T("popup.terminal.desc"), atajo: atajoPrincipal(Teclas.Terminal), accion: accionTerminal},
+		{titulo: "📶 " + T("modo.red"), desc: descripcionModoRed(tienda), atajo: atajoPrincipal(Teclas.ModoRed), accion: accionModoRed},
+		{titulo: Icons.Logs + " " + T("popup.copiar_pagina"), atajo: atajoPrincipal(Teclas.CopiarPagina), accion: accionCopiarPagina},
+		{titulo: Icons.Logs + " " + T("popup.copiar_todo"), atajo: atajoPrincipal(Teclas.CopiarTodo), accion: accionCopiarTodo},
 	}
 
+	if hayBusqueda {
+		opciones = append(opciones, itemMenu{titulo: Icons.Search + " " + T("popup.copiar_coincidencias"), atajo: atajoPrincipal(Teclas.CopiarCoincidencias), accion: accionCopiarCoincidencias})
+	}
+
+	opciones = append(opciones,
+		itemMenu{titulo: Icons.Download + " " + T("popup.exportar_texto"), atajo: atajoPrincipal(Teclas.ExportarTexto), accion: accionExportarTexto},
+		itemMenu{titulo: Icons.Download + " " + T("popup.exportar_json"), atajo: atajoPrincipal(Teclas.ExportarJSON), accion: accionExportarJSON},
+	)
+
 	if tieneServidor {
 		opciones = append([]itemMenu{
-			{titulo: Icons.Stop + " Detener", desc: "Parar servidor", atajo: "s"},
+			{titulo: Icons.Stop + " " + T("modo.detener"), desc: T("modo.detener.desc"), atajo: atajoPrincipal(Teclas.Detener), accion: accionDetener},
 		}, opciones...)
 	}
 
@@ -771,7 +1209,7 @@ func crearOpcionesPopup(tieneServidor bool) []itemMenu {
 func (m Model) updatePopup(msg tea.Msg) (tea.Model, tea.Cmd) {
 	gestor := ObtenerGestor()
 	tieneServidor := gestor.TieneServidorActivo(m.tiendaParaDev.Nombre)
-	opciones := crearOpcionesPopup(tieneServidor)
+	opciones := crearOpcionesPopup(m.tiendaParaDev, tieneServidor, m.busquedaLogs != "")
 
 	ejecutarOpcion := func(indice int) (tea.Model, tea.Cmd) {
 		if indice < 0 || indice >= len(opciones) {
@@ -781,91 +1219,106 @@ func (m Model) updatePopup(msg tea.Msg) (tea.Model, tea.Cmd) {
 		opcion := opciones[indice]
 		m.vista = VistaLogs
 
-		switch {
-		case strings.Contains(opcion.titulo, "Detener"):
+		switch opcion.accion {
+		case accionDetener:
 			if err := gestor.DetenerServidor(m.tiendaParaDev.Nombre); err != nil {
 				m.mensaje = IconError(err.Error())
 			} else {
-				m.mensaje = Icons.Stop + " Servidor detenido"
+				m.mensaje = Icons.Stop + " " + T("servidor.detenido")
 				m.vista = VistaMenu
 				m.recrearMenuPrincipal()
 			}
 			return m, nil
 
-		case strings.Contains(opcion.titulo, "Pull"):
-			return m, ejecutarThemePull(m.tiendaParaDev)
+		case accionPull:
+			return m, lanzarTrabajo(accionPull, m.tiendaParaDev)
 
-		case strings.Contains(opcion.titulo, "Push"):
-			return m, ejecutarThemePush(m.tiendaParaDev)
+		case accionPush:
+			return m, lanzarTrabajo(accionPush, m.tiendaParaDev)
 
-		case strings.Contains(opcion.titulo, "Editor"):
+		case accionEditor:
 			return m, ejecutarAbrirEditor(m.tiendaParaDev)
 
-		case strings.Contains(opcion.titulo, "Terminal"):
-			return m, ejecutarAbrirTerminal(m.tiendaParaDev)
+		case accionTerminal:
+			return m.abrirEnTerminal(ejecutarAbrirTerminal(m.tiendaParaDev))
+
+		case accionModoRed:
+			return m.alternarModoRed()
+
+		case accionCopiarPagina:
+			m = m.copiarLogs(alcancePagina)
+
+		case accionCopiarTodo:
+			m = m.copiarLogs(alcanceTodo)
+
+		case accionCopiarCoincidencias:
+			m = m.copiarLogs(alcanceCoincidencias)
+
+		case accionExportarTexto:
+			m = m.exportarLogs(formatoTexto)
+
+		case accionExportarJSON:
+			m = m.exportarLogs(formatoJSON)
 		}
 
-		return m, tickCmd()
+		return m, nil
+	}
+
+	ejecutarAccion := func(accion string) (tea.Model, tea.Cmd) {
+		for i, op := range opciones {
+			if op.accion == accion {
+				return ejecutarOpcion(i)
+			}
+		}
+		return m, nil
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		key := msg.String()
-
-		switch key {
-		case "esc", "q", " ", "m", "ctrl+p":
+		switch {
+		case key.Matches(msg, Teclas.Volver, Teclas.Menu):
 			m.vista = VistaLogs
-			return m, tickCmd()
+			return m, nil
 
-		case "j", "down":
+		case key.Matches(msg, Teclas.Abajo):
 			m.popupIndex++
 			if m.popupIndex >= len(opciones) {
 				m.popupIndex = 0
 			}
 			return m, nil
 
-		case "k", "up":
+		case key.Matches(msg, Teclas.Arriba):
 			m.popupIndex--
 			if m.popupIndex < 0 {
 				m.popupIndex = len(opciones) - 1
 			}
 			return m, nil
 
-		case "enter", "l":
+		case key.Matches(msg, Teclas.Seleccionar):
 			return ejecutarOpcion(m.popupIndex)
 
-		case "s":
-			if tieneServidor {
-				for i, op := range opciones {
-					if strings.Contains(op.titulo, "Detener") {
-						return ejecutarOpcion(i)
-					}
-				}
-			}
-		case "p":
-			for i, op := range opciones {
-				if strings.Contains(op.titulo, "Pull") {
-					return ejecutarOpcion(i)
-				}
-			}
-		case "u":
-			for i, op := range opciones {
-				if strings.Contains(op.titulo, "Push") {
-					return ejecutarOpcion(i)
-				}
-			}
-		case "e":
-			for i, op := range opciones {
-				if strings.Contains(op.titulo, "Editor") {
-					return ejecutarOpcion(i)
-				}
-			}
-		case "t":
-			for i, op := range opciones {
-				if strings.Contains(op.titulo, "Terminal") {
-					return ejecutarOpcion(i)
-				}
-			}
+		case key.Matches(msg, Teclas.Detener):
+			return ejecutarAccion(accionDetener)
+		case key.Matches(msg, Teclas.Pull):
+			return ejecutarAccion(accionPull)
+		case key.Matches(msg, Teclas.Push):
+			return ejecutarAccion(accionPush)
+		case key.Matches(msg, Teclas.Editor):
+			return ejecutarAccion(accionEditor)
+		case key.Matches(msg, Teclas.Terminal):
+			return ejecutarAccion(accionTerminal)
+		case key.Matches(msg, Teclas.ModoRed):
+			return ejecutarAccion(accionModoRed)
+		case key.Matches(msg, Teclas.CopiarPagina):
+			return ejecutarAccion(accionCopiarPagina)
+		case key.Matches(msg, Teclas.CopiarTodo):
+			return ejecutarAccion(accionCopiarTodo)
+		case key.Matches(msg, Teclas.CopiarCoincidencias):
+			return ejecutarAccion(accionCopiarCoincidencias)
+		case key.Matches(msg, Teclas.ExportarTexto):
+			return ejecutarAccion(accionExportarTexto)
+		case key.Matches(msg, Teclas.ExportarJSON):
+			return ejecutarAccion(accionExportarJSON)
 		}
 	}
 
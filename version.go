@@ -2,13 +2,23 @@ package main
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 const (
 	Version        = "1.5.6"
 	NpmPackageName = "shopify-cli-tui"
+
+	variableSinActualizaciones = "SHOPIFY_TUI_NO_UPDATE_CHECK"
+	horasActualizacionDefecto  = 24
 )
 
 type NpmPackageInfo struct {
@@ -17,33 +27,174 @@ type NpmPackageInfo struct {
 	} `json:"dist-tags"`
 }
 
-func verificarActualizacion() (hayActualizacion bool, versionNueva string) {
+type ConfigActualizaciones struct {
+	Desactivadas bool `json:"desactivadas,omitempty"`
+	Horas        int  `json:"horas,omitempty"`
+}
+
+var ActualizacionesGlobal = ConfigActualizaciones{Horas: horasActualizacionDefecto}
+
+func InitActualizaciones(config ConfigActualizaciones) error {
+	if config.Horas < 0 {
+		return errors.New(T("version.horas_invalidas", config.Horas))
+	}
+	if config.Horas == 0 {
+		config.Horas = horasActualizacionDefecto
+	}
+	if os.Getenv(variableSinActualizaciones) != "" {
+		config.Desactivadas = true
+	}
+
+	ActualizacionesGlobal = config
+	return nil
+}
+
+type cacheVersion struct {
+	Comprobado time.Time `json:"comprobado"`
+	Ultima     string    `json:"ultima"`
+}
+
+type actualizacionMsg struct {
+	version string
+}
+
+func obtenerRutaCacheVersion() (string, error) {
+	dirBase, err := obtenerDirectorioBase()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dirBase, "version.json"), nil
+}
+
+func leerCacheVersion() (cacheVersion, bool) {
+	rutaArchivo, err := obtenerRutaCacheVersion()
+	if err != nil {
+		return cacheVersion{}, false
+	}
+
+	datos, err := os.ReadFile(rutaArchivo)
+	if err != nil {
+		return cacheVersion{}, false
+	}
+
+	var cache cacheVersion
+	if err := json.Unmarshal(datos, &cache); err != nil {
+		return cacheVersion{}, false
+	}
+
+	vigencia := time.Duration(ActualizacionesGlobal.Horas) * time.Hour
+	if time.Since(cache.Comprobado) > vigencia || cache.Comprobado.After(time.Now()) {
+		return cacheVersion{}, false
+	}
+	return cache, true
+}
+
+func guardarCacheVersion(cache cacheVersion) error {
+	if err := crearDirectorioBase(); err != nil {
+		return err
+	}
+
+	rutaArchivo, err := obtenerRutaCacheVersion()
+	if err != nil {
+		return err
+	}
+
+	datos, err := json.MarshalIndent(cache, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(rutaArchivo, datos, 0644)
+}
+
+func consultarUltimaVersion() (string, error) {
 	client := &http.Client{
 		Timeout: 3 * time.Second,
 	}
 
 	resp, err := client.Get("https://registry.npmjs.org/" + NpmPackageName)
 	if err != nil {
-		return false, ""
+		return "", err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != 200 {
-		return false, ""
+		return "", errors.New(resp.Status)
 	}
 
 	var info NpmPackageInfo
 	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
-		return false, ""
+		return "", err
+	}
+
+	return info.DistTags.Latest, nil
+}
+
+func verificarActualizacion() tea.Cmd {
+	if ActualizacionesGlobal.Desactivadas {
+		return nil
+	}
+
+	return func() tea.Msg {
+		cache, vigente := leerCacheVersion()
+		if !vigente {
+			ultima, err := consultarUltimaVersion()
+			if err != nil {
+				return actualizacionMsg{}
+			}
+			cache = cacheVersion{Comprobado: time.Now(), Ultima: ultima}
+			guardarCacheVersion(cache)
+		}
+
+		if compararVersiones(Version, cache.Ultima) {
+			return actualizacionMsg{version: cache.Ultima}
+		}
+		return actualizacionMsg{}
+	}
+}
+
+type versionSemantica struct {
+	numeros        [3]int
+	prelanzamiento string
+}
+
+func parsearVersion(texto string) (versionSemantica, bool) {
+	texto = strings.TrimPrefix(strings.TrimSpace(texto), "v")
+	if i := strings.IndexByte(texto, '+'); i >= 0 {
+		texto = texto[:i]
 	}
 
-	if info.DistTags.Latest != Version {
-		return true, info.DistTags.Latest
+	var version versionSemantica
+	if i := strings.IndexByte(texto, '-'); i >= 0 {
+		version.prelanzamiento = texto[i+1:]
+		texto = texto[:i]
 	}
 
-	return false, ""
+	partes := strings.Split(texto, ".")
+	if len(partes) != 3 {
+		return versionSemantica{}, false
+	}
+	for i, parte := range partes {
+		numero, err := strconv.Atoi(parte)
+		if err != nil || numero < 0 {
+			return versionSemantica{}, false
+		}
+		version.numeros[i] = numero
+	}
+	return version, true
 }
 
 func compararVersiones(actual, nueva string) bool {
-	return actual != nueva
+	vActual, okActual := parsearVersion(actual)
+	vNueva, okNueva := parsearVersion(nueva)
+	if !okActual || !okNueva || vNueva.prelanzamiento != "" {
+		return false
+	}
+
+	for i := range vNueva.numeros {
+		if vNueva.numeros[i] != vActual.numeros[i] {
+			return vNueva.numeros[i] > vActual.numeros[i]
+		}
+	}
+	return vActual.prelanzamiento != ""
 }
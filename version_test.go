@@ -0,0 +1,157 @@
+package main
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestParsearVersion(t *testing.T) {
+	casos := []struct {
+		texto    string
+		esperado versionSemantica
+		ok       bool
+	}{
+		{"1.5.6", versionSemantica{numeros: [3]int{1, 5, 6}}, true},
+		{"v2.0.10", versionSemantica{numeros: [3]int{2, 0, 10}}, true},
+		{" 1.2.3 ", versionSemantica{numeros: [3]int{1, 2, 3}}, true},
+		{"1.6.0-beta.1", versionSemantica{numeros: [3]int{1, 6, 0}, prelanzamiento: "beta.1"}, true},
+		{"1.6.0+build.7", versionSemantica{numeros: [3]int{1, 6, 0}}, true},
+		{"1.6.0-rc.1+build.7", versionSemantica{numeros: [3]int{1, 6, 0}, prelanzamiento: "rc.1"}, true},
+		{"1.6", versionSemantica{}, false},
+		{"1.6.0.1", versionSemantica{}, false},
+		{"1.x.0", versionSemantica{}, false},
+		{"1.-1.0", versionSemantica{}, false},
+		{"", versionSemantica{}, false},
+	}
+
+	for _, caso := range casos {
+		version, ok := parsearVersion(caso.texto)
+		if ok != caso.ok || version != caso.esperado {
+			t.Errorf("parsearVersion(%q) = %+v, %v; se esperaba %+v, %v", caso.texto, version, ok, caso.esperado, caso.ok)
+		}
+	}
+}
+
+func TestCompararVersiones(t *testing.T) {
+	casos := []struct {
+		actual, nueva string
+		esperado      bool
+	}{
+		{"1.5.6", "1.5.7", true},
+		{"1.5.6", "1.6.0", true},
+		{"1.5.6", "2.0.0", true},
+		{"1.5.6", "1.5.6", false},
+		{"1.5.6", "1.5.5", false},
+		{"1.9.0", "1.10.0", true},
+		{"1.10.0", "1.9.0", false},
+		{"v1.5.6", "1.5.7", true},
+		{"1.5.6", "v1.5.7", true},
+		{"1.6.0-beta.1", "1.6.0", true},
+		{"1.5.6", "1.6.0-beta.1", false},
+		{"1.6.0-beta.1", "1.6.0-beta.2", false},
+		{"1.5.6", "1.5.7+build.3", true},
+		{"1.5.6", "latest", false},
+		{"dev", "1.5.7", false},
+		{"1.5.6", "", false},
+	}
+
+	for _, caso := range casos {
+		if got := compararVersiones(caso.actual, caso.nueva); got != caso.esperado {
+			t.Errorf("compararVersiones(%q, %q) = %v, se esperaba %v", caso.actual, caso.nueva, got, caso.esperado)
+		}
+	}
+}
+
+func TestCacheVersion(t *testing.T) {
+	anterior := ActualizacionesGlobal
+	t.Cleanup(func() { ActualizacionesGlobal = anterior })
+	ActualizacionesGlobal = ConfigActualizaciones{Horas: 24}
+
+	casos := []struct {
+		nombre     string
+		comprobado time.Time
+		vigente    bool
+	}{
+		{"reciente", time.Now().Add(-time.Hour), true},
+		{"caducada", time.Now().Add(-25 * time.Hour), false},
+		{"en el futuro", time.Now().Add(time.Hour), false},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("USERPROFILE", home)
+
+			if _, vigente := leerCacheVersion(); vigente {
+				t.Fatal("sin archivo no debería haber caché")
+			}
+			if err := guardarCacheVersion(cacheVersion{Comprobado: caso.comprobado, Ultima: "1.6.0"}); err != nil {
+				t.Fatalf("guardarCacheVersion: %v", err)
+			}
+
+			cache, vigente := leerCacheVersion()
+			if vigente != caso.vigente {
+				t.Fatalf("leerCacheVersion() vigente = %v, se esperaba %v", vigente, caso.vigente)
+			}
+			if vigente && cache.Ultima != "1.6.0" {
+				t.Fatalf("Ultima = %q, se esperaba 1.6.0", cache.Ultima)
+			}
+		})
+	}
+
+	t.Run("archivo corrupto", func(t *testing.T) {
+		home := t.TempDir()
+		t.Setenv("HOME", home)
+		t.Setenv("USERPROFILE", home)
+
+		rutaArchivo, err := obtenerRutaCacheVersion()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := crearDirectorioBase(); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(rutaArchivo, []byte("{no es json"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		if _, vigente := leerCacheVersion(); vigente {
+			t.Fatal("un archivo corrupto no debería contar como caché vigente")
+		}
+	})
+}
+
+func TestInitActualizaciones(t *testing.T) {
+	anterior := ActualizacionesGlobal
+	t.Cleanup(func() { ActualizacionesGlobal = anterior })
+
+	casos := []struct {
+		nombre   string
+		config   ConfigActualizaciones
+		entorno  string
+		esperado ConfigActualizaciones
+		error    bool
+	}{
+		{"por defecto", ConfigActualizaciones{}, "", ConfigActualizaciones{Horas: horasActualizacionDefecto}, false},
+		{"horas propias", ConfigActualizaciones{Horas: 6}, "", ConfigActualizaciones{Horas: 6}, false},
+		{"desactivadas", ConfigActualizaciones{Desactivadas: true}, "", ConfigActualizaciones{Desactivadas: true, Horas: horasActualizacionDefecto}, false},
+		{"variable de entorno", ConfigActualizaciones{Horas: 6}, "1", ConfigActualizaciones{Desactivadas: true, Horas: 6}, false},
+		{"horas negativas", ConfigActualizaciones{Horas: -1}, "", ConfigActualizaciones{}, true},
+	}
+
+	for _, caso := range casos {
+		t.Run(caso.nombre, func(t *testing.T) {
+			t.Setenv(variableSinActualizaciones, caso.entorno)
+			ActualizacionesGlobal = ConfigActualizaciones{Horas: horasActualizacionDefecto}
+
+			err := InitActualizaciones(caso.config)
+			if (err != nil) != caso.error {
+				t.Fatalf("InitActualizaciones(%+v) error = %v", caso.config, err)
+			}
+			if !caso.error && ActualizacionesGlobal != caso.esperado {
+				t.Fatalf("ActualizacionesGlobal = %+v, se esperaba %+v", ActualizacionesGlobal, caso.esperado)
+			}
+		})
+	}
+}
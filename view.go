@@ -5,57 +5,41 @@ import (
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 )
 
 var (
-	estiloTitulo = lipgloss.NewStyle().
-			Bold(true).
-			Foreground(lipgloss.Color("#7D56F4")).
-			MarginBottom(1)
-
-	estiloExito = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#04B575")).
-			Bold(true)
-
-	estiloError = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FF6B6B")).
-			Bold(true)
-
-	estiloContenedor = lipgloss.NewStyle().
-				Padding(1, 2).
-				Border(lipgloss.RoundedBorder()).
-				BorderForeground(lipgloss.Color("#7D56F4"))
-
-	estiloInputActivo = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#7D56F4")).
-				Bold(true)
-
-	estiloLabel = lipgloss.NewStyle().
-			Bold(true).
-			MarginBottom(0)
-
-	estiloAyuda = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262")).
-			MarginTop(1)
-
-	estiloInfo = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#7D56F4")).
-			Italic(true)
-
-	estiloAtajo = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#FFB800")).
-			Bold(true)
-
-	estiloItemNormal = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#FFFFFF"))
-
-	estiloItemSeleccionado = lipgloss.NewStyle().
-				Foreground(lipgloss.Color("#7D56F4")).
-				Bold(true)
-
-	estiloDesc = lipgloss.NewStyle().
-			Foreground(lipgloss.Color("#626262"))
+	estiloTitulo           lipgloss.Style
+	estiloExito            lipgloss.Style
+	estiloError            lipgloss.Style
+	estiloContenedor       lipgloss.Style
+	estiloInputActivo      lipgloss.Style
+	estiloLabel            lipgloss.Style
+	estiloAyuda            lipgloss.Style
+	estiloInfo             lipgloss.Style
+	estiloAtajo            lipgloss.Style
+	estiloItemNormal       lipgloss.Style
+	estiloItemSeleccionado lipgloss.Style
+	estiloDesc             lipgloss.Style
+	estiloAviso            lipgloss.Style
+	estiloEnlace           lipgloss.Style
+	estiloComando          lipgloss.Style
+	estiloPopup            lipgloss.Style
+	estiloPopupTitulo      lipgloss.Style
+	estiloCoincidencia     lipgloss.Style
+	estiloQR               lipgloss.Style
+
+	estiloDiffAgregado       lipgloss.Style
+	estiloDiffEliminado      lipgloss.Style
+	estiloSintaxisPalabra    lipgloss.Style
+	estiloSintaxisCadena     lipgloss.Style
+	estiloSintaxisNumero     lipgloss.Style
+	estiloSintaxisClave      lipgloss.Style
+	estiloSintaxisComentario lipgloss.Style
+	estiloSintaxisEtiqueta   lipgloss.Style
+	estiloSintaxisFuncion    lipgloss.Style
 )
 
 func renderMenuConAtajos(items []itemMenu, selectedIndex int, titulo string) string {
@@ -89,49 +73,115 @@ func renderMenuConAtajos(items []itemMenu, selectedIndex int, titulo string) str
 	return b.String()
 }
 
-func renderListaTiendas(tiendas []Tienda, selectedIndex int, titulo string) string {
+func (m Model) renderListaTiendas(titulo string) string {
 	var b strings.Builder
 
 	b.WriteString(estiloTitulo.Render(titulo))
-	b.WriteString("\n\n")
+	b.WriteString("\n")
+	if m.lista.SettingFilter() || m.lista.IsFiltered() {
+		b.WriteString(m.lista.FilterInput.View())
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
-	for i, tienda := range tiendas {
+	visibles := m.lista.VisibleItems()
+	if len(visibles) == 0 {
+		b.WriteString(estiloAviso.Render(T("tiendas.sin_coincidencias", m.lista.FilterInput.Value())))
+		b.WriteString("\n\n")
+		return b.String()
+	}
 
-		num := estiloAtajo.Render(fmt.Sprintf("[%d]", i+1))
+	selectedIndex := m.lista.Index()
+	inicio, fin := m.lista.Paginator.GetSliceBounds(len(visibles))
 
-		var nombre string
-		tieneServidor := ObtenerGestor().TieneServidorActivo(tienda.Nombre)
-		if tieneServidor {
-			nombre = Icons.ServerOn + " " + tienda.Nombre
-		} else {
-			nombre = tienda.Nombre
+	numero := 0
+	for i := inicio; i < fin; i++ {
+		cursor := "  "
+		if i == selectedIndex {
+			cursor = "> "
 		}
 
-		if i == selectedIndex {
-			nombre = estiloItemSeleccionado.Render(nombre)
-		} else {
-			nombre = estiloItemNormal.Render(nombre)
+		if grupo, ok := visibles[i].(itemGrupo); ok {
+			flecha := "▾"
+			if grupo.colapsado {
+				flecha = "▸"
+			}
+			encabezado := fmt.Sprintf("%s %s (%d)", flecha, grupo.nombre, grupo.cantidad)
+			if i == selectedIndex {
+				encabezado = estiloItemSeleccionado.Render(encabezado)
+			} else {
+				encabezado = estiloLabel.Render(encabezado)
+			}
+			b.WriteString(cursor + m.marcaGrupo(grupo) + encabezado + "\n")
+			continue
 		}
 
-		metodo := Icons.Download + " pull"
-		if tienda.Metodo == MetodoGitClone {
-			metodo = Icons.Git + " git"
+		item, ok := visibles[i].(itemTienda)
+		if !ok {
+			continue
 		}
-		desc := estiloDesc.Render(tienda.URL + " [" + metodo + "]")
+		tienda := item.tienda
+		coincidencias := m.lista.MatchesForItem(i)
 
-		cursor := "  "
+		numero++
+		num := estiloAtajo.Render(fmt.Sprintf("[%d]", numero))
+
+		estiloNombre := estiloItemNormal
 		if i == selectedIndex {
-			cursor = "> "
+			estiloNombre = estiloItemSeleccionado
+		}
+		nombre := resaltarCoincidencias(tienda.Nombre, rangoCampo(tienda, campoNombre), coincidencias, estiloNombre)
+		if servidor := m.instantanea(tienda.Nombre); servidor != nil && servidor.Activo() {
+			nombre = estiloNombre.Render(Icons.ServerOn+" ") + nombre
+			if salud := servidor.Salud; salud.Estado == saludDegradada || salud.Estado == saludCaida {
+				nombre += " " + renderSalud(salud)
+			}
+		}
+		if tienda.Favorita {
+			nombre = estiloAviso.Render("★ ") + nombre
 		}
 
-		b.WriteString(fmt.Sprintf("%s%s %s\n", cursor, num, nombre))
+		metodo := Icons.Download + " " + T("tienda.metodo.pull")
+		if tienda.Metodo == MetodoGitClone {
+			metodo = Icons.Git + " " + T("tienda.metodo.git")
+		}
+		desc := resaltarCoincidencias(tienda.URL, rangoCampo(tienda, campoURL), coincidencias, estiloDesc) +
+			estiloDesc.Render(" ["+metodo+"]")
+		if len(tienda.Etiquetas) > 0 {
+			desc += " " + resaltarCoincidencias(textoEtiquetas(tienda.Etiquetas),
+				rangoCampo(tienda, campoEtiquetas), coincidencias, estiloEnlace)
+		}
+		if resumen := resumenActividad(tienda.Nombre); resumen != "" {
+			desc += estiloDesc.Render(" · " + resumen)
+		}
+
+		b.WriteString(fmt.Sprintf("%s%s%s %s\n", cursor, m.marcaTienda(tienda), num, nombre))
 		b.WriteString(fmt.Sprintf("      %s\n", desc))
+
+		if estable := URLEstable(tienda); estable != "" {
+			b.WriteString(fmt.Sprintf("      %s\n", estiloEnlace.Render("🌐 "+estable)))
+		}
+
+		if tienda.GitURL != "" && coincidenEnCampo(tienda, campoGit, coincidencias) {
+			b.WriteString(fmt.Sprintf("      %s\n",
+				resaltarCoincidencias(tienda.GitURL, rangoCampo(tienda, campoGit), coincidencias, estiloDesc)))
+		}
 	}
 
 	return b.String()
 }
 
 func (m Model) View() string {
+	barra := m.barraTrabajos()
+	if barra == "" {
+		return m.conTerminal(m.vistaActual())
+	}
+
+	m.alto--
+	return recortarAlto(m.conTerminal(m.vistaActual()), m.alto) + "\n" + barra
+}
+
+func (m Model) vistaActual() string {
 	switch m.vista {
 	case VistaMenu:
 		return m.vistaMenu()
@@ -151,6 +201,22 @@ func (m Model) View() string {
 		return m.vistaServidores()
 	case VistaPopup:
 		return m.vistaPopup()
+	case VistaEditarTienda:
+		return m.vistaEditarTienda()
+	case VistaPanel:
+		return m.vistaPanel()
+	case VistaNovedades:
+		return m.vistaNovedades()
+	case VistaLote:
+		return m.vistaLote()
+	case VistaTrabajo:
+		return m.vistaTrabajo()
+	case VistaTrabajos:
+		return m.vistaTrabajos()
+	case VistaDiff:
+		return m.vistaDiff()
+	case VistaDiffArchivo:
+		return m.vistaDiffArchivo()
 	default:
 		return m.vistaMenu()
 	}
@@ -168,11 +234,10 @@ func (m Model) vistaMenu() string {
 	s := renderMenuConAtajos(items, m.lista.Index(), Icons.App+" Shopify TUI")
 
 	if m.hayActualizacion {
-		estiloAviso := lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD700")).Bold(true)
-		estiloVersion := lipgloss.NewStyle().Foreground(lipgloss.Color("#00BFFF"))
-		estiloComando := lipgloss.NewStyle().Foreground(lipgloss.Color("#00FF00"))
-		s += "\n\n" + estiloAviso.Render("⚡ Nueva versión disponible: ") + estiloVersion.Render(m.versionNueva) + estiloAviso.Render(" (actual: "+Version+")")
-		s += "\n" + estiloAviso.Render("📦 Actualiza: ") + estiloComando.Render("npm update -g shopify-cli-tui")
+		s += "\n\n" + estiloAviso.Render("⚡ "+T("version.disponible")+" ") + estiloEnlace.Render(m.versionNueva) + estiloAviso.Render(" "+T("version.actual", Version))
+		s += "\n" + estiloAviso.Render("📦 "+T("version.actualiza")+" ") + estiloComando.Render("sho self-update") +
+			estiloAviso.Render(" "+T("version.tecla", ayudaTecla(Teclas.Actualizar)))
+		s += "\n" + estiloDesc.Render("📝 "+T("version.novedades", ayudaTecla(Teclas.Novedades)))
 	}
 
 	if m.mensaje != "" {
@@ -184,11 +249,18 @@ func (m Model) vistaMenu() string {
 		}
 	}
 
-	servidoresActivos := ObtenerGestor().ContarActivos()
+	servidoresActivos := len(m.servidoresActivos())
 	s += "\n" + estiloAyuda.Render(
-		fmt.Sprintf("Tiendas: %d | Servidores: %d", len(m.tiendas), servidoresActivos),
+		T("menu.resumen", len(m.tiendas), servidoresActivos),
 	)
-	s += "\n" + estiloAyuda.Render("[A/T/D/V] j/k l/enter: seleccionar | Ctrl+Q: salir")
+	s += "\n" + estiloAyuda.Render(T("ayuda.menu",
+		strings.ToUpper(strings.Join([]string{
+			ayudaTecla(Teclas.Login), ayudaTecla(Teclas.AgregarTienda),
+			ayudaTecla(Teclas.Desarrollo), ayudaTecla(Teclas.Servidores), ayudaTecla(Teclas.Trabajos),
+		}, "/")),
+		ayudaTecla(Teclas.Abajo), ayudaTecla(Teclas.Arriba),
+		ayudaTecla(Teclas.Seleccionar), ayudaTecla(Teclas.Salir),
+	))
 
 	return s
 }
@@ -196,30 +268,30 @@ func (m Model) vistaMenu() string {
 func (m Model) vistaAgregarTienda() string {
 	var b strings.Builder
 
-	b.WriteString(estiloTitulo.Render("➕ Agregar Nueva Tienda"))
+	b.WriteString(estiloTitulo.Render("➕ " + T("agregar.titulo")))
 	b.WriteString("\n\n")
 
-	b.WriteString(estiloInfo.Render("Paso 1 de 2: Información básica"))
+	b.WriteString(estiloInfo.Render(T("agregar.paso")))
 	b.WriteString("\n\n")
 
 	if m.cursorInput == 0 {
-		b.WriteString(estiloInputActivo.Render("> Nombre de la tienda:"))
+		b.WriteString(estiloInputActivo.Render("> " + T("agregar.nombre")))
 	} else {
-		b.WriteString(estiloLabel.Render("  Nombre de la tienda:"))
+		b.WriteString(estiloLabel.Render("  " + T("agregar.nombre")))
 	}
 	b.WriteString("\n")
 	b.WriteString("  " + m.inputNombre.View())
 	b.WriteString("\n")
-	b.WriteString(estiloAyuda.Render("    Un nombre para identificar la tienda"))
+	b.WriteString(estiloAyuda.Render("    " + T("agregar.nombre.ayuda")))
 	b.WriteString("\n\n")
 
 	if m.cursorInput == 1 {
-		b.WriteString(estiloInputActivo.Render("> URL de Shopify:"))
+		b.WriteString(estiloInputActivo.Render("> " + T("agregar.url")))
 	} else {
-		b.WriteString(estiloLabel.Render("  URL de Shopify:"))
+		b.WriteString(estiloLabel.Render("  " + T("agregar.url")))
 	}
 	b.WriteString("\n")
-	b.WriteString("  " + m.inputURL.View() + lipgloss.NewStyle().Foreground(lipgloss.Color("#00BFFF")).Render(".myshopify.com"))
+	b.WriteString("  " + m.inputURL.View() + estiloEnlace.Render(".myshopify.com"))
 	b.WriteString("\n\n")
 
 	if m.mensaje != "" {
@@ -227,7 +299,54 @@ func (m Model) vistaAgregarTienda() string {
 		b.WriteString("\n\n")
 	}
 
-	b.WriteString(estiloAyuda.Render("tab: cambiar campo • enter: continuar • esc: cancelar"))
+	b.WriteString(estiloAyuda.Render(T("ayuda.agregar",
+		ayudaTecla(Teclas.CampoSiguiente), ayudaTecla(Teclas.Aceptar), ayudaTecla(Teclas.Volver),
+	)))
+
+	return estiloContenedor.Render(b.String())
+}
+
+func (m Model) vistaEditarTienda() string {
+	var b strings.Builder
+
+	nombre := ""
+	if m.indiceEdicion >= 0 && m.indiceEdicion < len(m.tiendas) {
+		nombre = m.tiendas[m.indiceEdicion].Nombre
+	}
+
+	b.WriteString(estiloTitulo.Render("🏷️  " + T("editar.titulo", nombre)))
+	b.WriteString("\n\n")
+
+	if m.cursorInput == 0 {
+		b.WriteString(estiloInputActivo.Render("> " + T("editar.etiquetas")))
+	} else {
+		b.WriteString(estiloLabel.Render("  " + T("editar.etiquetas")))
+	}
+	b.WriteString("\n")
+	b.WriteString("  " + m.inputEtiquetas.View())
+	b.WriteString("\n")
+	b.WriteString(estiloAyuda.Render("    " + T("editar.etiquetas.ayuda")))
+	b.WriteString("\n\n")
+
+	if m.cursorInput == 1 {
+		b.WriteString(estiloInputActivo.Render("> " + T("editar.grupo")))
+	} else {
+		b.WriteString(estiloLabel.Render("  " + T("editar.grupo")))
+	}
+	b.WriteString("\n")
+	b.WriteString("  " + m.inputGrupo.View())
+	b.WriteString("\n")
+	b.WriteString(estiloAyuda.Render("    " + T("editar.grupo.ayuda")))
+	b.WriteString("\n\n")
+
+	if m.mensaje != "" {
+		b.WriteString(estiloError.Render(m.mensaje))
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(estiloAyuda.Render(T("ayuda.editar",
+		ayudaTecla(Teclas.CampoSiguiente), ayudaTecla(Teclas.Aceptar), ayudaTecla(Teclas.Volver),
+	)))
 
 	return estiloContenedor.Render(b.String())
 }
@@ -235,13 +354,13 @@ func (m Model) vistaAgregarTienda() string {
 func (m Model) vistaSeleccionarMetodo() string {
 	var b strings.Builder
 
-	b.WriteString(estiloTitulo.Render(Icons.Download + " Método de descarga"))
+	b.WriteString(estiloTitulo.Render(Icons.Download + " " + T("metodo.titulo")))
 	b.WriteString("\n\n")
 
-	b.WriteString(estiloLabel.Render("Tienda: "))
+	b.WriteString(estiloLabel.Render(T("etiqueta.tienda") + " "))
 	b.WriteString(m.tiendaTemporal.Nombre)
 	b.WriteString("\n")
-	b.WriteString(estiloLabel.Render("URL: "))
+	b.WriteString(estiloLabel.Render(T("etiqueta.url") + " "))
 	b.WriteString(m.tiendaTemporal.URL)
 	b.WriteString("\n\n")
 
@@ -252,7 +371,7 @@ func (m Model) vistaSeleccionarMetodo() string {
 		}
 	}
 
-	b.WriteString(renderMenuConAtajos(items, m.lista.Index(), "Elige método"))
+	b.WriteString(renderMenuConAtajos(items, m.lista.Index(), T("metodo.elige")))
 	b.WriteString("\n")
 
 	if m.mensaje != "" {
@@ -260,7 +379,10 @@ func (m Model) vistaSeleccionarMetodo() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString(estiloAyuda.Render("[S]hopify Pull | [G]it Clone | l/enter | q: volver"))
+	b.WriteString(estiloAyuda.Render(T("ayuda.metodo",
+		strings.ToUpper(ayudaTecla(Teclas.ShopifyPull)), strings.ToUpper(ayudaTecla(Teclas.GitClone)),
+		ayudaTecla(Teclas.Seleccionar), ayudaTecla(Teclas.Volver),
+	)))
 
 	return b.String()
 }
@@ -268,23 +390,23 @@ func (m Model) vistaSeleccionarMetodo() string {
 func (m Model) vistaInputGit() string {
 	var b strings.Builder
 
-	b.WriteString(estiloTitulo.Render("🔗 Clonar desde Git"))
+	b.WriteString(estiloTitulo.Render("🔗 " + T("git.titulo")))
 	b.WriteString("\n\n")
 
-	b.WriteString(estiloLabel.Render("Tienda: "))
+	b.WriteString(estiloLabel.Render(T("etiqueta.tienda") + " "))
 	b.WriteString(m.tiendaTemporal.Nombre)
 	b.WriteString("\n\n")
 
-	b.WriteString(estiloInputActivo.Render("> URL del repositorio:"))
+	b.WriteString(estiloInputActivo.Render("> " + T("git.url")))
 	b.WriteString("\n")
 	b.WriteString("  " + m.inputGit.View())
 	b.WriteString("\n\n")
 
-	b.WriteString(estiloAyuda.Render("Ejemplos:"))
+	b.WriteString(estiloAyuda.Render(T("git.ejemplos")))
 	b.WriteString("\n")
-	b.WriteString(estiloAyuda.Render("  SSH:   git@github.com:usuario/tema.git"))
+	b.WriteString(estiloAyuda.Render("  SSH:   " + T("git.ejemplo.ssh")))
 	b.WriteString("\n")
-	b.WriteString(estiloAyuda.Render("  HTTPS: https://github.com/usuario/tema.git"))
+	b.WriteString(estiloAyuda.Render("  HTTPS: " + T("git.ejemplo.https")))
 	b.WriteString("\n\n")
 
 	if m.mensaje != "" {
@@ -292,7 +414,9 @@ func (m Model) vistaInputGit() string {
 		b.WriteString("\n\n")
 	}
 
-	b.WriteString(estiloAyuda.Render("enter: clonar | q: volver"))
+	b.WriteString(estiloAyuda.Render(T("ayuda.git",
+		ayudaTecla(Teclas.Aceptar), ayudaTecla(Teclas.Volver),
+	)))
 
 	return estiloContenedor.Render(b.String())
 }
@@ -301,21 +425,25 @@ func (m Model) vistaSeleccionarTienda() string {
 	var b strings.Builder
 
 	if len(m.tiendas) == 0 {
-		b.WriteString(estiloTitulo.Render("🚀 Ejecutar Theme Dev"))
+		b.WriteString(estiloTitulo.Render("🚀 " + T("tiendas.vacio.titulo")))
 		b.WriteString("\n\n")
-		b.WriteString(estiloError.Render("No hay tiendas guardadas."))
+		b.WriteString(estiloError.Render(T("tiendas.vacio")))
 		b.WriteString("\n")
-		b.WriteString(estiloAyuda.Render("Primero agrega una tienda desde el menú principal."))
+		b.WriteString(estiloAyuda.Render(T("tiendas.vacio.ayuda")))
 		b.WriteString("\n\n")
-		b.WriteString(estiloAyuda.Render("q: volver"))
+		b.WriteString(estiloAyuda.Render(T("ayuda.volver", ayudaTecla(Teclas.Volver))))
 		return estiloContenedor.Render(b.String())
 	}
 
-	s := renderListaTiendas(m.tiendas, m.lista.Index(), Icons.Server+" Selecciona tienda")
+	s := m.renderListaTiendas(m.tituloTiendas())
+
+	if tienda, _, ok := m.tiendaVisible(m.lista.Index()); ok {
+		s += estiloInfo.Render("📁 " + tienda.Ruta)
+		s += "\n"
+	}
 
-	idx := m.lista.Index()
-	if idx >= 0 && idx < len(m.tiendas) {
-		s += estiloInfo.Render("📁 " + m.tiendas[idx].Ruta)
+	if marcadas := len(m.tiendasMarcadas()); marcadas > 0 {
+		s += estiloInfo.Render("◉ " + T("tiendas.marcadas", marcadas, ayudaTecla(Teclas.Pull), ayudaTecla(Teclas.Push)))
 		s += "\n"
 	}
 
@@ -328,7 +456,21 @@ func (m Model) vistaSeleccionarTienda() string {
 		s += "\n"
 	}
 
-	s += estiloAyuda.Render("[1-9] l/enter: iniciar servidor | d: eliminar | q: volver")
+	if m.lista.SettingFilter() {
+		s += estiloAyuda.Render(T("tiendas.filtro_ayuda",
+			ayudaTecla(m.lista.KeyMap.AcceptWhileFiltering), ayudaTecla(m.lista.KeyMap.CancelWhileFiltering),
+		))
+		return s
+	}
+
+	s += estiloAyuda.Render(T("ayuda.tiendas",
+		ayudaTecla(Teclas.SeleccionRapida), ayudaTecla(Teclas.Seleccionar),
+		ayudaTecla(Teclas.Filtrar), ayudaTecla(Teclas.Ordenar), ayudaTecla(Teclas.Favorita), ayudaTecla(Teclas.Etiquetar),
+		ayudaTecla(Teclas.Eliminar), ayudaTecla(Teclas.Volver),
+	))
+	s += "\n" + estiloDesc.Render(T("ayuda.tiendas_lote",
+		ayudaTecla(Teclas.Marcar), ayudaTecla(Teclas.MarcarTodas), ayudaTecla(Teclas.Pull), ayudaTecla(Teclas.Push),
+	))
 
 	return s
 }
@@ -336,19 +478,17 @@ func (m Model) vistaSeleccionarTienda() string {
 func (m Model) vistaSeleccionarModo() string {
 	var b strings.Builder
 
-	gestor := ObtenerGestor()
-	tieneServidor := gestor.TieneServidorActivo(m.tiendaParaDev.Nombre)
+	servidor := m.instantanea(m.tiendaParaDev.Nombre)
+	tieneServidor := servidor != nil && servidor.Activo()
 
 	if tieneServidor {
-		b.WriteString(estiloExito.Render("● Servidor activo"))
+		b.WriteString(estiloExito.Render("● " + T("servidor.activo")))
 		b.WriteString("\n")
-
-		for _, s := range gestor.ObtenerServidoresActivos() {
-			if s.Tienda.Nombre == m.tiendaParaDev.Nombre {
-				b.WriteString(estiloInfo.Render("  " + s.URL))
-				b.WriteString("\n")
-				break
-			}
+		b.WriteString(estiloInfo.Render("  " + servidor.URL))
+		b.WriteString("\n")
+		if servidor.URLRed != "" {
+			b.WriteString(estiloInfo.Render("  📶 " + servidor.URLRed))
+			b.WriteString("\n")
 		}
 		b.WriteString("\n")
 	}
@@ -375,11 +515,17 @@ func (m Model) vistaSeleccionarModo() string {
 		b.WriteString("\n")
 	}
 
+	acciones := []key.Binding{Teclas.Iniciar}
 	if tieneServidor {
-		b.WriteString(estiloAyuda.Render("[L]ogs [S]top [P]ull p[U]sh [E]ditor [T]erminal | q: volver"))
-	} else {
-		b.WriteString(estiloAyuda.Render("[I]niciar [P]ull p[U]sh [E]ditor [T]erminal | q: volver"))
+		acciones = []key.Binding{Teclas.Logs, Teclas.Detener}
 	}
+	acciones = append(acciones, Teclas.Pull, Teclas.Push, Teclas.Check, Teclas.Empaquetar, Teclas.Diff, Teclas.Editor, Teclas.Terminal, Teclas.ModoRed)
+
+	var ayuda []string
+	for _, accion := range acciones {
+		ayuda = append(ayuda, "["+strings.ToUpper(ayudaTecla(accion))+"] "+accion.Help().Desc)
+	}
+	b.WriteString(estiloAyuda.Render(strings.Join(ayuda, " ") + " | " + T("ayuda.volver", ayudaTecla(Teclas.Volver))))
 
 	return b.String()
 }
@@ -387,77 +533,86 @@ func (m Model) vistaSeleccionarModo() string {
 func (m Model) vistaLogs() string {
 	var b strings.Builder
 
-	servidor := ObtenerGestor().ObtenerServidor(m.tiendaParaDev.Nombre)
+	servidor := m.instantanea(m.tiendaParaDev.Nombre)
 
-	if servidor != nil && servidor.Activo {
-		b.WriteString(estiloExito.Render(Icons.ServerOn + " " + m.tiendaParaDev.Nombre + " - Servidor activo"))
+	if servidor != nil && servidor.Activo() {
+		b.WriteString(estiloExito.Render(Icons.ServerOn + " " + m.tiendaParaDev.Nombre + " - " + T("servidor.activo")))
 		b.WriteString("\n")
 		b.WriteString(estiloInfo.Render("   " + servidor.URL))
+		if servidor.URLRed != "" {
+			b.WriteString(estiloInfo.Render(" · 📶 " + servidor.URLRed))
+			if m.ocultarQR {
+				b.WriteString(estiloDesc.Render(" · " + T("red.mostrar_qr", ayudaTecla(Teclas.CodigoQR))))
+			}
+		}
+	} else if servidor != nil {
+		ciclo := servidor.Ciclo
+		estilo := estiloAviso
+		if ciclo.Estado == EstadoCaido {
+			estilo = estiloError
+		}
+		b.WriteString(estilo.Render(Icons.Stop + " " + m.tiendaParaDev.Nombre + " - " + ciclo.Estado.Texto()))
+		if ciclo.Estado.Final() {
+			b.WriteString("\n")
+			b.WriteString(estiloDesc.Render("   " + T("servidor.codigo_salida", ciclo.Codigo, ciclo.Finalizado.Format("15:04:05")) +
+				" · " + T("logs.descartar", ayudaTecla(Teclas.Descartar))))
+		}
 	} else {
-		b.WriteString(estiloError.Render(Icons.Stop + " " + m.tiendaParaDev.Nombre + " - Servidor detenido"))
+		b.WriteString(estiloError.Render(Icons.Stop + " " + m.tiendaParaDev.Nombre + " - " + T("servidor.detenido")))
 	}
 	b.WriteString("\n")
 	b.WriteString(strings.Repeat("─", 60))
 	b.WriteString("\n\n")
 
 	if servidor != nil {
-		logs := servidor.ObtenerLogs()
+		logs := servidor.Logs()
 
+		var registro strings.Builder
 		if len(logs) == 0 {
-			b.WriteString(estiloAyuda.Render("Esperando logs del servidor..."))
-			b.WriteString("\n")
+			registro.WriteString(estiloAyuda.Render(T("logs.esperando")))
+			registro.WriteString("\n")
 		} else {
-
-			lineasVisibles := 15
-			if m.alto > 0 {
-				lineasVisibles = m.alto - 12
-				if lineasVisibles < 5 {
-					lineasVisibles = 5
-				}
-			}
-
-			inicio := m.logsScroll
-			fin := inicio + lineasVisibles
-
-			if fin > len(logs) {
-				fin = len(logs)
-			}
-			if inicio > len(logs) {
-				inicio = len(logs)
-			}
-
+			inicio, fin := m.paginaLogs(len(logs))
 			for i := inicio; i < fin; i++ {
-				linea := logs[i]
-
-				if strings.Contains(linea, "error") || strings.Contains(linea, "Error") {
-					b.WriteString(estiloError.Render(linea))
-				} else if strings.Contains(linea, "http://") || strings.Contains(linea, "https://") {
-					b.WriteString(estiloExito.Render(linea))
+				if coincideLog(logs[i], m.busquedaLogs) {
+					registro.WriteString(estiloCoincidencia.Render(ansi.Strip(logs[i])))
 				} else {
-					b.WriteString(linea)
+					registro.WriteString(renderLineaLog(logs[i], servidor.Tienda))
 				}
-				b.WriteString("\n")
+				registro.WriteString("\n")
 			}
+		}
+		b.WriteString(m.conCodigoQR(registro.String(), servidor))
 
-			if len(logs) > lineasVisibles {
-				b.WriteString("\n")
-				porcentaje := 0
-				if len(logs)-lineasVisibles > 0 {
-					porcentaje = (m.logsScroll * 100) / (len(logs) - lineasVisibles)
-				}
-				b.WriteString(estiloAyuda.Render(fmt.Sprintf("Líneas %d-%d de %d (%d%%)", inicio+1, fin, len(logs), porcentaje)))
+		if lineasVisibles := m.lineasPorPagina(); len(logs) > lineasVisibles {
+			inicio, fin := m.paginaLogs(len(logs))
+			b.WriteString("\n")
+			porcentaje := 0
+			if len(logs)-lineasVisibles > 0 {
+				porcentaje = (m.logsScroll * 100) / (len(logs) - lineasVisibles)
 			}
+			b.WriteString(estiloAyuda.Render(T("logs.posicion", inicio+1, fin, len(logs), porcentaje)))
 		}
 	} else {
-		b.WriteString(estiloError.Render("No hay servidor activo"))
+		b.WriteString(estiloError.Render(T("logs.sin_servidor")))
 	}
 
 	b.WriteString("\n\n")
 	b.WriteString(strings.Repeat("─", 60))
 	b.WriteString("\n")
 
+	if m.buscandoLogs {
+		b.WriteString(m.inputBusqueda.View())
+		b.WriteString("\n")
+	} else if m.busquedaLogs != "" && servidor != nil {
+		total := len(coincidenciasLogs(servidor.Logs(), m.busquedaLogs))
+		b.WriteString(estiloInfo.Render(T("logs.busqueda", m.busquedaLogs, total,
+			ayudaTecla(Teclas.SiguienteCoincidencia), ayudaTecla(Teclas.AnteriorCoincidencia))))
+		b.WriteString("\n")
+	}
+
 	if m.mensaje != "" {
-		if strings.HasPrefix(m.mensaje, "✅") || strings.HasPrefix(m.mensaje, "🛑") {
+		if strings.HasPrefix(m.mensaje, "✅") || strings.HasPrefix(m.mensaje, "🛑") || strings.HasPrefix(m.mensaje, Icons.Success) {
 			b.WriteString(estiloExito.Render(m.mensaje))
 		} else {
 			b.WriteString(estiloError.Render(m.mensaje))
@@ -466,13 +621,24 @@ func (m Model) vistaLogs() string {
 	}
 
 	if m.modoSeleccion {
-		b.WriteString(estiloExito.Render("✓ MODO SELECCIÓN ACTIVO - Selecciona texto con el mouse"))
+		b.WriteString(estiloExito.Render("✓ " + T("logs.seleccion_activa")))
 		b.WriteString("\n")
 	}
 
-	b.WriteString(estiloInfo.Render(Icons.Terminal + " MODO INTERACTIVO - Las teclas se envían a Shopify CLI"))
-	b.WriteString("\n")
-	b.WriteString(estiloAyuda.Render("space/m: menú | j/k: scroll | v: seleccionar | Ctrl+Q: volver"))
+	if m.escribiendo {
+		b.WriteString(estiloExito.Render(Icons.Terminal + " " + T("logs.interactivo", ayudaTecla(Teclas.SoltarTeclado))))
+		return b.String()
+	}
+
+	if servidor != nil && servidor.Activo() {
+		b.WriteString(estiloInfo.Render(Icons.Terminal + " " + T("logs.escribir", ayudaTecla(Teclas.Escribir))))
+		b.WriteString("\n")
+	}
+	b.WriteString(estiloAyuda.Render(T("ayuda.logs",
+		ayudaTecla(Teclas.Menu), ayudaTecla(Teclas.Abajo), ayudaTecla(Teclas.Arriba),
+		ayudaTecla(Teclas.Buscar), ayudaTecla(Teclas.CopiarPagina), ayudaTecla(Teclas.CopiarTodo),
+		ayudaTecla(Teclas.ModoSeleccion), ayudaTecla(Teclas.Volver),
+	)))
 
 	return b.String()
 }
@@ -480,17 +646,17 @@ func (m Model) vistaLogs() string {
 func (m Model) vistaServidores() string {
 	var b strings.Builder
 
-	b.WriteString(estiloTitulo.Render(Icons.Logs + " Servidores Activos"))
+	b.WriteString(estiloTitulo.Render(Icons.Logs + " " + T("servidores.titulo")))
 	b.WriteString("\n\n")
 
-	servidores := ObtenerGestor().ObtenerServidoresActivos()
+	servidores := m.servidoresActivos()
 
 	if len(servidores) == 0 {
-		b.WriteString(estiloAyuda.Render("No hay servidores corriendo."))
+		b.WriteString(estiloAyuda.Render(T("servidores.vacio")))
 		b.WriteString("\n")
-		b.WriteString(estiloAyuda.Render("Inicia uno desde '" + Icons.Rocket + " Iniciar servidor'"))
+		b.WriteString(estiloAyuda.Render(T("servidores.vacio.ayuda", Icons.Rocket)))
 		b.WriteString("\n\n")
-		b.WriteString(estiloAyuda.Render("q: volver"))
+		b.WriteString(estiloAyuda.Render(T("ayuda.volver", ayudaTecla(Teclas.Volver))))
 		return estiloContenedor.Render(b.String())
 	}
 
@@ -505,14 +671,45 @@ func (m Model) vistaServidores() string {
 		}
 
 		b.WriteString(estiloLabel.Render(servidor.Tienda.Nombre))
+		if estado := servidor.Ciclo.Estado; estado != EstadoListo {
+			b.WriteString(estiloAviso.Render(" · " + estado.Texto()))
+		}
 		b.WriteString("\n")
 		b.WriteString(fmt.Sprintf("    🌐 %s\n", servidor.URL))
-		b.WriteString(fmt.Sprintf("    📍 Puerto: %d | ⏱️ Activo: %s\n", servidor.Puerto, duracion))
+		if servidor.URLRed != "" {
+			b.WriteString(fmt.Sprintf("    📶 %s\n", servidor.URLRed))
+		}
+		if estable := URLEstable(servidor.Tienda); estable != "" {
+			b.WriteString(fmt.Sprintf("    🔗 %s\n", estable))
+		}
+		if compartir := servidor.URLCompartir; compartir != "" {
+			b.WriteString(fmt.Sprintf("    👀 %s\n", compartir))
+		}
+		b.WriteString("    " + T("servidores.detalle", servidor.Puerto, duracion) + "\n")
+		salud := servidor.Salud
+		b.WriteString("    " + renderSalud(salud))
+		if !salud.UltimoExito.IsZero() && salud.Estado != saludSana {
+			b.WriteString(estiloDesc.Render(" · " + T("salud.ultimo_exito", formatearDuracion(salud.UltimoExito))))
+		}
+		b.WriteString("\n")
+		if recursos := servidor.Recursos; !recursos.Medido.IsZero() {
+			b.WriteString("    " + renderRecursos(recursos) + "\n")
+		}
+		for _, estado := range servidor.Acompanantes {
+			icono, estilo := Icons.ServerOn, estiloExito
+			if !estado.Activo {
+				icono, estilo = Icons.Server, estiloAyuda
+				if estado.Codigo != 0 {
+					icono, estilo = Icons.Error, estiloError
+				}
+			}
+			b.WriteString("    ↳ " + estiloLabel.Render(estado.Nombre) + " " + estilo.Render(icono+" "+estado.Texto()) + "\n")
+		}
 		b.WriteString("\n")
 	}
 
 	if m.mensaje != "" {
-		if strings.HasPrefix(m.mensaje, "✅") {
+		if strings.HasPrefix(m.mensaje, "✅") || strings.HasPrefix(m.mensaje, Icons.Success) {
 			b.WriteString(estiloExito.Render(m.mensaje))
 		} else {
 			b.WriteString(estiloError.Render(m.mensaje))
@@ -520,14 +717,156 @@ func (m Model) vistaServidores() string {
 		b.WriteString("\n")
 	}
 
-	b.WriteString(estiloAyuda.Render("s: detener | S: detener todos | q: volver"))
+	b.WriteString(estiloAyuda.Render(T("ayuda.servidores",
+		ayudaTecla(Teclas.Detener), ayudaTecla(Teclas.DetenerTodos), ayudaTecla(Teclas.Reiniciar), ayudaTecla(Teclas.Panel), ayudaTecla(Teclas.Volver),
+	)))
 
 	return estiloContenedor.Render(b.String())
 }
 
+func (m Model) vistaPanel() string {
+	servidores := m.servidoresActivos()
+	if len(servidores) == 0 {
+		return m.vistaServidores()
+	}
+
+	ancho, alto := m.ancho, m.alto
+	if ancho == 0 || alto == 0 {
+		ancho, alto = 80, 24
+	}
+
+	foco := m.panelFoco
+	if foco >= len(servidores) {
+		foco = len(servidores) - 1
+	}
+
+	titulo := Icons.Logs + " " + T("panel.titulo")
+	if m.panelCombinado {
+		titulo += " · " + T("panel.combinado")
+	}
+
+	var b strings.Builder
+	b.WriteString(estiloTitulo.Render(titulo))
+	b.WriteString("\n")
+
+	altoPaneles := alto - 5
+	if m.panelCombinado {
+		b.WriteString(m.renderLogsCombinados(servidores, ancho, altoPaneles))
+	} else {
+		columnas, filas := distribucionPanel(len(servidores), ancho)
+		altoPanel := altoPaneles / filas
+
+		var renglones []string
+		for fila := 0; fila < filas; fila++ {
+			var paneles []string
+			for columna := 0; columna < columnas; columna++ {
+				i := fila*columnas + columna
+				if i >= len(servidores) {
+					break
+				}
+				paneles = append(paneles, m.renderPanelServidor(servidores[i], i == foco, ancho/columnas, altoPanel))
+			}
+			renglones = append(renglones, lipgloss.JoinHorizontal(lipgloss.Top, paneles...))
+		}
+		b.WriteString(lipgloss.JoinVertical(lipgloss.Left, renglones...))
+	}
+
+	b.WriteString("\n")
+	b.WriteString(estiloAyuda.Render(T("ayuda.panel",
+		ayudaTecla(Teclas.FocoSiguiente), ayudaTecla(Teclas.FocoAnterior), ayudaTecla(Teclas.Combinar),
+		ayudaTecla(Teclas.Aceptar), ayudaTecla(Teclas.Volver),
+	)))
+
+	return b.String()
+}
+
+func (m Model) renderPanelServidor(servidor *InstantaneaServidor, enfocado bool, ancho, alto int) string {
+	borde := colorTema(Tema.Tenue)
+	if enfocado {
+		borde = colorTema(Tema.Primario)
+	}
+	estilo := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borde).
+		Width(ancho - 2).
+		Height(alto - 2)
+
+	encabezado := estiloLabel.Render(servidor.Tienda.Nombre) + estiloDesc.Render(fmt.Sprintf(" :%d", servidor.Puerto))
+	if sinLeer := m.erroresSinLeer(servidor); sinLeer > 0 && !enfocado {
+		encabezado += " " + estiloError.Render(Icons.Error+" "+T("panel.errores", sinLeer))
+	}
+
+	lineas := []string{ansi.Truncate(encabezado, ancho-2, "…")}
+	logs := servidor.Logs()
+	if visibles := alto - 3; len(logs) > visibles {
+		logs = logs[len(logs)-max(visibles, 0):]
+	}
+	for _, linea := range logs {
+		lineas = append(lineas, ansi.Truncate(renderLineaLog(linea, servidor.Tienda), ancho-2, "…"))
+	}
+
+	return estilo.Render(strings.Join(lineas, "\n"))
+}
+
+func (m Model) renderLogsCombinados(servidores []*InstantaneaServidor, ancho, alto int) string {
+	estilo := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(colorTema(Tema.Primario)).
+		Width(ancho - 2).
+		Height(alto - 2)
+
+	lineas := combinarLogs(servidores)
+	if visibles := alto - 2; len(lineas) > visibles {
+		lineas = lineas[len(lineas)-max(visibles, 0):]
+	}
+
+	var renglones []string
+	for _, linea := range lineas {
+		fuente := lipgloss.NewStyle().Foreground(colorFuente(linea.fuente)).Bold(true).Render(linea.servidor.Tienda.Nombre)
+		texto := estiloDesc.Render(linea.Momento.Format("15:04:05")) + " " + fuente + " " + renderLineaLog(linea.Texto, linea.servidor.Tienda)
+		renglones = append(renglones, ansi.Truncate(texto, ancho-2, "…"))
+	}
+
+	return estilo.Render(strings.Join(renglones, "\n"))
+}
+
+func renderLineaLog(linea string, tienda Tienda) string {
+	fuente, resto := separarFuenteLog(linea, tienda)
+
+	var texto string
+	if strings.Contains(resto, "\x1b[") {
+		texto = cerrarEstilos(resto)
+	} else if esLineaError(resto) {
+		texto = estiloError.Render(resto)
+	} else if strings.Contains(resto, "http://") || strings.Contains(resto, "https://") {
+		texto = estiloExito.Render(resto)
+	} else {
+		texto = resto
+	}
+
+	if fuente == "" {
+		return texto
+	}
+	return estiloLabel.Render("["+fuente+"]") + " " + texto
+}
+
+func renderSalud(salud Salud) string {
+	switch salud.Estado {
+	case saludSana:
+		return estiloExito.Render(Icons.Success + " " + salud.Texto())
+	case saludDegradada:
+		return estiloAviso.Render(Icons.Warning + " " + salud.Texto())
+	case saludCaida:
+		return estiloError.Render(Icons.Error + " " + salud.Texto())
+	}
+	return estiloDesc.Render(Icons.Info + " " + salud.Texto())
+}
+
 func formatearDuracion(inicio time.Time) string {
-	duracion := time.Since(inicio)
+	return formatearLapso(time.Since(inicio))
+}
 
+func formatearLapso(duracion time.Duration) string {
 	if duracion < time.Minute {
 		return fmt.Sprintf("%ds", int(duracion.Seconds()))
 	} else if duracion < time.Hour {
@@ -536,45 +875,18 @@ func formatearDuracion(inicio time.Time) string {
 	return fmt.Sprintf("%dh %dm", int(duracion.Hours()), int(duracion.Minutes())%60)
 }
 
-var estiloPopup = lipgloss.NewStyle().
-	Border(lipgloss.RoundedBorder()).
-	BorderForeground(lipgloss.Color("#7D56F4")).
-	Padding(1, 2).
-	Background(lipgloss.Color("#1a1a2e"))
-
-var estiloPopupTitulo = lipgloss.NewStyle().
-	Bold(true).
-	Foreground(lipgloss.Color("#7D56F4")).
-	MarginBottom(1)
-
 func (m Model) vistaPopup() string {
-	gestor := ObtenerGestor()
-	tieneServidor := gestor.TieneServidorActivo(m.tiendaParaDev.Nombre)
+	servidor := m.instantanea(m.tiendaParaDev.Nombre)
+	tieneServidor := servidor != nil && servidor.Activo()
 
-	var opciones []itemMenu
-	if tieneServidor {
-		opciones = []itemMenu{
-			{titulo: Icons.Stop + " Detener", desc: "Parar servidor", atajo: "s"},
-			{titulo: Icons.Download + " Pull", desc: "Bajar cambios", atajo: "p"},
-			{titulo: Icons.Upload + " Push", desc: "Subir cambios", atajo: "u"},
-			{titulo: Icons.Editor + " Editor", desc: "Abrir VS Code", atajo: "e"},
-			{titulo: Icons.Terminal + " Terminal", desc: "Abrir terminal", atajo: "t"},
-		}
-	} else {
-		opciones = []itemMenu{
-			{titulo: Icons.Download + " Pull", desc: "Bajar cambios", atajo: "p"},
-			{titulo: Icons.Upload + " Push", desc: "Subir cambios", atajo: "u"},
-			{titulo: Icons.Editor + " Editor", desc: "Abrir VS Code", atajo: "e"},
-			{titulo: Icons.Terminal + " Terminal", desc: "Abrir terminal", atajo: "t"},
-		}
-	}
+	opciones := crearOpcionesPopup(m.tiendaParaDev, tieneServidor, m.busquedaLogs != "")
 
 	var popupContent strings.Builder
-	popupContent.WriteString(estiloPopupTitulo.Render(Icons.Rocket + " Acciones"))
+	popupContent.WriteString(estiloPopupTitulo.Render(Icons.Rocket + " " + T("popup.titulo")))
 	popupContent.WriteString("\n\n")
 
 	for i, op := range opciones {
-		atajo := estiloAtajo.Render("[" + strings.ToUpper(op.atajo) + "]")
+		atajo := estiloAtajo.Render("[" + op.atajo + "]")
 		cursor := "  "
 		if i == m.popupIndex {
 			cursor = "> "
@@ -585,18 +897,20 @@ func (m Model) vistaPopup() string {
 	}
 
 	popupContent.WriteString("\n")
-	popupContent.WriteString(estiloAyuda.Render("j/k navegar | l/enter ejecutar | space cerrar"))
+	popupContent.WriteString(estiloAyuda.Render(T("ayuda.popup",
+		ayudaTecla(Teclas.Abajo), ayudaTecla(Teclas.Arriba),
+		ayudaTecla(Teclas.Seleccionar), ayudaTecla(Teclas.Menu),
+	)))
 
 	popup := estiloPopup.Render(popupContent.String())
 
-	servidor := gestor.ObtenerServidor(m.tiendaParaDev.Nombre)
 	var header strings.Builder
-	if servidor != nil && servidor.Activo {
+	if tieneServidor {
 		header.WriteString(estiloExito.Render(Icons.ServerOn + " " + m.tiendaParaDev.Nombre))
 		header.WriteString(" - ")
 		header.WriteString(estiloInfo.Render(servidor.URL))
 	} else {
-		header.WriteString(estiloError.Render(Icons.Stop + " " + m.tiendaParaDev.Nombre + " - Detenido"))
+		header.WriteString(estiloError.Render(Icons.Stop + " " + m.tiendaParaDev.Nombre + " - " + T("popup.detenido")))
 	}
 	header.WriteString("\n")
 	header.WriteString(strings.Repeat("─", 50))
@@ -621,24 +935,24 @@ func (m Model) vistaPopup() string {
 func (m Model) vistaLogsBase() string {
 	var b strings.Builder
 
-	servidor := ObtenerGestor().ObtenerServidor(m.tiendaParaDev.Nombre)
+	servidor := m.instantanea(m.tiendaParaDev.Nombre)
 
-	if servidor != nil && servidor.Activo {
-		b.WriteString(estiloExito.Render(Icons.ServerOn + " " + m.tiendaParaDev.Nombre + " - Servidor activo"))
+	if servidor != nil && servidor.Activo() {
+		b.WriteString(estiloExito.Render(Icons.ServerOn + " " + m.tiendaParaDev.Nombre + " - " + T("servidor.activo")))
 		b.WriteString("\n")
 		b.WriteString(estiloInfo.Render("   " + servidor.URL))
 	} else {
-		b.WriteString(estiloError.Render(Icons.Stop + " " + m.tiendaParaDev.Nombre + " - Servidor detenido"))
+		b.WriteString(estiloError.Render(Icons.Stop + " " + m.tiendaParaDev.Nombre + " - " + T("servidor.detenido")))
 	}
 	b.WriteString("\n")
 	b.WriteString(strings.Repeat("─", 60))
 	b.WriteString("\n\n")
 
 	if servidor != nil {
-		logs := servidor.ObtenerLogs()
+		logs := servidor.Logs()
 
 		if len(logs) == 0 {
-			b.WriteString(estiloAyuda.Render("Esperando logs del servidor..."))
+			b.WriteString(estiloAyuda.Render(T("logs.esperando")))
 			b.WriteString("\n")
 		} else {
 			lineasVisibles := 15
@@ -660,19 +974,12 @@ func (m Model) vistaLogsBase() string {
 			}
 
 			for i := inicio; i < fin; i++ {
-				linea := logs[i]
-				if strings.Contains(linea, "error") || strings.Contains(linea, "Error") {
-					b.WriteString(estiloError.Render(linea))
-				} else if strings.Contains(linea, "http://") || strings.Contains(linea, "https://") {
-					b.WriteString(estiloExito.Render(linea))
-				} else {
-					b.WriteString(linea)
-				}
+				b.WriteString(renderLineaLog(logs[i], servidor.Tienda))
 				b.WriteString("\n")
 			}
 		}
 	} else {
-		b.WriteString(estiloError.Render("No hay servidor activo"))
+		b.WriteString(estiloError.Render(T("logs.sin_servidor")))
 	}
 
 	return b.String()